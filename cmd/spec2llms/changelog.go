@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/generator"
+	"github.com/mdwit/spec2llms/internal/parser"
+	"github.com/mdwit/spec2llms/internal/specdiff"
+	"github.com/spf13/cobra"
+)
+
+var (
+	changelogSince  string
+	changelogPrint  bool
+	changelogReport string
+	failOnBreaking  bool
+)
+
+// changelogReportFile — JSON-отчёт об изменениях для CI
+type changelogReportFile struct {
+	Since    string            `json:"since"`
+	Breaking int               `json:"breaking"`
+	Changes  []specdiff.Change `json:"changes"`
+}
+
+// newChangelogCmd создаёт команду changelog: изменения API с ревизии git в changelog.txt рядом с llms.txt.
+// llms.txt не меняется: ссылку на журнал добавляет генерация, поэтому check не видит расхождений
+func newChangelogCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "changelog [source]",
+		Short:   "Write the API changes since a git revision of the spec to changelog.txt, linked from llms.txt",
+		Example: `  spec2llms changelog api.yaml --since v1.2.0 -o ./llms`,
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    runChangelog,
+	}
+	cmd.Flags().StringVarP(&cfgFile, "config", "c", "", "config file (spec2llms.json)")
+	cmd.Flags().StringVarP(&output, "output", "o", "./llms", "output directory for changelog.txt")
+	cmd.Flags().StringVar(&changelogSince, "since", "", "git revision (tag, branch or commit) of the previous spec")
+	cmd.Flags().BoolVar(&changelogPrint, "print", false, "print the changelog instead of writing changelog.txt")
+	cmd.Flags().BoolVar(&failOnBreaking, "fail-on-breaking", false, "exit 1 if any change is breaking (removed operation, new required parameter, narrowed enum...)")
+	cmd.Flags().StringVar(&changelogReport, "report", "", "write a JSON report of every change to this file")
+	cmd.Flags().BoolVar(&skipValidation, "skip-validation", false, "skip OpenAPI spec validation")
+	cmd.Flags().BoolVar(&offline, "offline", false, "forbid all network access (URL sources, remote refs)")
+	cmd.MarkFlagRequired("since")
+	return cmd
+}
+
+func runChangelog(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig(args)
+	if err != nil {
+		return usageError(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return usageError(err)
+	}
+
+	current, err := parser.ParseContext(cmd.Context(), cfg.Source, &parser.ParseOptions{
+		SkipValidation: cfg.SkipValidation,
+		Offline:        cfg.Offline,
+		Preprocess:     preParseHooks(cmd.Context(), cfg),
+	})
+	if err != nil {
+		return parseError(cfg, err)
+	}
+	previous, err := parseRevision(cmd.Context(), cfg.Source, changelogSince, cfg.Offline)
+	if err != nil {
+		return err
+	}
+
+	changes := specdiff.Compare(previous, current)
+	if changelogReport != "" {
+		if err := writeChangelogReport(changelogReport, changes); err != nil {
+			return err
+		}
+	}
+
+	section := specdiff.Changelog(changes, changelogSince)
+	if changelogPrint {
+		fmt.Fprint(cmd.OutOrStdout(), section)
+	} else {
+		path := filepath.Join(cfg.Output, generator.ChangelogFile)
+		if err := writeChangelog(path, section); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d changes since %s to %s\n", len(changes), changelogSince, path)
+		if !linksChangelog(cfg.Output) {
+			fmt.Fprintf(cmd.OutOrStdout(), "Regenerate the docs to link it from llms.txt\n")
+		}
+	}
+
+	if failOnBreaking && specdiff.HasBreaking(changes) {
+		breaking := 0
+		for _, c := range changes {
+			if c.Breaking {
+				fmt.Fprintf(os.Stderr, "breaking: %s: %s\n", c.Operation, c.Message)
+				breaking++
+			}
+		}
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d breaking changes since %s", breaking, changelogSince)
+	}
+	return nil
+}
+
+// writeChangelogReport записывает JSON-отчёт со всеми изменениями и числом ломающих
+func writeChangelogReport(path string, changes []specdiff.Change) error {
+	report := changelogReportFile{Since: changelogSince, Changes: changes}
+	if report.Changes == nil {
+		report.Changes = []specdiff.Change{}
+	}
+	for _, c := range changes {
+		if c.Breaking {
+			report.Breaking++
+		}
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// parseRevision читает спецификацию из ревизии git и разбирает её без валидации:
+// старые версии не обязаны проходить текущие проверки
+func parseRevision(ctx context.Context, source, rev string, offline bool) (*parser.API, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return nil, fmt.Errorf("changelog needs a spec file tracked in git, got URL %s", source)
+	}
+	dir, name := filepath.Split(source)
+	if dir == "" {
+		dir = "."
+	}
+
+	var stderr bytes.Buffer
+	show := exec.CommandContext(ctx, "git", "-C", dir, "show", rev+":./"+name)
+	show.Stderr = &stderr
+	data, err := show.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s at %s: %s", source, rev, strings.TrimSpace(stderr.String()))
+	}
+
+	// Временный файл рядом с исходным, чтобы относительные $ref разрешались так же
+	tmp, err := os.CreateTemp(dir, ".spec2llms-*"+filepath.Ext(name))
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	tmp.Close()
+
+	api, err := parser.ParseContext(ctx, tmp.Name(), &parser.ParseOptions{SkipValidation: true, Offline: offline})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec at %s: %w", rev, err)
+	}
+	return api, nil
+}
+
+// writeChangelog записывает журнал изменений, заменяя журнал прошлого запуска
+func writeChangelog(path, section string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(section), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// linksChangelog сообщает, что llms.txt в output уже ссылается на журнал изменений
+func linksChangelog(output string) bool {
+	data, err := os.ReadFile(filepath.Join(output, "llms.txt"))
+	return err == nil && strings.Contains(string(data), "](./"+generator.ChangelogFile+")")
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mdwit/spec2llms/internal/generator"
+	"github.com/mdwit/spec2llms/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+// newCheckCmd создаёт команду check: ненулевой код выхода, если сгенерированная документация устарела
+func newCheckCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check [source]",
+		Short: "Exit 1 if the output directory differs from freshly generated docs",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runCheck,
+	}
+	cmd.Flags().StringVarP(&cfgFile, "config", "c", "", "config file (spec2llms.json)")
+	cmd.Flags().StringVarP(&output, "output", "o", "./llms", "output directory")
+	addLanguageFlag(cmd)
+	cmd.Flags().StringVar(&audience, "audience", "", "include only operations whose x-audience lists this audience")
+	cmd.Flags().BoolVar(&versioned, "versioned", false, "compare against <output>/<api-version>/")
+	cmd.Flags().BoolVar(&skipValidation, "skip-validation", false, "skip OpenAPI spec validation")
+	cmd.Flags().BoolVar(&bestEffort, "best-effort", false, "skip only the paths and operations that fail validation, reporting them as warnings")
+	cmd.Flags().BoolVar(&offline, "offline", false, "forbid all network access (URL sources, remote refs)")
+	cmd.Flags().BoolVar(&ghAnnotations, "github-annotations", false, "print validation errors as GitHub Actions workflow commands")
+	return cmd
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig(args)
+	if err != nil {
+		return usageError(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return usageError(err)
+	}
+
+	api, err := parser.ParseContext(cmd.Context(), cfg.Source, &parser.ParseOptions{
+		SkipValidation: cfg.SkipValidation,
+		BestEffort:     cfg.BestEffort,
+		Offline:        cfg.Offline,
+		Preprocess:     preParseHooks(cmd.Context(), cfg),
+	})
+	if err != nil {
+		return parseError(cfg, err)
+	}
+	printParseWarnings(cfg, api)
+
+	if _, err := versionedOutput(cfg, api); err != nil {
+		return err
+	}
+
+	files, err := generator.New(cfg, api).Render()
+	if err != nil {
+		return fmt.Errorf("failed to generate: %w", err)
+	}
+	changes, err := generator.Changes(cfg.Output, files)
+	if err != nil {
+		return fmt.Errorf("failed to compare with %s: %w", cfg.Output, err)
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s is up to date\n", cfg.Output)
+		return nil
+	}
+	for _, c := range changes {
+		fmt.Fprintf(cmd.OutOrStdout(), "  %-6s %s\n", c.Kind, c.Path)
+	}
+	cmd.SilenceUsage = true
+	return fmt.Errorf("%s is stale: %d files differ, regenerate with spec2llms", cfg.Output, len(changes))
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mdwit/spec2llms/internal/config"
+	"github.com/mdwit/spec2llms/internal/generator"
+	"github.com/mdwit/spec2llms/internal/textdiff"
+)
+
+// dryRun генерирует всё в память и печатает, какие файлы были бы созданы, изменены или удалены,
+// а с --diff — unified diff каждого изменения. Директория вывода не изменяется
+func dryRun(cfg *config.Config, gen *generator.Generator) error {
+	files, err := gen.Render()
+	if err != nil {
+		return fmt.Errorf("failed to generate: %w", err)
+	}
+	changes, err := generator.Changes(cfg.Output, files)
+	if err != nil {
+		return fmt.Errorf("failed to compare with %s: %w", cfg.Output, err)
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(console, "Dry run: %s is up to date\n", cfg.Output)
+		return nil
+	}
+	fmt.Fprintf(console, "Dry run: %d files would change in %s\n", len(changes), cfg.Output)
+	for _, c := range changes {
+		fmt.Fprintf(console, "  %-6s %s\n", c.Kind, c.Path)
+	}
+
+	if showDiff {
+		for _, c := range changes {
+			oldName, newName := "a/"+c.Path, "b/"+c.Path
+			switch c.Kind {
+			case generator.ChangeCreate:
+				oldName = "/dev/null"
+			case generator.ChangeDelete:
+				newName = "/dev/null"
+			}
+			fmt.Fprint(console, textdiff.Unified(oldName, newName, string(c.Old), string(c.New)))
+		}
+	}
+	return nil
+}
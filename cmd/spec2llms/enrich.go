@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mdwit/spec2llms/internal/enrich"
+	"github.com/mdwit/spec2llms/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+// Значения по умолчанию команды enrich
+const (
+	defaultOverridesFile   = "overrides.yaml"
+	defaultEnrichCache     = ".spec2llms-enrich-cache.json"
+	defaultEnrichAPIKeyEnv = "OPENAI_API_KEY"
+)
+
+var (
+	llmEndpoint  string
+	llmModel     string
+	llmMinLength int
+	llmCache     string
+	llmAPIKeyEnv string
+)
+
+// newEnrichCmd создаёт команду enrich: переписывает краткие описания операций с помощью LLM
+// и записывает их в файл overrides, который генератор объединяет со спецификацией
+func newEnrichCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "enrich [source]",
+		Short:   "Rewrite terse or missing operation descriptions with an LLM into an overrides file",
+		Example: `  spec2llms enrich api.yaml --llm-endpoint https://api.openai.com/v1 --model gpt-4o-mini --overrides overrides.yaml`,
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    runEnrich,
+	}
+	cmd.Flags().StringVarP(&cfgFile, "config", "c", "", "config file (spec2llms.json)")
+	cmd.Flags().StringVar(&overridesFile, "overrides", "", "overrides file to write (default overrides.yaml)")
+	cmd.Flags().StringVar(&llmEndpoint, "llm-endpoint", "", "base URL of an OpenAI-compatible API (e.g. https://api.openai.com/v1)")
+	cmd.Flags().StringVar(&llmModel, "model", "", "model name (e.g. gpt-4o-mini)")
+	cmd.Flags().StringVar(&llmAPIKeyEnv, "api-key-env", "", "environment variable holding the API key (default OPENAI_API_KEY)")
+	cmd.Flags().IntVar(&llmMinLength, "min-length", 0, "rewrite descriptions shorter than this many characters (default 40)")
+	cmd.Flags().StringVar(&llmCache, "cache", "", "cache file for model responses (default .spec2llms-enrich-cache.json)")
+	cmd.Flags().BoolVar(&skipValidation, "skip-validation", false, "skip OpenAPI spec validation")
+	cmd.Flags().BoolVar(&offline, "offline", false, "forbid all network access (URL sources, remote refs)")
+	return cmd
+}
+
+func runEnrich(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig(args)
+	if err != nil {
+		return usageError(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return usageError(err)
+	}
+	if cfg.Offline {
+		return usageError(errors.New("enrich calls an LLM endpoint and cannot run with --offline"))
+	}
+
+	opts := enrich.Options{
+		Endpoint:  cfg.Enrich.Endpoint,
+		Model:     cfg.Enrich.Model,
+		MinLength: cfg.Enrich.MinLength,
+		CacheFile: cfg.Enrich.Cache,
+	}
+	if llmEndpoint != "" {
+		opts.Endpoint = llmEndpoint
+	}
+	if llmModel != "" {
+		opts.Model = llmModel
+	}
+	if llmMinLength > 0 {
+		opts.MinLength = llmMinLength
+	}
+	if llmCache != "" {
+		opts.CacheFile = llmCache
+	}
+	if opts.CacheFile == "" {
+		opts.CacheFile = defaultEnrichCache
+	}
+	keyEnv := cfg.Enrich.APIKeyEnv
+	if llmAPIKeyEnv != "" {
+		keyEnv = llmAPIKeyEnv
+	}
+	if keyEnv == "" {
+		keyEnv = defaultEnrichAPIKeyEnv
+	}
+	opts.APIKey = os.Getenv(keyEnv)
+
+	path := cfg.Overrides
+	if path == "" {
+		path = defaultOverridesFile
+	}
+	// Правки, уже записанные в файл (в том числе вручную), не перезаписываются
+	overrides := &parser.Overrides{}
+	if _, err := os.Stat(path); err == nil {
+		if overrides, err = parser.LoadOverrides(path); err != nil {
+			return err
+		}
+	}
+	skip := make(map[string]bool)
+	for key, o := range overrides.Operations {
+		if o.Description != "" {
+			skip[key] = true
+		}
+	}
+
+	api, err := parser.ParseContext(cmd.Context(), cfg.Source, &parser.ParseOptions{
+		SkipValidation: cfg.SkipValidation,
+		Preprocess:     preParseHooks(cmd.Context(), cfg),
+	})
+	if err != nil {
+		return parseError(cfg, err)
+	}
+
+	result, err := enrich.Run(cmd.Context(), api, opts, skip)
+	if err != nil {
+		return err
+	}
+	// В файл дописываются только новые описания: комментарии и ручные правки остаются как были
+	if err := parser.SaveDescriptions(path, result.Descriptions); err != nil {
+		return err
+	}
+
+	fmt.Printf("Enriched %d descriptions (%d from cache) in %s\n", len(result.Descriptions), result.Cached, path)
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// Коды выхода: по ним оркестраторы (Docker, Kubernetes Jobs, CI) отличают ошибку конфигурации,
+// которую повтор не исправит, от проблемы спецификации или сбоя генерации
+const (
+	exitOK          = 0
+	exitFailure     = 1   // генерация не удалась или документация устарела (check, verify)
+	exitUsage       = 2   // неверные флаги или конфиг
+	exitSpec        = 3   // спецификация не найдена, не OpenAPI 3.x или не проходит валидацию
+	exitInterrupted = 130 // прервано Ctrl+C или SIGTERM
+)
+
+// exitError задаёт код выхода для ошибки, не меняя её текст
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// usageError помечает ошибку флагов или конфига кодом exitUsage
+func usageError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: exitUsage, err: err}
+}
+
+// exitCode возвращает код выхода для ошибки команды
+func exitCode(ctx context.Context, err error) int {
+	var exit *exitError
+	switch {
+	case err == nil:
+		return exitOK
+	case ctx.Err() != nil:
+		return exitInterrupted
+	case errors.As(err, &exit):
+		return exit.code
+	case errors.Is(err, parser.ErrSpecNotFound), errors.Is(err, parser.ErrUnsupportedVersion), errors.Is(err, parser.ErrInvalidSpec):
+		return exitSpec
+	}
+	return exitFailure
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// defaultConfigFile — имя конфига, который создаёт init
+const defaultConfigFile = "spec2llms.json"
+
+// workflowFile — путь GitHub workflow, который создаёт init --github-workflow
+var workflowFile = filepath.Join(".github", "workflows", "llms.yml")
+
+// specCandidates — типичные имена спецификации, предлагаемые init по умолчанию
+var specCandidates = []string{
+	"openapi.yaml", "openapi.yml", "openapi.json",
+	"api/openapi.yaml", "api/openapi.yml", "api/openapi.json",
+	"docs/openapi.yaml", "docs/openapi.json",
+	"swagger.yaml", "swagger.json",
+}
+
+// Флаги init свои: значения по умолчанию общих переменных (cfgFile, groupBy) поменяли бы
+// поведение основной команды
+var (
+	initConfig       string
+	initOutput       string
+	initLanguage     string
+	initGroupBy      string
+	initExcludePaths []string
+	initExcludeTags  []string
+	initWorkflow     bool
+	initForce        bool
+	initYes          bool
+)
+
+// starterConfig — поля, которые init записывает в конфиг; остальное остаётся по умолчанию
+type starterConfig struct {
+	Source   string         `json:"source"`
+	Output   string         `json:"output"`
+	Language string         `json:"language"`
+	GroupBy  string         `json:"groupBy"`
+	Filter   *starterFilter `json:"filter,omitempty"`
+}
+
+// starterFilter — исключения операций стартового конфига без пустых списков включений
+type starterFilter struct {
+	ExcludePaths []string `json:"excludePaths,omitempty"`
+	ExcludeTags  []string `json:"excludeTags,omitempty"`
+}
+
+// newInitCmd создаёт команду init: стартовый spec2llms.json и, по желанию, GitHub workflow
+func newInitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init [source]",
+		Short: "Write a starter spec2llms.json (and optionally a GitHub workflow)",
+		Long: `init writes a starter spec2llms.json. In a terminal it asks for every value not given
+by flags; with --yes or without a terminal it uses the flags and defaults as is.`,
+		Example: `  spec2llms init
+  spec2llms init api/openapi.yaml -o ./public/llms --exclude-path /internal/** --github-workflow --yes`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runInit,
+	}
+	cmd.Flags().StringVarP(&initConfig, "config", "c", defaultConfigFile, "config file to write")
+	cmd.Flags().StringVarP(&initOutput, "output", "o", "./llms", "output directory")
+	cmd.Flags().StringVarP(&initLanguage, "lang", "l", config.LanguageEN, "output language (en, ru, auto)")
+	cmd.Flags().StringVar(&initGroupBy, "group-by", config.GroupByTag, "endpoint grouping (tag, path, x-group, none)")
+	cmd.Flags().StringSliceVar(&initExcludePaths, "exclude-path", nil, "exclude operations by path pattern (e.g. /internal/**)")
+	cmd.Flags().StringSliceVar(&initExcludeTags, "exclude-tag", nil, "exclude operations by tag")
+	cmd.Flags().BoolVar(&initWorkflow, "github-workflow", false, "also write "+filepath.ToSlash(workflowFile)+" that fails pull requests with stale docs")
+	cmd.Flags().BoolVar(&initForce, "force", false, "overwrite existing files")
+	cmd.Flags().BoolVarP(&initYes, "yes", "y", false, "do not ask questions, use flags and defaults")
+	return cmd
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	starter := starterConfig{Source: detectSpec(), Output: initOutput, Language: initLanguage, GroupBy: initGroupBy}
+	if len(args) > 0 {
+		starter.Source = args[0]
+	}
+	filter := starterFilter{ExcludePaths: initExcludePaths, ExcludeTags: initExcludeTags}
+	writeWorkflow := initWorkflow
+
+	out := cmd.OutOrStdout()
+	if in := cmd.InOrStdin(); !initYes && interactive(in) {
+		ask := newPrompter(in, out)
+		flags := cmd.Flags()
+		if len(args) == 0 {
+			starter.Source = ask.String("OpenAPI spec (file or URL)", starter.Source)
+		}
+		if !flags.Changed("output") {
+			starter.Output = ask.String("Output directory", starter.Output)
+		}
+		if !flags.Changed("lang") {
+			starter.Language = ask.String("Language of headings (en, ru, auto)", starter.Language)
+		}
+		if !flags.Changed("group-by") {
+			starter.GroupBy = ask.String("Group endpoints by (tag, path, x-group, none)", starter.GroupBy)
+		}
+		if !flags.Changed("exclude-path") {
+			filter.ExcludePaths = ask.List("Exclude paths, comma-separated (e.g. /internal/**)")
+		}
+		if !flags.Changed("exclude-tag") {
+			filter.ExcludeTags = ask.List("Exclude tags, comma-separated")
+		}
+		if !flags.Changed("github-workflow") {
+			writeWorkflow = ask.Bool("Add a GitHub workflow that checks the docs are up to date?", false)
+		}
+		if ask.err != nil {
+			return ask.err
+		}
+	}
+	if len(filter.ExcludePaths) > 0 || len(filter.ExcludeTags) > 0 {
+		starter.Filter = &filter
+	}
+
+	// Проверяем значения так же, как при генерации, чтобы стартовый конфиг сразу работал
+	check := config.Config{Source: starter.Source, Language: starter.Language, GroupBy: starter.GroupBy}
+	if err := check.Validate(); err != nil {
+		if errors.Is(err, config.ErrSourceRequired) {
+			return usageError(fmt.Errorf("%w: pass the spec as an argument, e.g. spec2llms init openapi.yaml", err))
+		}
+		return usageError(err)
+	}
+
+	data, err := json.MarshalIndent(starter, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeNewFile(initConfig, append(data, '\n')); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Wrote %s\n", initConfig)
+
+	if writeWorkflow {
+		if err := os.MkdirAll(filepath.Dir(workflowFile), 0755); err != nil {
+			return err
+		}
+		if err := writeNewFile(workflowFile, []byte(starterWorkflow(initConfig, starter.Source))); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Wrote %s\n", workflowFile)
+	}
+
+	fmt.Fprintf(out, "Generate the docs with: spec2llms -c %s\n", initConfig)
+	return nil
+}
+
+// detectSpec возвращает первую найденную спецификацию с типичным именем или пустую строку
+func detectSpec() string {
+	for _, name := range specCandidates {
+		if info, err := os.Stat(filepath.FromSlash(name)); err == nil && !info.IsDir() {
+			return name
+		}
+	}
+	return ""
+}
+
+// interactive сообщает, что init может задавать вопросы: ответы читаются из терминала или из reader,
+// переданного вместо stdin (cmd.SetIn). Перенаправленный в файл или канал stdin вопросов не получает
+func interactive(in io.Reader) bool {
+	if f, ok := in.(*os.File); ok {
+		return isTerminal(f)
+	}
+	return true
+}
+
+// writeNewFile записывает файл, не перезаписывая существующий без --force
+func writeNewFile(path string, data []byte) error {
+	if !initForce {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists, use --force to overwrite it", path)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// starterWorkflow возвращает GitHub workflow, который валит pull request с устаревшей документацией.
+// Запускается при изменении спецификации или конфига
+func starterWorkflow(configPath, source string) string {
+	configPath = filepath.ToSlash(configPath)
+	paths := "      - " + configPath + "\n"
+	if source != "" && !strings.Contains(source, "://") {
+		paths = "      - " + filepath.ToSlash(source) + "\n" + paths
+	}
+	return `name: llms.txt
+
+on:
+  pull_request:
+    paths:
+` + paths + `      - .github/workflows/llms.yml
+
+jobs:
+  check:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+
+      - uses: actions/setup-go@v5
+        with:
+          go-version: stable
+
+      - name: Install spec2llms
+        run: go install github.com/mdwitr0/spec2llms/cmd/spec2llms@latest
+
+      - name: Check llms.txt is up to date
+        run: spec2llms check -c ` + configPath + ` --github-annotations
+`
+}
+
+// prompter задаёт вопросы init в терминале. Первая ошибка чтения запоминается,
+// последующие вопросы возвращают значения по умолчанию
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	err error
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{in: bufio.NewReader(in), out: out}
+}
+
+// String спрашивает значение; пустой ответ оставляет def
+func (p *prompter) String(question, def string) string {
+	if def != "" {
+		question += " [" + def + "]"
+	}
+	if answer := p.ask(question); answer != "" {
+		return answer
+	}
+	return def
+}
+
+// List спрашивает список через запятую; пустой ответ — пустой список
+func (p *prompter) List(question string) []string {
+	var items []string
+	for item := range strings.SplitSeq(p.ask(question), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Bool спрашивает да/нет; пустой ответ оставляет def
+func (p *prompter) Bool(question string, def bool) bool {
+	hint := " [y/N]"
+	if def {
+		hint = " [Y/n]"
+	}
+	switch strings.ToLower(p.ask(question + hint)) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	}
+	return def
+}
+
+func (p *prompter) ask(question string) string {
+	if p.err != nil {
+		return ""
+	}
+	fmt.Fprint(p.out, question+": ")
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err != io.EOF {
+			p.err = err
+		}
+		return ""
+	}
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mdwit/spec2llms/internal/lint"
+	"github.com/mdwit/spec2llms/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+// newLintCmd создаёт команду lint: отчёт о качестве спецификации с местами замечаний
+func newLintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint [source]",
+		Short: "Report spec quality issues that degrade generated docs",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runLint,
+	}
+	cmd.Flags().StringVarP(&cfgFile, "config", "c", "", "config file (spec2llms.json)")
+	cmd.Flags().StringVar(&lintRuleset, "ruleset", "", "YAML ruleset: enable/disable rules, severities and custom regex rules")
+	cmd.Flags().BoolVar(&skipValidation, "skip-validation", false, "skip OpenAPI spec validation")
+	cmd.Flags().BoolVar(&bestEffort, "best-effort", false, "skip only the paths and operations that fail validation, reporting them as warnings")
+	cmd.Flags().BoolVar(&offline, "offline", false, "forbid all network access (URL sources, remote refs)")
+	cmd.Flags().BoolVar(&ghAnnotations, "github-annotations", false, "print findings as GitHub Actions ::error/::warning workflow commands")
+	return cmd
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig(args)
+	if err != nil {
+		return usageError(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return usageError(err)
+	}
+
+	var rs *lint.Ruleset
+	if cfg.LintRuleset != "" {
+		if rs, err = lint.LoadRuleset(cfg.LintRuleset); err != nil {
+			return usageError(err)
+		}
+	}
+
+	api, err := parser.ParseContext(cmd.Context(), cfg.Source, &parser.ParseOptions{
+		SkipValidation: cfg.SkipValidation,
+		BestEffort:     cfg.BestEffort,
+		Offline:        cfg.Offline,
+		Preprocess:     preParseHooks(cmd.Context(), cfg),
+	})
+	if err != nil {
+		return parseError(cfg, err)
+	}
+	printParseWarnings(cfg, api)
+
+	findings := lint.Lint(api, rs)
+	for _, f := range findings {
+		if ghAnnotations {
+			fmt.Println(f.GitHubAnnotation(cfg.Source))
+		} else {
+			fmt.Println(f.Format(cfg.Source))
+		}
+	}
+	fmt.Printf("%d issues found\n", len(findings))
+
+	if lint.HasErrors(findings) {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("lint found errors")
+	}
+	return nil
+}
@@ -1,11 +1,19 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/mdwit/spec2llms/internal/config"
 	"github.com/mdwit/spec2llms/internal/generator"
+	"github.com/mdwit/spec2llms/internal/hooks"
+	"github.com/mdwit/spec2llms/internal/lint"
 	"github.com/mdwit/spec2llms/internal/parser"
 	"github.com/spf13/cobra"
 )
@@ -17,12 +25,73 @@ var (
 	output         string
 	title          string
 	baseURL        string
+	server         string
 	docsBaseURL    string
 	language       string
+	groupBy        string
+	sortBy         string
+	format         string
+	indexLayout    string
+	fileExtension  string
+	endpointsDir   string
+	tokenizer      string
+	renderer       string
+	cacheDir       string
+	audience       string
+	workflows      string
+	lintRuleset    string
+	overridesFile  string
+	examples       []string
+	contentTypes   []string
+	excludePaths   []string
+	excludeTags    []string
+	maxDepth       int
+	maxProps       int
+	maxDescLength  int
 	skipValidation bool
+	offline        bool
+	sharedFrags    bool
+	sectionMarks   bool
+	excludedReport bool
+	searchIndex    bool
+	schemaGlossary bool
+	publishMfst    bool
+	incremental    bool
+	dryRunMode     bool
+	showDiff       bool
+	curlScripts    bool
+	allContentType bool
+	ghAnnotations  bool
+	noAutoSummary  bool
+	versioned      bool
+	bestEffort     bool
+	profile        string
+	allProfiles    bool
+	reportFormat   string
+	showTokens     bool
+	publishURL     string
+	publishHeader  string
+	publishDelete  bool
 )
 
 func main() {
+	// Ctrl+C отменяет контекст команды: загрузка спецификации прерывается, генерация останавливается
+	// перед записью следующего файла; повторный Ctrl+C завершает процесс сразу
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	if err := newRootCmd().ExecuteContext(ctx); err != nil {
+		os.Exit(exitCode(ctx, err))
+	}
+}
+
+// newRootCmd создаёт корневую команду со всеми подкомандами. Регистрация флагов сбрасывает
+// их переменные к значениям по умолчанию
+func newRootCmd() *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:     "spec2llms [source]",
 		Short:   "Generate llms.txt from OpenAPI specification",
@@ -33,61 +102,327 @@ func main() {
 	}
 
 	rootCmd.Flags().StringVarP(&cfgFile, "config", "c", "", "config file (spec2llms.json)")
+	rootCmd.Flags().StringVar(&profile, "profile", "", "generate the named profile from the config file")
+	rootCmd.Flags().BoolVar(&allProfiles, "all-profiles", false, "generate every profile from the config file")
+	rootCmd.Flags().StringVar(&reportFormat, "report", "", "run summary: text (default) or json, printed to stdout with progress messages moved to stderr")
 	rootCmd.Flags().StringVarP(&output, "output", "o", "./llms", "output directory")
 	rootCmd.Flags().StringVarP(&title, "title", "t", "", "API title")
 	rootCmd.Flags().StringVarP(&baseURL, "base-url", "b", "", "base URL for API")
+	rootCmd.Flags().StringVar(&server, "server", "", "server from spec used in examples (index, description or URL)")
 	rootCmd.Flags().StringVar(&docsBaseURL, "docs-base-url", "", "base URL for documentation links (e.g., https://api.example.com)")
-	rootCmd.Flags().StringVarP(&language, "lang", "l", "en", "output language (en, ru)")
+	addLanguageFlag(rootCmd)
+	rootCmd.Flags().IntVar(&maxDepth, "max-schema-depth", 0, "depth of nested objects expanded in schemas (default 4)")
+	rootCmd.Flags().IntVar(&maxProps, "max-properties-per-object", 0, "max fields shown per object in schema docs (0 = all)")
+	rootCmd.Flags().IntVar(&maxDescLength, "max-description-length", 0, "truncate operation descriptions to this many characters (0 = no limit)")
+	rootCmd.Flags().StringSliceVar(&examples, "examples", nil, "example formats (curl, powershell, httpie)")
+	rootCmd.Flags().StringSliceVar(&contentTypes, "content-type", nil, "preferred request body content types, in order (default application/json)")
+	rootCmd.Flags().BoolVar(&allContentType, "all-content-types", false, "render a separate request example for every body content type")
+	rootCmd.Flags().StringVar(&sortBy, "sort", "", "endpoint order (path, method, operationId, spec-order)")
+	rootCmd.Flags().StringVar(&format, "format", "", "output format (text, html, json, chunks)")
+	rootCmd.Flags().StringVar(&indexLayout, "index-layout", "", "endpoint list in llms.txt (groups, matrix)")
+	rootCmd.Flags().StringVar(&fileExtension, "file-extension", "", "endpoint file extension (txt, md)")
+	rootCmd.Flags().StringVar(&endpointsDir, "endpoints-dir", "", "directory for endpoint files inside the output (default endpoints, . = next to llms.txt)")
+	rootCmd.Flags().StringVar(&tokenizer, "tokenizer", "", "tokenizer for token estimates (cl100k, o200k, llama, bytes)")
+	rootCmd.Flags().BoolVar(&showTokens, "tokens", false, "print approximate token counts of the generated files")
+	rootCmd.Flags().StringVar(&renderer, "renderer", "", "custom renderer (registered name, exec:<command> or plugin:<file.so>)")
+	rootCmd.Flags().StringVar(&groupBy, "group-by", "", "endpoint grouping (tag, path, x-group, none)")
+	rootCmd.Flags().StringSliceVar(&excludePaths, "exclude-path", nil, "exclude operations by path pattern (e.g. /internal/**)")
+	rootCmd.Flags().StringSliceVar(&excludeTags, "exclude-tag", nil, "exclude operations by tag")
+	rootCmd.Flags().StringVar(&workflows, "workflows", "", "Arazzo document with multi-step workflows to document")
+	rootCmd.Flags().StringVar(&overridesFile, "overrides", "", "YAML file with hand-curated operation corrections merged before rendering")
+	rootCmd.Flags().StringVar(&audience, "audience", "", "include only operations whose x-audience lists this audience")
+	rootCmd.Flags().BoolVar(&excludedReport, "excluded-report", false, "write excluded.json listing omitted operations and why")
+	rootCmd.Flags().BoolVar(&searchIndex, "search-index", false, "write search.json mapping keywords to output files and anchors")
+	rootCmd.Flags().BoolVar(&schemaGlossary, "schema-glossary", false, "write schemas.txt listing named component schemas with their fields, linked from llms.txt")
+	rootCmd.Flags().BoolVar(&versioned, "versioned", false, "write into <output>/<api-version>/ and maintain a latest link and versions.txt")
+	rootCmd.Flags().BoolVar(&publishMfst, "manifest", false, "write manifest.json with the hash and size of every generated file (see verify)")
+	rootCmd.Flags().StringVar(&cacheDir, "cache-dir", "", "reuse rendered endpoint sections across runs from this directory")
+	rootCmd.Flags().BoolVar(&incremental, "incremental", false, "skip rewriting files whose content hash is unchanged")
+	rootCmd.Flags().BoolVar(&dryRunMode, "dry-run", false, "print which files would be created, updated or deleted without writing")
+	rootCmd.Flags().BoolVar(&showDiff, "diff", false, "with --dry-run, print unified diffs of the changes")
+	rootCmd.Flags().StringVar(&publishURL, "publish", "", "upload the output: s3://bucket/prefix, gs://bucket/prefix, az://account/container/prefix or https://host/path")
+	rootCmd.Flags().BoolVar(&publishDelete, "publish-delete-stale", false, "with --publish, delete objects under the prefix that are no longer generated")
+	rootCmd.Flags().StringVar(&publishHeader, "publish-auth-header", "", "header carrying $SPEC2LLMS_PUBLISH_AUTH in https:// uploads (default Authorization)")
+	rootCmd.Flags().BoolVar(&curlScripts, "curl-scripts", false, "also write curl examples to examples/<operationId>.sh with a smoke.sh runner")
 	rootCmd.Flags().BoolVar(&skipValidation, "skip-validation", false, "skip OpenAPI spec validation")
+	rootCmd.Flags().BoolVar(&bestEffort, "best-effort", false, "skip only the paths and operations that fail validation, reporting them as warnings")
+	rootCmd.Flags().BoolVar(&offline, "offline", false, "forbid all network access (URL sources, remote refs)")
+	rootCmd.Flags().BoolVar(&sectionMarks, "section-markers", false, "delimit generated sections with stable HTML comment markers")
+	rootCmd.Flags().BoolVar(&ghAnnotations, "github-annotations", false, "print validation errors as GitHub Actions workflow commands")
+	rootCmd.Flags().BoolVar(&noAutoSummary, "no-auto-summaries", false, "do not synthesize summaries for operations without one")
+	rootCmd.Flags().BoolVar(&sharedFrags, "shared-fragments", false, "hoist repeated description paragraphs into a shared section")
 
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+	rootCmd.AddCommand(newLintCmd())
+	rootCmd.AddCommand(newRenderEndpointCmd())
+	rootCmd.AddCommand(newCheckCmd())
+	rootCmd.AddCommand(newMigrateNamesCmd())
+	rootCmd.AddCommand(newVerifyCmd())
+	rootCmd.AddCommand(newEnrichCmd())
+	rootCmd.AddCommand(newChangelogCmd())
+	rootCmd.AddCommand(newInitCmd())
+	// Ошибки флагов и аргументов любой команды завершаются кодом exitUsage. Обязательные флаги
+	// проверяем сами: cobra проверяет их после PersistentPreRunE и возвращает ошибку как есть
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return usageError(err)
+	})
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
+		// Значение --lang по умолчанию только для справки: язык из конфига переопределяет явный флаг
+		if !cmd.Flags().Changed("lang") {
+			language = ""
+		}
+		return usageError(cmd.ValidateRequiredFlags())
+	}
+	for _, cmd := range append(rootCmd.Commands(), rootCmd) {
+		if validate := cmd.Args; validate != nil {
+			cmd.Args = func(cmd *cobra.Command, args []string) error {
+				return usageError(validate(cmd, args))
+			}
+		}
 	}
+	return rootCmd
 }
 
 func run(cmd *cobra.Command, args []string) error {
-	cfg, err := loadConfig(args)
-	if err != nil {
+	start := time.Now()
+	if err := validateReport(); err != nil {
 		return err
 	}
+	report := &runReport{DryRun: dryRunMode}
+	err := runProfiles(cmd, args, report)
+	if reportFormat == reportJSON {
+		printReport(cmd.OutOrStdout(), report, time.Since(start), exitCode(cmd.Context(), err), err)
+	}
+	return err
+}
+
+// runProfiles генерирует вывод по каждому выбранному профилю, добавляя их итоги в report
+func runProfiles(cmd *cobra.Command, args []string, report *runReport) error {
+	profiles, err := loadProfiles(args)
+	if err != nil {
+		return usageError(err)
+	}
+	for _, p := range profiles {
+		report.Runs = append(report.Runs, runSummary{Profile: p.name, Source: p.cfg.Source, Output: p.cfg.Output})
+		summary := &report.Runs[len(report.Runs)-1]
+		if p.name == "" {
+			return generate(cmd, p.cfg, summary)
+		}
+		fmt.Fprintf(console, "Profile %s\n", p.name)
+		if err := generate(cmd, p.cfg, summary); err != nil {
+			return fmt.Errorf("profile %s: %w", p.name, err)
+		}
+	}
+	return nil
+}
 
+// generate разбирает спецификацию и генерирует вывод по одному конфигу, заполняя summary
+func generate(cmd *cobra.Command, cfg *config.Config, summary *runSummary) error {
 	if err := cfg.Validate(); err != nil {
-		return err
+		return usageError(err)
+	}
+	if cfg.Offline && cfg.Publish.URL != "" {
+		return usageError(errors.New("publish uploads over the network and cannot run with --offline"))
 	}
 
-	fmt.Printf("Parsing OpenAPI spec: %s\n", cfg.Source)
-	api, err := parser.Parse(cfg.Source, &parser.ParseOptions{
+	fmt.Fprintf(console, "Parsing OpenAPI spec: %s\n", cfg.Source)
+	parseStart := time.Now()
+	api, err := parser.ParseContext(cmd.Context(), cfg.Source, &parser.ParseOptions{
 		SkipValidation: cfg.SkipValidation,
+		BestEffort:     cfg.BestEffort,
+		Offline:        cfg.Offline,
+		Preprocess:     preParseHooks(cmd.Context(), cfg),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to parse spec: %w", err)
+		return parseError(cfg, err)
 	}
+	summary.ParseMs = time.Since(parseStart).Milliseconds()
+	summary.Endpoints = len(api.Endpoints)
+	summary.Warnings = api.Warnings
+	printParseWarnings(cfg, api)
 
-	fmt.Printf("Found %d endpoints\n", len(api.Endpoints))
+	fmt.Fprintf(console, "Found %d endpoints\n", len(api.Endpoints))
+
+	versionRoot, err := versionedOutput(cfg, api)
+	if err != nil {
+		return err
+	}
+	summary.Output = cfg.Output
 
 	gen := generator.New(cfg, api)
-	if err := gen.Generate(); err != nil {
+	generateStart := time.Now()
+	defer func() {
+		summary.GenerateMs = time.Since(generateStart).Milliseconds()
+		summary.setFiles(gen.Files())
+	}()
+	if dryRunMode {
+		return dryRun(cfg, gen)
+	}
+	// Индикатор прогресса только в терминале, чтобы не засорять логи CI
+	var bar *progressBar
+	if isTerminal(console) {
+		bar = newProgressBar()
+		gen.SetProgress(bar.Update)
+	}
+	err = gen.GenerateContext(cmd.Context())
+	if bar != nil {
+		bar.Finish()
+	}
+	if err != nil {
 		return fmt.Errorf("failed to generate: %w", err)
 	}
+	if versionRoot != "" {
+		if err := generator.UpdateVersionIndex(versionRoot, versionTitle(cfg, api)); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case cfg.Renderer != "":
+		fmt.Fprintf(console, "Rendered with %s in %s\n", cfg.Renderer, cfg.Output)
+	case cfg.Format == config.FormatHTML:
+		fmt.Fprintf(console, "Generated HTML site in %s\n", cfg.Output)
+	case cfg.Format == config.FormatJSON:
+		fmt.Fprintf(console, "Generated api.json in %s\n", cfg.Output)
+	case cfg.Format == config.FormatChunks:
+		fmt.Fprintf(console, "Generated %s in %s\n", generator.ChunksFile, cfg.Output)
+	default:
+		fmt.Fprintf(console, "Generated llms.txt in %s\n", cfg.Output)
+	}
+
+	if cfg.Publish.URL != "" {
+		if summary.Publish, err = publishOutput(cmd.Context(), cfg, versionRoot); err != nil {
+			return err
+		}
+	}
 
-	fmt.Printf("Generated llms.txt in %s\n", cfg.Output)
+	if err := hooks.PostGenerate(cmd.Context(), cfg.Hooks.PostGenerate, cfg.Source, cfg.Output); err != nil {
+		return err
+	}
+
+	// Оценка токенов только по запросу: --tokens или --report (text печатает её, json включает в сводку)
+	if !showTokens && reportFormat == "" {
+		return nil
+	}
+	estimate, err := estimateTokens(cfg, gen.Files())
+	if err != nil {
+		return err
+	}
+	if showTokens || reportFormat == reportText {
+		estimate.print(console)
+	}
+	summary.Tokens = estimate.totals
 	return nil
 }
 
-func loadConfig(args []string) (*config.Config, error) {
-	var cfg *config.Config
-	var err error
+// addLanguageFlag регистрирует --lang. Переменная language общая для основной команды, check
+// и render-endpoint, поэтому значение по умолчанию задаётся только здесь: регистрация флага
+// с другим значением по умолчанию сменила бы его и для основной команды. Без флага
+// в командной строке language сбрасывается (PersistentPreRunE), и действует язык из конфига
+func addLanguageFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&language, "lang", "l", config.LanguageEN, "output language (en, ru, auto)")
+}
+
+// versionedOutput переключает вывод в поддиректорию версии API (output/<info.version>)
+// и возвращает корень версионированного вывода; пустая строка — versioned выключен
+func versionedOutput(cfg *config.Config, api *parser.API) (string, error) {
+	if !cfg.Versioned {
+		return "", nil
+	}
+	dir, err := generator.VersionDir(api.Version)
+	if err != nil {
+		return "", err
+	}
+	root := cfg.Output
+	cfg.Output = filepath.Join(root, dir)
+	return root, nil
+}
+
+// versionTitle возвращает заголовок списка версий: title из конфига или спецификации
+func versionTitle(cfg *config.Config, api *parser.API) string {
+	if cfg.Title != "" {
+		return cfg.Title
+	}
+	return api.Title
+}
 
-	if cfgFile != "" {
-		cfg, err = config.LoadFromFile(cfgFile)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load config: %w", err)
+// preParseHooks возвращает предобработку спецификации хуками preParse из конфига.
+// Команды хуков останавливаются при отмене ctx
+func preParseHooks(ctx context.Context, cfg *config.Config) func([]byte) ([]byte, error) {
+	if len(cfg.Hooks.PreParse) == 0 {
+		return nil
+	}
+	return func(data []byte) ([]byte, error) {
+		return hooks.PreParse(ctx, cfg.Hooks.PreParse, cfg.Source, data)
+	}
+}
+
+// parseError оформляет ошибку разбора спецификации: печатает её как аннотацию GitHub Actions,
+// чтобы она появилась в PR рядом с файлом спецификации, и дописывает подсказку по виду ошибки
+func parseError(cfg *config.Config, err error) error {
+	if ghAnnotations {
+		f := lint.Finding{Rule: "openapi-validation", Severity: lint.SeverityError, Message: err.Error()}
+		fmt.Fprintln(console, f.GitHubAnnotation(cfg.Source))
+	}
+	if hint := parseErrorHint(err); hint != "" {
+		return fmt.Errorf("failed to parse spec: %w\n\n%s", err, hint)
+	}
+	return fmt.Errorf("failed to parse spec: %w", err)
+}
+
+// parseErrorHint подсказывает, как исправить ошибку разбора
+func parseErrorHint(err error) string {
+	var network *parser.NetworkRequiredError
+	switch {
+	case errors.Is(err, parser.ErrSpecNotFound):
+		return "Check the spec path or URL (the source argument or \"source\" in the config file)"
+	case errors.Is(err, parser.ErrUnsupportedVersion):
+		return "Convert the spec to OpenAPI 3 first, for example: npx swagger2openapi swagger.yaml -o openapi.yaml"
+	case errors.Is(err, parser.ErrInvalidSpec):
+		return "Use --best-effort to skip only the invalid operations or --skip-validation to ignore validation errors"
+	case errors.As(err, &network):
+		return "Download the remote files and reference them by relative path, or run without --offline"
+	}
+	return ""
+}
+
+// printParseWarnings сообщает о путях и операциях, пропущенных при разборе с --best-effort
+func printParseWarnings(cfg *config.Config, api *parser.API) {
+	for _, w := range api.Warnings {
+		switch {
+		case ghAnnotations:
+			f := lint.Finding{Rule: "openapi-validation", Severity: lint.SeverityWarning, Message: w.String(), Location: w.Location}
+			fmt.Fprintln(console, f.GitHubAnnotation(cfg.Source))
+		case w.Location.Pointer != "":
+			fmt.Fprintf(os.Stderr, "Warning: %s:%s: %s\n", cfg.Source, w.Location, w)
+		default:
+			fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", cfg.Source, w)
 		}
-	} else {
-		cfg = config.DefaultConfig()
 	}
+}
 
-	// CLI флаги переопределяют конфиг
+func loadConfig(args []string) (*config.Config, error) {
+	cfg, err := loadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	applyFlags(cfg, args)
+	return cfg, nil
+}
+
+// loadConfigFile читает конфиг из --config или возвращает конфиг по умолчанию
+func loadConfigFile() (*config.Config, error) {
+	if cfgFile == "" {
+		return config.DefaultConfig(), nil
+	}
+	cfg, err := config.LoadFromFile(cfgFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return cfg, nil
+}
+
+// applyFlags переносит CLI флаги в конфиг: они переопределяют значения из файла и профиля
+func applyFlags(cfg *config.Config, args []string) {
 	if len(args) > 0 {
 		cfg.Source = args[0]
 	}
@@ -100,15 +435,124 @@ func loadConfig(args []string) (*config.Config, error) {
 	if baseURL != "" {
 		cfg.BaseURL = baseURL
 	}
+	if server != "" {
+		cfg.Server = server
+	}
 	if docsBaseURL != "" {
 		cfg.DocsBaseURL = docsBaseURL
 	}
 	if language != "" {
 		cfg.Language = language
 	}
+	if groupBy != "" {
+		cfg.GroupBy = groupBy
+	}
+	if sortBy != "" {
+		cfg.Sort = sortBy
+	}
+	if format != "" {
+		cfg.Format = format
+	}
+	if indexLayout != "" {
+		cfg.IndexLayout = indexLayout
+	}
+	if fileExtension != "" {
+		cfg.FileExtension = fileExtension
+	}
+	if endpointsDir != "" {
+		cfg.EndpointsDir = endpointsDir
+	}
+	if tokenizer != "" {
+		cfg.Tokenizer = tokenizer
+	}
+	if renderer != "" {
+		cfg.Renderer = renderer
+	}
+	if len(examples) > 0 {
+		cfg.Examples = examples
+	}
+	if len(contentTypes) > 0 {
+		cfg.ContentTypes = contentTypes
+	}
+	if allContentType {
+		cfg.AllContentTypes = true
+	}
+	if len(excludePaths) > 0 {
+		cfg.Filter.ExcludePaths = append(cfg.Filter.ExcludePaths, excludePaths...)
+	}
+	if len(excludeTags) > 0 {
+		cfg.Filter.ExcludeTags = append(cfg.Filter.ExcludeTags, excludeTags...)
+	}
+	if curlScripts {
+		cfg.CurlScripts = true
+	}
+	if publishURL != "" {
+		cfg.Publish.URL = publishURL
+	}
+	if publishHeader != "" {
+		cfg.Publish.AuthHeader = publishHeader
+	}
+	if publishDelete {
+		cfg.Publish.DeleteStale = true
+	}
+	if cacheDir != "" {
+		cfg.CacheDir = cacheDir
+	}
+	if incremental {
+		cfg.Incremental = true
+	}
+	if audience != "" {
+		cfg.Audience = audience
+	}
+	if workflows != "" {
+		cfg.Workflows = workflows
+	}
+	if overridesFile != "" {
+		cfg.Overrides = overridesFile
+	}
+	if lintRuleset != "" {
+		cfg.LintRuleset = lintRuleset
+	}
+	if excludedReport {
+		cfg.ExcludedReport = true
+	}
+	if searchIndex {
+		cfg.SearchIndex = true
+	}
+	if schemaGlossary {
+		cfg.SchemaGlossary = true
+	}
+	if versioned {
+		cfg.Versioned = true
+	}
+	if publishMfst {
+		cfg.PublishManifest = true
+	}
+	if maxDepth > 0 {
+		cfg.MaxSchemaDepth = maxDepth
+	}
+	if maxProps > 0 {
+		cfg.MaxPropertiesPerObject = maxProps
+	}
+	if maxDescLength > 0 {
+		cfg.MaxDescriptionLength = maxDescLength
+	}
 	if skipValidation {
 		cfg.SkipValidation = true
 	}
-
-	return cfg, nil
+	if bestEffort {
+		cfg.BestEffort = true
+	}
+	if sharedFrags {
+		cfg.SharedFragments = true
+	}
+	if offline {
+		cfg.Offline = true
+	}
+	if noAutoSummary {
+		cfg.NoAutoSummaries = true
+	}
+	if sectionMarks {
+		cfg.SectionMarkers = true
+	}
 }
@@ -0,0 +1,713 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mdwit/spec2llms/internal/config"
+	"github.com/mdwit/spec2llms/internal/generator"
+	"github.com/mdwit/spec2llms/internal/parser"
+	"github.com/mdwit/spec2llms/internal/publish"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+func TestMain(m *testing.M) {
+	// Сообщения о ходе генерации не нужны в выводе тестов
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		panic(err)
+	}
+	console = devNull
+	os.Exit(m.Run())
+}
+
+// execute запускает CLI с аргументами и возвращает вывод команды (OutOrStdout и OutOrStderr)
+func execute(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	return executeInput(t, nil, args...)
+}
+
+// executeInput запускает CLI, как execute, отвечая на вопросы из in (nil — stdin теста)
+func executeInput(t *testing.T, in io.Reader, args ...string) (string, error) {
+	t.Helper()
+	cmd := newRootCmd()
+	if in != nil {
+		cmd.SetIn(in)
+	}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+// writeSpec записывает спецификацию с операциями GET для каждого пути и возвращает её путь
+func writeSpec(t *testing.T, dir string, paths ...string) string {
+	t.Helper()
+	var sb strings.Builder
+	sb.WriteString("openapi: 3.0.0\ninfo:\n  title: Test API\n  version: 1.0.0\npaths:\n")
+	for _, p := range paths {
+		sb.WriteString("  " + p + ":\n    get:\n      summary: Get " + strings.Trim(p, "/") + "\n")
+		sb.WriteString("      responses:\n        \"200\":\n          description: OK\n")
+	}
+	path := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	return path
+}
+
+func TestCheckAfterRemovingEndpoint(t *testing.T) {
+	for _, flags := range [][]string{nil, {"--manifest"}, {"--incremental"}} {
+		t.Run(strings.Join(append([]string{"plain"}, flags...), " "), func(t *testing.T) {
+			dir := t.TempDir()
+			out := filepath.Join(dir, "llms")
+			generate := func(spec string) {
+				t.Helper()
+				if _, err := execute(t, append([]string{spec, "-o", out}, flags...)...); err != nil {
+					t.Fatalf("generation failed: %v", err)
+				}
+			}
+
+			generate(writeSpec(t, dir, "/a", "/b"))
+			spec := writeSpec(t, dir, "/a")
+
+			// Без перегенерации check падает и называет изменения
+			msg, err := execute(t, "check", spec, "-o", out)
+			if err == nil || !strings.Contains(msg, "update llms.txt") {
+				t.Fatalf("expected stale output, got %v:\n%s", err, msg)
+			}
+
+			// После перегенерации, как советует check, вывод актуален
+			generate(spec)
+			if msg, err := execute(t, "check", spec, "-o", out); err != nil {
+				t.Errorf("check still fails after regenerating: %v\n%s", err, msg)
+			}
+		})
+	}
+}
+
+// captureConsole возвращает сообщения, напечатанные в console во время fn
+func captureConsole(t *testing.T, fn func()) string {
+	t.Helper()
+	f, err := os.CreateTemp(t.TempDir(), "console")
+	if err != nil {
+		t.Fatalf("CreateTemp failed: %v", err)
+	}
+	defer f.Close()
+	saved := console
+	console = f
+	defer func() { console = saved }()
+	fn()
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatalf("Failed to read console: %v", err)
+	}
+	return string(data)
+}
+
+func TestDryRunMatchesGeneration(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "llms")
+	if _, err := execute(t, writeSpec(t, dir, "/a", "/b"), "-o", out, "--manifest"); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(out, "notes.md"), []byte("hand-written\n"), 0644); err != nil {
+		t.Fatalf("Failed to write user file: %v", err)
+	}
+	spec := writeSpec(t, dir, "/a")
+
+	msg := captureConsole(t, func() {
+		if _, err := execute(t, spec, "-o", out, "--manifest", "--dry-run"); err != nil {
+			t.Fatalf("dry run failed: %v", err)
+		}
+	})
+	var deleted []string
+	for line := range strings.Lines(msg) {
+		if path, ok := strings.CutPrefix(strings.TrimSpace(line), "delete "); ok {
+			deleted = append(deleted, strings.TrimSpace(path))
+		}
+	}
+	if len(deleted) != 1 || deleted[0] != "endpoints/get-b.txt" {
+		t.Fatalf("dry run deletes %v, want [endpoints/get-b.txt]:\n%s", deleted, msg)
+	}
+
+	if _, err := execute(t, spec, "-o", out, "--manifest"); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "endpoints", "get-b.txt")); !os.IsNotExist(err) {
+		t.Errorf("generation kept endpoints/get-b.txt that dry run reported as deleted")
+	}
+	if _, err := os.Stat(filepath.Join(out, "notes.md")); err != nil {
+		t.Errorf("generation removed a file dry run did not report: %v", err)
+	}
+}
+
+func TestTokenReport(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "llms")
+	spec := writeSpec(t, dir, "/a", "/b")
+
+	msg := captureConsole(t, func() {
+		if _, err := execute(t, spec, "-o", out); err != nil {
+			t.Fatalf("generation failed: %v", err)
+		}
+	})
+	if strings.Contains(msg, "Estimated tokens") {
+		t.Errorf("token report printed without --tokens:\n%s", msg)
+	}
+
+	// Посторонние файлы в директории вывода в оценку не попадают
+	if err := os.MkdirAll(filepath.Join(out, "notes"), 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(out, "notes", "todo.md"), []byte("hand-written\n"), 0644); err != nil {
+		t.Fatalf("Failed to write user file: %v", err)
+	}
+	msg = captureConsole(t, func() {
+		if _, err := execute(t, spec, "-o", out, "--tokens"); err != nil {
+			t.Fatalf("generation failed: %v", err)
+		}
+	})
+	if !strings.Contains(msg, "Estimated tokens (cl100k, approximate):") || !strings.Contains(msg, "  endpoints/ — ") || !strings.Contains(msg, "(2 files)") {
+		t.Errorf("token report missing with --tokens:\n%s", msg)
+	}
+	if strings.Contains(msg, "notes/") {
+		t.Errorf("token report counts a file the run did not write:\n%s", msg)
+	}
+}
+
+// writeSwapSpec записывает спецификацию, в которой имена по operationId меняют местами
+// файлы по путям: GET /a → get-b.txt, GET /b → get-a.txt, GET /c → get-d.txt
+func writeSwapSpec(t *testing.T, dir string) string {
+	t.Helper()
+	spec := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+`
+	for _, op := range [][2]string{{"/a", "get-b"}, {"/b", "get-a"}, {"/c", "get-d"}} {
+		spec += "  " + op[0] + ":\n    get:\n      operationId: " + op[1] + "\n      summary: Get " + strings.Trim(op[0], "/") + "\n"
+		spec += "      responses:\n        \"200\":\n          description: OK\n"
+	}
+	path := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(path, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	return path
+}
+
+func TestMigrateNamesSwap(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "llms")
+	spec := writeSwapSpec(t, dir)
+	if _, err := execute(t, spec, "-o", out); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+	page := filepath.Join(out, "index.html")
+	if err := os.WriteFile(page, []byte(`<a href="./endpoints/get-a.txt">a</a>`), 0644); err != nil {
+		t.Fatalf("Failed to write page: %v", err)
+	}
+
+	if _, err := execute(t, "migrate-names", spec, "-o", out, "--from", "path", "--to", "operationId"); err != nil {
+		t.Fatalf("migrate-names failed: %v", err)
+	}
+	for file, want := range map[string]string{"get-a.txt": "GET /b", "get-b.txt": "GET /a", "get-d.txt": "GET /c"} {
+		data, err := os.ReadFile(filepath.Join(out, "endpoints", file))
+		if err != nil || !strings.Contains(string(data), want) {
+			t.Errorf("endpoints/%s should document %s, got %q (%v)", file, want, data, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(out, "endpoints", "get-c.txt")); !os.IsNotExist(err) {
+		t.Errorf("endpoints/get-c.txt should be renamed")
+	}
+
+	data, err := os.ReadFile(filepath.Join(out, generator.RedirectsFile))
+	if err != nil {
+		t.Fatalf("Failed to read redirects: %v", err)
+	}
+	want := `{
+  "endpoints/get-a.txt": "endpoints/get-b.txt",
+  "endpoints/get-b.txt": "endpoints/get-a.txt",
+  "endpoints/get-c.txt": "endpoints/get-d.txt"
+}
+`
+	if string(data) != want {
+		t.Errorf("unexpected redirects:\n%s", data)
+	}
+
+	index, _ := os.ReadFile(filepath.Join(out, "llms.txt"))
+	if !strings.Contains(string(index), "[GET /a](./endpoints/get-b.txt#get-b)") || !strings.Contains(string(index), "[GET /b](./endpoints/get-a.txt#get-a)") {
+		t.Errorf("llms.txt links should follow the renames:\n%s", index)
+	}
+	if html, _ := os.ReadFile(page); string(html) != `<a href="./endpoints/get-b.txt">a</a>` {
+		t.Errorf("HTML links should follow the renames, got %s", html)
+	}
+}
+
+func TestMigrateNamesRollback(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "llms")
+	spec := writeSwapSpec(t, dir)
+	if _, err := execute(t, spec, "-o", out); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+	before := map[string][]byte{}
+	for _, file := range []string{"get-a.txt", "get-b.txt", "get-c.txt"} {
+		data, err := os.ReadFile(filepath.Join(out, "endpoints", file))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file, err)
+		}
+		before[file] = data
+	}
+	// Последнее переименование (в get-d.txt) не удаётся
+	rename = func(src, dst string) error {
+		if filepath.Base(dst) == "get-d.txt" {
+			return errors.New("disk full")
+		}
+		return os.Rename(src, dst)
+	}
+	defer func() { rename = os.Rename }()
+
+	if _, err := execute(t, "migrate-names", spec, "-o", out, "--from", "path", "--to", "operationId"); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("migrate-names should fail when a file cannot be renamed, got %v", err)
+	}
+	for file, data := range before {
+		if got, err := os.ReadFile(filepath.Join(out, "endpoints", file)); err != nil || !bytes.Equal(got, data) {
+			t.Errorf("endpoints/%s should be restored, got %q (%v)", file, got, err)
+		}
+	}
+	leftovers, _ := filepath.Glob(filepath.Join(out, "endpoints", "*.migrating"))
+	if len(leftovers) != 0 {
+		t.Errorf("temporary files left behind: %v", leftovers)
+	}
+	if _, err := os.Stat(filepath.Join(out, generator.RedirectsFile)); !os.IsNotExist(err) {
+		t.Errorf("redirects should not be written after a failed rename")
+	}
+}
+
+func TestMigrateNamesExistingTarget(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "llms")
+	spec := writeSwapSpec(t, dir)
+	if _, err := execute(t, spec, "-o", out); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+	target := filepath.Join(out, "endpoints", "get-d.txt")
+	if err := os.WriteFile(target, []byte("hand-written\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	_, err := execute(t, "migrate-names", spec, "-o", out, "--from", "path", "--to", "operationId")
+	if err == nil || !strings.Contains(err.Error(), "endpoints/get-d.txt already exists") {
+		t.Fatalf("migrate-names should refuse to overwrite an existing file, got %v", err)
+	}
+	if data, _ := os.ReadFile(target); string(data) != "hand-written\n" {
+		t.Errorf("existing file was overwritten: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(out, "endpoints", "get-c.txt")); err != nil {
+		t.Errorf("no file should be renamed: %v", err)
+	}
+}
+
+func TestMigrateNamesRelatedLinks(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "llms")
+	spec := filepath.Join(dir, "openapi.yaml")
+	data := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      operationId: createUser
+      responses:
+        "201":
+          description: Created
+          links:
+            GetUser:
+              operationId: getUser
+              parameters:
+                id: $response.body#/id
+  /users/{id}:
+    get:
+      operationId: getUser
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+`
+	if err := os.WriteFile(spec, []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	// Редирект прошлой миграции ведёт на файл, который переименуется сейчас
+	if err := os.MkdirAll(out, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(out, generator.RedirectsFile), []byte(`{"endpoints/users-get.txt": "endpoints/get-users-id.txt"}`), 0644); err != nil {
+		t.Fatalf("Failed to write redirects: %v", err)
+	}
+	if _, err := execute(t, spec, "-o", out); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+	if file, _ := os.ReadFile(filepath.Join(out, "endpoints", "post-users.txt")); !strings.Contains(string(file), "(get-users-id.txt#getuser)") {
+		t.Fatalf("expected a related operation link, got:\n%s", file)
+	}
+
+	if _, err := execute(t, "migrate-names", spec, "-o", out, "--from", "path", "--to", "operationId"); err != nil {
+		t.Fatalf("migrate-names failed: %v", err)
+	}
+	if file, _ := os.ReadFile(filepath.Join(out, "endpoints", "createUser.txt")); !strings.Contains(string(file), "(getUser.txt#getuser)") {
+		t.Errorf("related operation link should follow the rename, got:\n%s", file)
+	}
+
+	cfgFile := filepath.Join(dir, "spec2llms.json")
+	if err := os.WriteFile(cfgFile, []byte(`{"fileNaming": "operationId"}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if msg, err := execute(t, "check", spec, "-c", cfgFile, "-o", out); err != nil {
+		t.Errorf("migrated output should be up to date: %v\n%s", err, msg)
+	}
+
+	redirects, _ := os.ReadFile(filepath.Join(out, generator.RedirectsFile))
+	want := `{
+  "endpoints/get-users-id.txt": "endpoints/getUser.txt",
+  "endpoints/post-users.txt": "endpoints/createUser.txt",
+  "endpoints/users-get.txt": "endpoints/getUser.txt"
+}
+`
+	if string(redirects) != want {
+		t.Errorf("redirects should be merged with the previous ones, got:\n%s", redirects)
+	}
+}
+
+func TestChangelog(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "llms")
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if data, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, data)
+		}
+	}
+	git("init", "-q")
+	spec := writeSpec(t, dir, "/a")
+	git("add", "openapi.yaml")
+	git("commit", "-q", "-m", "spec")
+	writeSpec(t, dir, "/a", "/b")
+
+	if _, err := execute(t, "changelog", spec, "--since", "HEAD", "-o", out); err != nil {
+		t.Fatalf("changelog failed: %v", err)
+	}
+	changelog, err := os.ReadFile(filepath.Join(out, generator.ChangelogFile))
+	if err != nil || !strings.Contains(string(changelog), "`GET /b`: operation added") {
+		t.Fatalf("changelog.txt should list the added operation, got %q (%v)", changelog, err)
+	}
+
+	// Генерация ссылается на журнал, не меняя его, и check после неё не видит расхождений
+	for range 2 {
+		if _, err := execute(t, spec, "-o", out, "--incremental"); err != nil {
+			t.Fatalf("generation failed: %v", err)
+		}
+		if msg, err := execute(t, "check", spec, "-o", out); err != nil {
+			t.Fatalf("check failed after generation: %v\n%s", err, msg)
+		}
+	}
+	index, _ := os.ReadFile(filepath.Join(out, "llms.txt"))
+	if !strings.Contains(string(index), "](./changelog.txt)") || strings.Contains(string(index), "operation added") {
+		t.Errorf("llms.txt should link the changelog instead of embedding it:\n%s", index)
+	}
+	if data, _ := os.ReadFile(filepath.Join(out, generator.ChangelogFile)); !bytes.Equal(data, changelog) {
+		t.Errorf("generation changed changelog.txt:\n%s", data)
+	}
+
+	// Повторный запуск заменяет журнал, а llms.txt остаётся актуальным
+	if _, err := execute(t, "changelog", spec, "--since", "HEAD", "-o", out); err != nil {
+		t.Fatalf("changelog failed: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(out, generator.ChangelogFile)); !bytes.Equal(data, changelog) {
+		t.Errorf("re-running changelog should replace the file, got:\n%s", data)
+	}
+	if msg, err := execute(t, "check", spec, "-o", out); err != nil {
+		t.Errorf("check failed after re-running changelog: %v\n%s", err, msg)
+	}
+}
+
+func TestPublishVersionedRoot(t *testing.T) {
+	var mu sync.Mutex
+	uploaded := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		uploaded[r.URL.Path] = true
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	if _, err := execute(t, writeSpec(t, dir, "/a"), "-o", filepath.Join(dir, "llms"), "--versioned", "--publish", server.URL+"/docs"); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+	for _, path := range []string{"/docs/1.0.0/llms.txt", "/docs/latest/llms.txt", "/docs/versions.txt"} {
+		if !uploaded[path] {
+			t.Errorf("%s was not published, got %v", path, slices.Sorted(maps.Keys(uploaded)))
+		}
+	}
+}
+
+func TestInit(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeSpec(t, ".", "/a")
+
+	out, err := execute(t, "init", "--yes", "-o", "./public/llms", "--exclude-path", "/internal/**", "--github-workflow")
+	if err != nil {
+		t.Fatalf("init failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Wrote spec2llms.json") || !strings.Contains(out, "Wrote .github/workflows/llms.yml") {
+		t.Errorf("unexpected init output:\n%s", out)
+	}
+	data, _ := os.ReadFile("spec2llms.json")
+	want := `{
+  "source": "openapi.yaml",
+  "output": "./public/llms",
+  "language": "en",
+  "groupBy": "tag",
+  "filter": {
+    "excludePaths": [
+      "/internal/**"
+    ]
+  }
+}
+`
+	if string(data) != want {
+		t.Errorf("unexpected config:\n%s", data)
+	}
+	workflow, _ := os.ReadFile(workflowFile)
+	for _, line := range []string{"      - openapi.yaml\n", "      - spec2llms.json\n", "run: spec2llms check -c spec2llms.json --github-annotations\n"} {
+		if !strings.Contains(string(workflow), line) {
+			t.Errorf("workflow is missing %q:\n%s", line, workflow)
+		}
+	}
+
+	// Стартовый конфиг сразу работает с generation и check
+	if out, err := execute(t, "-c", "spec2llms.json"); err != nil {
+		t.Fatalf("generation with the starter config failed: %v\n%s", err, out)
+	}
+	if out, err := execute(t, "check", "-c", "spec2llms.json"); err != nil {
+		t.Errorf("check with the starter config failed: %v\n%s", err, out)
+	}
+
+	// Существующие файлы перезаписываются только с --force
+	if _, err := execute(t, "init", "--yes"); err == nil || !strings.Contains(err.Error(), "already exists, use --force") {
+		t.Errorf("expected init to refuse overwriting, got %v", err)
+	}
+	if _, err := execute(t, "init", "--yes", "--force"); err != nil {
+		t.Errorf("init --force failed: %v", err)
+	}
+	if data, _ := os.ReadFile("spec2llms.json"); strings.Contains(string(data), "filter") {
+		t.Errorf("init --force did not overwrite the config:\n%s", data)
+	}
+}
+
+func TestInitPrompts(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeSpec(t, ".", "/a")
+
+	// Пустой ответ оставляет значение по умолчанию; вопросы о флагах из командной строки не задаются
+	answers := strings.NewReader("\n./site/llms\nru\n/internal/**, /admin/**\n\ny\n")
+	out, err := executeInput(t, answers, "init", "--group-by", "path")
+	if err != nil {
+		t.Fatalf("init failed: %v\n%s", err, out)
+	}
+	for _, question := range []string{"OpenAPI spec (file or URL) [openapi.yaml]: ", "Output directory [./llms]: ", "Add a GitHub workflow"} {
+		if !strings.Contains(out, question) {
+			t.Errorf("expected question %q in:\n%s", question, out)
+		}
+	}
+	if strings.Contains(out, "Group endpoints by") {
+		t.Errorf("asked for a value given by a flag:\n%s", out)
+	}
+
+	data, _ := os.ReadFile("spec2llms.json")
+	want := `{
+  "source": "openapi.yaml",
+  "output": "./site/llms",
+  "language": "ru",
+  "groupBy": "path",
+  "filter": {
+    "excludePaths": [
+      "/internal/**",
+      "/admin/**"
+    ]
+  }
+}
+`
+	if string(data) != want {
+		t.Errorf("unexpected config:\n%s", data)
+	}
+	if _, err := os.Stat(workflowFile); err != nil {
+		t.Errorf("workflow not written: %v", err)
+	}
+
+	// Неверный ответ отклоняется так же, как при генерации
+	if _, err := executeInput(t, strings.NewReader("\n\nklingon\n"), "init", "--force"); err == nil {
+		t.Error("expected an invalid language to be rejected")
+	}
+}
+
+func TestSubcommandFlagsKeepRootDefaults(t *testing.T) {
+	newRootCmd()
+	if cfgFile != "" || groupBy != "" || output != "./llms" || language != config.LanguageEN {
+		t.Errorf("subcommand flags changed root defaults: config %q, group-by %q, output %q, lang %q", cfgFile, groupBy, output, language)
+	}
+
+	// check и render-endpoint выбирают язык так же, как генерация
+	dir := t.TempDir()
+	cfg := filepath.Join(dir, "spec2llms.json")
+	data := fmt.Sprintf(`{"source": %q, "output": %q, "language": "ru"}`, writeSpec(t, dir, "/a"), filepath.Join(dir, "llms"))
+	if err := os.WriteFile(cfg, []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := execute(t, "-c", cfg); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+	index := filepath.Join(dir, "llms", "llms.txt")
+	if data, _ := os.ReadFile(index); !strings.Contains(string(data), "## Эндпоинты") {
+		t.Errorf("language from the config should apply without --lang, got:\n%s", data)
+	}
+	if out, err := execute(t, "check", "-c", cfg); err != nil {
+		t.Errorf("check renders in a different language than generation: %v\n%s", err, out)
+	}
+
+	// Явный --lang переопределяет конфиг
+	if _, err := execute(t, "-c", cfg, "--lang", "en"); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+	if data, _ := os.ReadFile(index); !strings.Contains(string(data), "## Endpoints") {
+		t.Errorf("--lang should override the config, got:\n%s", data)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want int
+	}{
+		{"ok", context.Background(), nil, exitOK},
+		{"failure", context.Background(), errors.New("failed to generate"), exitFailure},
+		{"usage", context.Background(), usageError(config.ErrSourceRequired), exitUsage},
+		{"wrapped usage", context.Background(), fmt.Errorf("profile docs: %w", usageError(errors.New("bad"))), exitUsage},
+		{"spec not found", context.Background(), fmt.Errorf("failed to parse spec: %w", parser.ErrSpecNotFound), exitSpec},
+		{"unsupported version", context.Background(), parser.ErrUnsupportedVersion, exitSpec},
+		{"invalid spec", context.Background(), &parser.InvalidSpecError{Details: []string{"missing id"}}, exitSpec},
+		{"interrupted", canceled, context.Canceled, exitInterrupted},
+		{"interrupted wins over usage", canceled, usageError(errors.New("bad")), exitInterrupted},
+		{"nil usage error", context.Background(), usageError(nil), exitOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.ctx, tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsageErrorsExitWithUsageCode(t *testing.T) {
+	dir := t.TempDir()
+	spec := writeSpec(t, dir, "/a")
+	missing := filepath.Join(dir, "missing.json")
+	tests := [][]string{
+		{spec, "-c", missing},
+		{spec, "--lang", "klingon"},
+		{spec, "--no-such-flag"},
+		{"a.yaml", "b.yaml"},
+		{"lint", spec, "-c", missing},
+		{"check", spec, "-c", missing},
+		{"render-endpoint", "GET /a", "--spec", spec, "-c", missing},
+		{"migrate-names", spec, "-c", missing, "--from", "path", "--to", "operationId"},
+		{"changelog", spec, "--since", "HEAD", "-c", missing},
+		{"changelog", spec},
+		{"enrich", spec, "--offline"},
+		{"verify"},
+		{"init", "--yes", "--lang", "klingon", "-c", filepath.Join(dir, "new.json")},
+	}
+	for _, args := range tests {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			_, err := execute(t, args...)
+			if got := exitCode(context.Background(), err); got != exitUsage {
+				t.Errorf("exit code %d, want %d (%v)", got, exitUsage, err)
+			}
+		})
+	}
+}
+
+func TestPrintReport(t *testing.T) {
+	report := &runReport{Runs: []runSummary{
+		{
+			Profile:   "public",
+			Source:    "openapi.yaml",
+			Output:    "llms/public",
+			Endpoints: 2,
+			Warnings:  []parser.Warning{{Method: "POST", Path: "/users", Message: "invalid parameter"}},
+			Tokens:    map[string]int{"llms.txt": 120, "endpoints/": 480},
+			Publish:   &publish.Result{URL: "s3://docs/llms", Uploaded: 2, Unchanged: 1},
+			ParseMs:   12,
+		},
+		{Profile: "partner", Source: "openapi.yaml", Output: "llms/partner"},
+	}}
+	report.Runs[0].setFiles([]generator.GeneratedFile{
+		{Path: "endpoints/get-users.txt", SHA256: "ab12", Size: 300},
+		{Path: "llms.txt", SHA256: "cd34", Size: 100},
+	})
+	err := fmt.Errorf("profile partner: %w", parser.ErrSpecNotFound)
+
+	var out bytes.Buffer
+	printReport(&out, report, 1500*time.Millisecond, exitCode(context.Background(), err), err)
+
+	golden := filepath.Join("testdata", "report.json")
+	if *updateGolden {
+		if err := os.WriteFile(golden, out.Bytes(), 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if out.String() != string(want) {
+		t.Errorf("report differs from %s:\n%s", golden, out.String())
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/config"
+	"github.com/mdwit/spec2llms/internal/generator"
+	"github.com/mdwit/spec2llms/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+var (
+	namingFrom string
+	namingTo   string
+)
+
+// rename переименовывает файлы вывода; подменяется в тестах, чтобы проверить откат
+var rename = os.Rename
+
+// newMigrateNamesCmd создаёт команду migrate-names: переименование файлов эндпоинтов
+// под другую стратегию именования с картой редиректов для внешних ссылок
+func newMigrateNamesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "migrate-names [source]",
+		Short:   "Rename endpoint files to another naming strategy and write a redirects map",
+		Example: `  spec2llms migrate-names api.yaml -o ./llms --from path --to operationId`,
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    runMigrateNames,
+	}
+	cmd.Flags().StringVarP(&cfgFile, "config", "c", "", "config file (spec2llms.json)")
+	cmd.Flags().StringVarP(&output, "output", "o", "./llms", "output directory")
+	cmd.Flags().StringVar(&namingFrom, "from", config.FileNamingPath, "current naming strategy (path, operationId)")
+	cmd.Flags().StringVar(&namingTo, "to", config.FileNamingOperationID, "new naming strategy (path, operationId)")
+	cmd.Flags().BoolVar(&skipValidation, "skip-validation", false, "skip OpenAPI spec validation")
+	cmd.Flags().BoolVar(&offline, "offline", false, "forbid all network access (URL sources, remote refs)")
+	return cmd
+}
+
+func runMigrateNames(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig(args)
+	if err != nil {
+		return usageError(err)
+	}
+	for _, naming := range []string{namingFrom, namingTo} {
+		check := *cfg
+		check.FileNaming = naming
+		if err := check.Validate(); err != nil {
+			return usageError(err)
+		}
+	}
+
+	api, err := parser.ParseContext(cmd.Context(), cfg.Source, &parser.ParseOptions{
+		SkipValidation: cfg.SkipValidation,
+		Offline:        cfg.Offline,
+		Preprocess:     preParseHooks(cmd.Context(), cfg),
+	})
+	if err != nil {
+		return parseError(cfg, err)
+	}
+
+	fromCfg, toCfg := *cfg, *cfg
+	fromCfg.FileNaming, toCfg.FileNaming = namingFrom, namingTo
+	oldFiles := generator.New(&fromCfg, api).EndpointFiles()
+	newFiles := generator.New(&toCfg, api).EndpointFiles()
+
+	redirects := make(map[string]string)
+	for op, oldPath := range oldFiles {
+		if newPath := newFiles[op]; newPath != oldPath {
+			redirects[oldPath] = newPath
+		}
+	}
+	if len(redirects) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "Nothing to rename")
+		return nil
+	}
+
+	redirectsPath := filepath.Join(cfg.Output, generator.RedirectsFile)
+	previous, err := readRedirects(redirectsPath)
+	if err != nil {
+		return err
+	}
+	renamed, err := renameOutputFiles(cfg.Output, redirects)
+	if err != nil {
+		return err
+	}
+	if err := rewriteLinks(cfg.Output, redirects); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(mergeRedirects(previous, redirects, newFiles), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(redirectsPath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", generator.RedirectsFile, err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Renamed %d files, redirects written to %s\n", renamed, filepath.Join(cfg.Output, generator.RedirectsFile))
+	fmt.Fprintf(cmd.OutOrStdout(), "Set \"fileNaming\": %q in your config to keep the new names\n", namingTo)
+	return nil
+}
+
+// readRedirects читает redirects.json прошлых переименований; нет файла — пустая карта
+func readRedirects(path string) (map[string]string, error) {
+	redirects := map[string]string{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return redirects, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &redirects); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return redirects, nil
+}
+
+// mergeRedirects дополняет карту прошлых переименований новыми. Старые редиректы переводятся
+// на новые имена, чтобы ссылки не вели через цепочку, а старые имена, снова занятые файлами
+// эндпоинтов (files), из карты убираются
+func mergeRedirects(previous, redirects, files map[string]string) map[string]string {
+	merged := make(map[string]string, len(previous)+len(redirects))
+	for oldPath, target := range previous {
+		if newPath, ok := redirects[target]; ok {
+			target = newPath
+		}
+		if target != oldPath {
+			merged[oldPath] = target
+		}
+	}
+	for _, current := range files {
+		delete(merged, current)
+	}
+	maps.Copy(merged, redirects)
+	return merged
+}
+
+// renameOutputFiles переименовывает существующие файлы. Переименование идёт через временные
+// имена, чтобы цепочки и обмены (a → b, b → a) не перезаписывали файлы. При ошибке уже
+// выполненные переименования откатываются, и файлы остаются под старыми именами
+func renameOutputFiles(output string, redirects map[string]string) (int, error) {
+	oldPaths := make([]string, 0, len(redirects))
+	for oldPath := range redirects {
+		oldPaths = append(oldPaths, oldPath)
+	}
+	sort.Strings(oldPaths)
+
+	file := func(rel string) string { return filepath.Join(output, filepath.FromSlash(rel)) }
+	temp := func(oldPath string) string { return file(oldPath) + ".migrating" }
+
+	// Файл, который не переименовывается сам, не перезаписывается
+	for _, oldPath := range oldPaths {
+		newPath := redirects[oldPath]
+		if _, ok := redirects[newPath]; ok {
+			continue
+		}
+		if _, err := os.Lstat(file(newPath)); err == nil {
+			return 0, fmt.Errorf("cannot rename %s: %s already exists", oldPath, newPath)
+		}
+	}
+
+	var moved, renamed []string
+	// rollback возвращает переименованные файлы на временные имена, а затем все временные — на старые
+	rollback := func(err error) (int, error) {
+		errs := []error{err}
+		for i := len(renamed) - 1; i >= 0; i-- {
+			if err := rename(file(redirects[renamed[i]]), temp(renamed[i])); err != nil {
+				errs = append(errs, fmt.Errorf("failed to roll back %s: %w", renamed[i], err))
+			}
+		}
+		for i := len(moved) - 1; i >= 0; i-- {
+			if err := rename(temp(moved[i]), file(moved[i])); err != nil {
+				errs = append(errs, fmt.Errorf("failed to roll back %s: %w", moved[i], err))
+			}
+		}
+		return 0, errors.Join(errs...)
+	}
+
+	for _, oldPath := range oldPaths {
+		if err := rename(file(oldPath), temp(oldPath)); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return rollback(fmt.Errorf("failed to rename %s: %w", file(oldPath), err))
+		}
+		moved = append(moved, oldPath)
+	}
+
+	for _, oldPath := range moved {
+		if err := rename(temp(oldPath), file(redirects[oldPath])); err != nil {
+			return rollback(fmt.Errorf("failed to rename %s: %w", temp(oldPath), err))
+		}
+		renamed = append(renamed, oldPath)
+	}
+	return len(moved), nil
+}
+
+// rewriteLinks заменяет ссылки на старые имена в llms.txt, HTML-страницах и других текстовых файлах вывода.
+// Ссылки между файлами одной директории (связанные операции) содержат только имя файла
+// и заменяются только в этой директории
+func rewriteLinks(output string, redirects map[string]string) error {
+	var pairs []string
+	local := map[string][]string{} // директория вывода через / → замены ссылок по имени файла
+	for oldPath, newPath := range redirects {
+		// Ссылка заканчивается именем файла (Markdown или href="…") или продолжается якорем операции
+		for _, end := range []string{")", `"`, "#"} {
+			pairs = append(pairs, "/"+oldPath+end, "/"+newPath+end)
+		}
+		dir, oldBase, newBase := path.Dir(oldPath), path.Base(oldPath), path.Base(newPath)
+		for _, start := range []string{"(", `"`} {
+			for _, end := range []string{")", `"`, "#"} {
+				local[dir] = append(local[dir], start+oldBase+end, start+newBase+end)
+			}
+		}
+	}
+	replacers := map[string]*strings.Replacer{}
+	replacerFor := func(dir string) *strings.Replacer {
+		if r, ok := replacers[dir]; ok {
+			return r
+		}
+		r := strings.NewReplacer(append(slices.Clone(pairs), local[dir]...)...)
+		replacers[dir] = r
+		return r
+	}
+
+	return filepath.WalkDir(output, func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !isTextOutput(file) {
+			return err
+		}
+		rel, err := filepath.Rel(output, file)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if updated := replacerFor(path.Dir(filepath.ToSlash(rel))).Replace(string(data)); updated != string(data) {
+			return os.WriteFile(file, []byte(updated), 0644)
+		}
+		return nil
+	})
+}
+
+// isTextOutput сообщает, что файл вывода содержит ссылки на файлы эндпоинтов: llms.txt,
+// файл эндпоинта (.txt, .md) или HTML-страница
+func isTextOutput(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == "."+config.FileExtensionTxt || ext == "."+config.FileExtensionMD || ext == ".html"
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/mdwit/spec2llms/internal/config"
+)
+
+// namedConfig — конфиг одного запуска генерации; name пусто, если профили не выбраны
+type namedConfig struct {
+	name string
+	cfg  *config.Config
+}
+
+// loadProfiles возвращает конфиги запуска: профиль из --profile, все профили с --all-profiles
+// или единственный основной конфиг. CLI флаги применяются поверх каждого профиля
+func loadProfiles(args []string) ([]namedConfig, error) {
+	base, err := loadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	switch {
+	case profile != "" && allProfiles:
+		return nil, errors.New("--profile and --all-profiles are mutually exclusive")
+	case allProfiles:
+		names = base.ProfileNames()
+		if len(names) == 0 {
+			return nil, errors.New("--all-profiles: the config defines no profiles")
+		}
+	case profile != "":
+		names = []string{profile}
+	default:
+		applyFlags(base, args)
+		return []namedConfig{{cfg: base}}, nil
+	}
+
+	configs := make([]namedConfig, 0, len(names))
+	outputs := make(map[string]string) // директория вывода → профиль
+	for _, name := range names {
+		cfg, err := base.Profile(name)
+		if err != nil {
+			return nil, err
+		}
+		applyFlags(cfg, args)
+		// Профили с общей директорией перезаписали бы вывод друг друга
+		output := filepath.Clean(cfg.Output)
+		if other, ok := outputs[output]; ok {
+			return nil, fmt.Errorf("profiles %s and %s both write to %s; set a distinct output for each", other, name, cfg.Output)
+		}
+		outputs[output] = name
+		configs = append(configs, namedConfig{name: name, cfg: cfg})
+	}
+	return configs, nil
+}
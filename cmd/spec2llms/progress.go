@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mdwit/spec2llms/internal/generator"
+)
+
+// progressInterval — минимальный интервал между перерисовками индикатора
+const progressInterval = 100 * time.Millisecond
+
+// isTerminal сообщает, что stdout — терминал, а не файл или pipe
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// progressBar рисует в терминале индикатор генерации: файлы, группы и оставшееся время
+type progressBar struct {
+	start time.Time
+	last  time.Time
+	drawn bool
+}
+
+func newProgressBar() *progressBar {
+	return &progressBar{start: time.Now()}
+}
+
+// Update перерисовывает индикатор не чаще progressInterval; последнее состояние рисуется всегда
+func (b *progressBar) Update(p generator.Progress) {
+	now := time.Now()
+	done := p.Files >= p.TotalFiles
+	if !done && now.Sub(b.last) < progressInterval {
+		return
+	}
+	b.last = now
+
+	const width = 30
+	filled := 0
+	if p.TotalFiles > 0 {
+		filled = width * p.Files / p.TotalFiles
+	}
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", width-filled)
+
+	line := fmt.Sprintf("[%s] %d/%d files", bar, p.Files, p.TotalFiles)
+	if p.TotalGroups > 0 {
+		line += fmt.Sprintf(" · %d/%d groups", p.Groups, p.TotalGroups)
+	}
+	if p.Files > 0 && !done {
+		elapsed := now.Sub(b.start)
+		eta := elapsed * time.Duration(p.TotalFiles-p.Files) / time.Duration(p.Files)
+		line += " · ETA " + eta.Round(time.Second).String()
+	}
+	fmt.Fprintf(console, "\r\033[K%s", line)
+	b.drawn = true
+}
+
+// Finish стирает индикатор, чтобы следующие сообщения начинались с чистой строки
+func (b *progressBar) Finish() {
+	if b.drawn {
+		fmt.Fprint(console, "\r\033[K")
+	}
+}
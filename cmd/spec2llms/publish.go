@@ -0,0 +1,34 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mdwit/spec2llms/internal/config"
+	"github.com/mdwit/spec2llms/internal/publish"
+)
+
+// defaultPublishAuthEnv — переменная окружения со значением заголовка авторизации для https://
+const defaultPublishAuthEnv = "SPEC2LLMS_PUBLISH_AUTH"
+
+// publishOutput выгружает сгенерированную директорию по адресу publish.url. Версионированный
+// вывод выгружается целиком, от корня versionRoot: вместе с версией уходят latest/ и versions.txt
+func publishOutput(ctx context.Context, cfg *config.Config, versionRoot string) (*publish.Result, error) {
+	dir := cmp.Or(versionRoot, cfg.Output)
+	target := cfg.Publish.URL
+
+	fmt.Fprintf(console, "Publishing to %s\n", publish.Redacted(target))
+	result, err := publish.Sync(ctx, dir, target, publish.Options{
+		CacheControl: cfg.Publish.CacheControl,
+		DeleteStale:  cfg.Publish.DeleteStale,
+		AuthHeader:   cfg.Publish.AuthHeader,
+		Auth:         os.Getenv(cmp.Or(cfg.Publish.AuthEnv, defaultPublishAuthEnv)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish: %w", err)
+	}
+	fmt.Fprintf(console, "Published %d files (%d unchanged, %d deleted)\n", result.Uploaded, result.Unchanged, result.Deleted)
+	return result, nil
+}
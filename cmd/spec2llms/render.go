@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mdwit/spec2llms/internal/generator"
+	"github.com/mdwit/spec2llms/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+var (
+	specSource  string
+	operationID string
+)
+
+// newRenderEndpointCmd создаёт команду render-endpoint: секция одной операции в stdout
+func newRenderEndpointCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render-endpoint",
+		Short: "Print the generated section of a single operation",
+		Example: `  spec2llms render-endpoint --spec api.yaml --op getUserById
+  spec2llms render-endpoint --spec api.yaml --op "GET /users/{id}"`,
+		Args: cobra.NoArgs,
+		RunE: runRenderEndpoint,
+	}
+	cmd.Flags().StringVarP(&cfgFile, "config", "c", "", "config file (spec2llms.json)")
+	cmd.Flags().StringVar(&specSource, "spec", "", "OpenAPI spec file or URL")
+	cmd.Flags().StringVar(&operationID, "op", "", `operation: operationId or "METHOD /path"`)
+	addLanguageFlag(cmd)
+	cmd.Flags().StringSliceVar(&examples, "examples", nil, "example formats (curl, powershell, httpie)")
+	cmd.Flags().BoolVar(&skipValidation, "skip-validation", false, "skip OpenAPI spec validation")
+	cmd.Flags().BoolVar(&offline, "offline", false, "forbid all network access (URL sources, remote refs)")
+	cmd.MarkFlagRequired("op")
+	return cmd
+}
+
+func runRenderEndpoint(cmd *cobra.Command, args []string) error {
+	if specSource != "" {
+		args = []string{specSource}
+	}
+	cfg, err := loadConfig(args)
+	if err != nil {
+		return usageError(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return usageError(err)
+	}
+
+	api, err := parser.ParseContext(cmd.Context(), cfg.Source, &parser.ParseOptions{
+		SkipValidation: cfg.SkipValidation,
+		Offline:        cfg.Offline,
+		Preprocess:     preParseHooks(cmd.Context(), cfg),
+	})
+	if err != nil {
+		return parseError(cfg, err)
+	}
+
+	section, err := generator.New(cfg, api).EndpointSection(operationID)
+	if err != nil {
+		return err
+	}
+	fmt.Print(section)
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/config"
+	"github.com/mdwit/spec2llms/internal/generator"
+	"github.com/mdwit/spec2llms/internal/tokens"
+)
+
+// tokenReport — оценка числа токенов в файлах запуска: по каждому файлу верхнего уровня
+// и суммарно по директориям (endpoints/ и т.п.)
+type tokenReport struct {
+	tokenizer string
+	totals    map[string]int
+	files     map[string]int // число файлов в директории
+}
+
+// estimateTokens оценивает токены в файлах, записанных запуском (gen.Files()), а не во всей
+// директории вывода: посторонние файлы в ней в оценку не попадают
+func estimateTokens(cfg *config.Config, files []generator.GeneratedFile) (*tokenReport, error) {
+	estimator, err := tokens.Get(cfg.Tokenizer)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &tokenReport{tokenizer: estimator.Name(), totals: make(map[string]int), files: make(map[string]int)}
+	for _, f := range files {
+		if f.Path == generator.ManifestFile || f.Path == generator.RedirectsFile {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.Output, filepath.FromSlash(f.Path)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate tokens: %w", err)
+		}
+		// Файлы во вложенных директориях суммируются по директории: endpoints/
+		entry, _, nested := strings.Cut(f.Path, "/")
+		if nested {
+			entry += "/"
+		}
+		report.totals[entry] += estimator.Count(string(data))
+		report.files[entry]++
+	}
+	return report, nil
+}
+
+// print печатает оценку в w по записям в алфавитном порядке
+func (r *tokenReport) print(w io.Writer) {
+	entries := make([]string, 0, len(r.totals))
+	for entry := range r.totals {
+		entries = append(entries, entry)
+	}
+	sort.Strings(entries)
+
+	fmt.Fprintf(w, "Estimated tokens (%s, approximate):\n", r.tokenizer)
+	for _, entry := range entries {
+		if strings.HasSuffix(entry, "/") {
+			fmt.Fprintf(w, "  %s — %d (%d files)\n", entry, r.totals[entry], r.files[entry])
+		} else {
+			fmt.Fprintf(w, "  %s — %d\n", entry, r.totals[entry])
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mdwit/spec2llms/internal/generator"
+	"github.com/mdwit/spec2llms/internal/hooks"
+	"github.com/mdwit/spec2llms/internal/parser"
+	"github.com/mdwit/spec2llms/internal/publish"
+)
+
+// Форматы итогового отчёта о запуске (--report)
+const (
+	reportText = "text" // только сообщения для человека
+	reportJSON = "json" // JSON-сводка в stdout, сообщения в stderr
+)
+
+// console — поток сообщений о ходе генерации. С --report json это stderr,
+// чтобы stdout содержал только сводку
+var console = os.Stdout
+
+// runReport — сводка запуска для оркестраторов, печатается в stdout с --report json
+type runReport struct {
+	Status     string       `json:"status"` // ok или error
+	ExitCode   int          `json:"exitCode"`
+	Error      string       `json:"error,omitempty"`
+	DryRun     bool         `json:"dryRun,omitempty"` // files — файлы, которые были бы записаны
+	DurationMs int64        `json:"durationMs"`
+	Runs       []runSummary `json:"runs"`
+}
+
+// runSummary — итог генерации по одному конфигу (профилю)
+type runSummary struct {
+	Profile    string                    `json:"profile,omitempty"`
+	Source     string                    `json:"source"`
+	Output     string                    `json:"output"`
+	Endpoints  int                       `json:"endpoints"`
+	Files      []generator.GeneratedFile `json:"files"`
+	Bytes      int64                     `json:"bytes"`
+	Warnings   []parser.Warning          `json:"warnings"`
+	Tokens     map[string]int            `json:"tokens,omitempty"` // оценка токенов по файлу или директории (endpoints/)
+	Publish    *publish.Result           `json:"publish,omitempty"`
+	ParseMs    int64                     `json:"parseMs"`
+	GenerateMs int64                     `json:"generateMs"`
+}
+
+// validateReport проверяет значение --report и переключает сообщения в stderr для json
+func validateReport() error {
+	switch reportFormat {
+	case "", reportText:
+	case reportJSON:
+		console = os.Stderr
+		hooks.Stdout = os.Stderr
+	default:
+		return usageError(fmt.Errorf("invalid report %q (expected text or json)", reportFormat))
+	}
+	return nil
+}
+
+// setFiles записывает в сводку файлы запуска и их суммарный размер
+func (s *runSummary) setFiles(files []generator.GeneratedFile) {
+	s.Files = files
+	s.Bytes = 0
+	for _, f := range files {
+		s.Bytes += f.Size
+	}
+}
+
+// printReport печатает сводку в w; ошибка запуска err попадает в status и exitCode
+func printReport(w io.Writer, report *runReport, duration time.Duration, code int, err error) {
+	report.Status = "ok"
+	report.ExitCode = code
+	if err != nil {
+		report.Status = "error"
+		report.Error = err.Error()
+	}
+	report.DurationMs = duration.Milliseconds()
+	if report.Runs == nil {
+		report.Runs = []runSummary{}
+	}
+	for i := range report.Runs {
+		if report.Runs[i].Files == nil {
+			report.Runs[i].Files = []generator.GeneratedFile{}
+		}
+		if report.Runs[i].Warnings == nil {
+			report.Runs[i].Warnings = []parser.Warning{}
+		}
+	}
+
+	data, _ := json.MarshalIndent(report, "", "  ")
+	w.Write(append(data, '\n'))
+}
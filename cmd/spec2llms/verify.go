@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mdwit/spec2llms/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// newVerifyCmd создаёт команду verify: проверка развёрнутой копии документации по manifest.json
+func newVerifyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "verify <dir>",
+		Short:   "Exit 1 if files in dir do not match its manifest.json",
+		Example: `  spec2llms verify ./public/llms`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runVerify,
+	}
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	dir := args[0]
+	problems, err := generator.Verify(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(problems) == 0 {
+		fmt.Printf("%s matches %s\n", dir, generator.PublishManifestFile)
+		return nil
+	}
+	for _, p := range problems {
+		fmt.Printf("  %-10s %s\n", p.Kind, p.Path)
+	}
+	cmd.SilenceUsage = true
+	return fmt.Errorf("%s does not match %s: %d problems", dir, generator.PublishManifestFile, len(problems))
+}
@@ -2,25 +2,279 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Режимы группировки эндпоинтов
+const (
+	GroupByTag    = "tag"
+	GroupByPath   = "path"
+	GroupByXGroup = "x-group" // расширение x-group операции, с откатом к тегу
+	GroupByNone   = "none"    // все эндпоинты в одном llms.txt без директории endpoints
+)
+
+// Стратегии сортировки эндпоинтов
+const (
+	SortPath        = "path"
+	SortMethod      = "method"
+	SortOperationID = "operationId"
+	SortSpecOrder   = "spec-order" // порядок, в котором пути записаны в спецификации
+)
+
+// Языки заголовков секций
+const (
+	LanguageEN   = "en"
+	LanguageRU   = "ru"
+	LanguageAuto = "auto" // по преобладающему языку описаний спецификации
+)
+
+// Токенизаторы для оценки числа токенов
+const (
+	TokenizerCL100K = "cl100k" // GPT-4, GPT-3.5
+	TokenizerO200K  = "o200k"  // GPT-4o и новее
+	TokenizerLlama  = "llama"
+	TokenizerBytes  = "bytes" // 4 байта на токен без учёта модели
+)
+
+// Форматы вывода
+const (
+	FormatText   = "text"   // llms.txt и файлы эндпоинтов
+	FormatHTML   = "html"   // статический сайт для ревью документации людьми
+	FormatJSON   = "json"   // нормализованная модель API (api.json) для сторонних инструментов
+	FormatChunks = "chunks" // фрагменты документации в JSONL для векторных баз (RAG)
+)
+
+// Стратегии именования файлов эндпоинтов
+const (
+	FileNamingPath        = "path"        // get-users-id.txt
+	FileNamingOperationID = "operationId" // getUserById.txt; без operationId — по пути
+)
+
+// Расширения файлов эндпоинтов
+const (
+	FileExtensionTxt = "txt"
+	FileExtensionMD  = "md" // для хостингов, которые рендерят Markdown (GitHub Pages, порталы документации)
+)
+
+// Раскладки списка эндпоинтов в llms.txt
+const (
+	IndexLayoutGroups = "groups" // ссылки на эндпоинты по группам
+	IndexLayoutMatrix = "matrix" // таблица ресурс × метод
+)
+
+// Форматы примеров запросов
+const (
+	ExampleCurl       = "curl"
+	ExamplePowerShell = "powershell" // Invoke-RestMethod
+	ExampleHTTPie     = "httpie"
 )
 
 type Config struct {
 	Source         string `json:"source"`
 	Output         string `json:"output"`
 	BaseURL        string `json:"baseUrl"`
-	DocsBaseURL    string `json:"docsBaseUrl"`    // базовый URL для ссылок на документацию (llms.txt)
+	Server         string `json:"server"`      // сервер из spec для примеров: индекс, description или URL
+	DocsBaseURL    string `json:"docsBaseUrl"` // базовый URL для ссылок на документацию (llms.txt)
 	Title          string `json:"title"`
-	Language       string `json:"language"`
-	GroupBy        string `json:"groupBy"`        // tag, path
+	Language       string `json:"language"`       // en, ru, auto
+	GroupBy        string `json:"groupBy"`        // tag, path, x-group, none
+	Sort           string `json:"sort"`           // path, method, operationId, spec-order
+	Format         string `json:"format"`         // text, html, json, chunks
+	FileNaming     string `json:"fileNaming"`     // path, operationId
+	FileExtension  string `json:"fileExtension"`  // txt, md
+	EndpointsDir   string `json:"endpointsDir"`   // директория файлов эндпоинтов (по умолчанию endpoints; "." — рядом с llms.txt)
+	IndexLayout    string `json:"indexLayout"`    // groups, matrix
 	SkipValidation bool   `json:"skipValidation"` // пропустить валидацию OpenAPI
+	BestEffort     bool   `json:"bestEffort"`     // пропускать только невалидные пути и операции
+	Offline        bool   `json:"offline"`        // запретить любой доступ к сети
+
+	// SharedFragments — выносить повторяющиеся абзацы описаний в общую секцию llms.txt
+	SharedFragments bool `json:"sharedFragments"`
+
+	// SectionMarkers — оборачивать секции HTML-комментариями с id операций для diff/патчинга
+	SectionMarkers bool `json:"sectionMarkers"`
+
+	// MaxSchemaDepth — глубина раскрытия вложенных объектов в схемах (0 — по умолчанию, 4)
+	MaxSchemaDepth int `json:"maxSchemaDepth"`
+	// MaxPropertiesPerObject — сколько полей объекта показывать в документации схем (0 — все)
+	MaxPropertiesPerObject int `json:"maxPropertiesPerObject"`
+	// MaxDescriptionLength — сколько символов описания операции выводить (0 — без ограничения)
+	MaxDescriptionLength int `json:"maxDescriptionLength"`
+
+	// Examples — форматы примеров запросов: curl (по умолчанию), powershell, httpie
+	Examples []string `json:"examples"`
+
+	// ContentTypes — предпочитаемые типы содержимого тела запроса по порядку (по умолчанию application/json)
+	ContentTypes []string `json:"contentTypes"`
+	// AllContentTypes — показывать отдельный пример запроса для каждого типа содержимого тела
+	AllContentTypes bool `json:"allContentTypes"`
+
+	// CurlScripts — дополнительно записывать примеры curl в examples/<operationId>.sh и smoke.sh
+	CurlScripts bool `json:"curlScripts"`
+
+	// Sections — написанные вручную секции Markdown, вставляемые в llms.txt
+	Sections []Section `json:"sections"`
+
+	// Tags — переопределения групп по имени тега (или x-group, сегмента пути)
+	Tags map[string]TagOverride `json:"tags"`
+
+	// OptionalTags — группы, выносимые в секцию "## Optional" llms.txt (как и теги с x-llms-optional: true)
+	OptionalTags []string `json:"optionalTags"`
+
+	// GroupBaseURLs — базовые URL для отдельных групп (тегов/x-group), например billing → https://billing.example.com
+	GroupBaseURLs map[string]string `json:"groupBaseUrls"`
+
+	// Placeholders — значения-заглушки в примерах запросов (токены, id из sandbox)
+	Placeholders Placeholders `json:"placeholders"`
+
+	// Renderer — внешний рендерер вместо встроенного формата: имя зарегистрированного в generator.RegisterRenderer,
+	// exec:<команда> (JSON-модель на stdin, файлы на stdout) или plugin:<файл.so> (Go-плагин)
+	Renderer string `json:"renderer"`
+
+	// Tokenizer — токенизатор для приблизительной оценки размера вывода: cl100k (по умолчанию), o200k, llama, bytes
+	Tokenizer string `json:"tokenizer"`
+
+	// CacheDir — директория кэша секций эндпоинтов между запусками (пусто — кэш только в памяти)
+	CacheDir string `json:"cacheDir"`
+
+	// Incremental — хранить хеши файлов в манифесте и не перезаписывать неизменённые
+	Incremental bool `json:"incremental"`
+
+	// Filter — какие операции попадают в документацию
+	Filter Filter `json:"filter"`
+	// Audience — включать только операции, в x-audience которых (или их тегов) есть эта аудитория
+	Audience string `json:"audience"`
+	// ExcludedReport — записывать excluded.json со списком исключённых операций и причинами
+	ExcludedReport bool `json:"excludedReport"`
+
+	// Versioned — писать в <output>/<info.version>/, поддерживая ссылку latest и versions.txt в корне
+	Versioned bool `json:"versioned"`
+
+	// PublishManifest — записывать manifest.json с хешем и размером каждого файла для spec2llms verify
+	PublishManifest bool `json:"publishManifest"`
+
+	// SearchIndex — записывать search.json: ключевые слова операций → файлы и якоря
+	SearchIndex bool `json:"searchIndex"`
+
+	// SchemaGlossary — записывать schemas.txt: именованные схемы components.schemas с описанием и полями
+	SchemaGlossary bool `json:"schemaGlossary"`
+
+	// Hooks — команды и Go-колбэки до парсинга и после генерации
+	Hooks Hooks `json:"hooks"`
+
+	// Publish — выгрузка сгенерированной директории в объектное хранилище после генерации
+	Publish Publish `json:"publish"`
+
+	// Sandbox — тестовое окружение для секции быстрого старта "Try it" в llms.txt
+	Sandbox *Sandbox `json:"sandbox"`
+
+	// ResponseExamplesDir — директория с примерами ответов вида <operationId>.<status>.json
+	ResponseExamplesDir string `json:"responseExamplesDir"`
+
+	// Workflows — документ Arazzo (YAML или JSON) со сценариями из нескольких вызовов
+	Workflows string `json:"workflows"`
+
+	// Overrides — YAML-файл правок операций (operationId или "METHOD /path"), применяемых поверх спецификации
+	Overrides string `json:"overrides"`
+
+	// Enrich — LLM для команды spec2llms enrich, переписывающей краткие описания операций в overrides
+	Enrich Enrich `json:"enrich"`
+
+	// NoAutoSummaries — не синтезировать summary операциям без него (по методу, пути и схеме ответа)
+	NoAutoSummaries bool `json:"noAutoSummaries"`
+
+	// LintRuleset — YAML-набор правил spec2llms lint: уровни, отключение и собственные правила
+	LintRuleset string `json:"lintRuleset"`
+
+	// Profiles — именованные варианты вывода из одной спецификации: каждый профиль — частичный
+	// конфиг, поля которого заменяют поля основного (например, свой output и filter)
+	Profiles map[string]json.RawMessage `json:"profiles,omitempty"`
+}
+
+// Placeholders задаёт значения, подставляемые в примеры запросов вместо заглушек,
+// чтобы примеры можно было сразу выполнить против sandbox-окружения
+type Placeholders struct {
+	APIKey string `json:"apiKey"` // вместо YOUR_API_KEY, например ${API_TOKEN}
+	Token  string `json:"token"`  // вместо YOUR_TOKEN в Authorization: Bearer
+	Basic  string `json:"basic"`  // вместо YOUR_USERNAME:YOUR_PASSWORD для HTTP Basic
+
+	// Params — значения path- и query-параметров: по имени параметра (id)
+	// или для конкретной операции (getUser.id), последнее приоритетнее
+	Params map[string]string `json:"params"`
+}
+
+// Filter отбирает операции по пути и тегам. Исключения приоритетнее включений;
+// пустой список include не ограничивает. Шаблоны путей: * — один сегмент, /** в конце — любое число сегментов
+type Filter struct {
+	IncludePaths []string `json:"includePaths"`
+	ExcludePaths []string `json:"excludePaths"`
+	IncludeTags  []string `json:"includeTags"`
+	ExcludeTags  []string `json:"excludeTags"`
+}
+
+// TagOverride переопределяет то, что выводится из спецификации для группы
+type TagOverride struct {
+	Title       string `json:"title"`       // отображаемое имя группы
+	Description string `json:"description"` // описание вместо описания тега
+	Filename    string `json:"filename"`    // замена общего префикса путей в именах файлов группы
+	Order       int    `json:"order"`       // позиция группы: меньшие идут первыми, 0 — без закрепления
+}
+
+// Section — пользовательская секция llms.txt (вступление, quickstart, условия использования)
+type Section struct {
+	Title   string `json:"title"`   // заголовок ## (необязателен)
+	Content string `json:"content"` // текст Markdown
+	File    string `json:"file"`    // или файл Markdown вместо content
+
+	// Position — точка вставки: top, end (по умолчанию), before:<id> или after:<id>
+	// секции индекса (authentication, errors, endpoints, optional...)
+	Position string `json:"position"`
+}
+
+// Enrich — OpenAI-совместимый API для обогащения описаний
+type Enrich struct {
+	Endpoint  string `json:"endpoint"`  // базовый URL, например https://api.openai.com/v1
+	Model     string `json:"model"`     // например gpt-4o-mini
+	APIKeyEnv string `json:"apiKeyEnv"` // переменная окружения с ключом (по умолчанию OPENAI_API_KEY)
+	MinLength int    `json:"minLength"` // описания короче — переписываются (по умолчанию 40)
+	Cache     string `json:"cache"`     // JSON-кеш ответов модели (по умолчанию .spec2llms-enrich-cache.json)
+}
+
+// Hooks встраивает генерацию в существующий пайплайн документации. Элемент списка —
+// команда shell или go:<имя> для колбэка, зарегистрированного в пакете hooks
+type Hooks struct {
+	PreParse     []string `json:"preParse"`     // получают спецификацию на stdin и печатают изменённую на stdout
+	PostGenerate []string `json:"postGenerate"` // запускаются после генерации, например загрузка в S3
+}
+
+// Publish описывает выгрузку вывода. Учётные данные берутся из переменных окружения
+// (AWS_ACCESS_KEY_ID, GOOGLE_OAUTH_ACCESS_TOKEN, AZURE_STORAGE_KEY, AuthEnv), а не из конфига
+type Publish struct {
+	URL          string `json:"url"`          // s3://bucket/prefix, gs://bucket/prefix, az://account/container/prefix или https://host/path
+	CacheControl string `json:"cacheControl"` // Cache-Control файлов (по умолчанию public, max-age=300)
+	DeleteStale  bool   `json:"deleteStale"`  // удалять объекты под префиксом, которых больше нет в выводе
+	AuthHeader   string `json:"authHeader"`   // заголовок авторизации для https:// (по умолчанию Authorization)
+	AuthEnv      string `json:"authEnv"`      // переменная окружения со значением заголовка (по умолчанию SPEC2LLMS_PUBLISH_AUTH)
+}
+
+// Sandbox описывает тестовое окружение, на котором агент может сразу выполнить запрос
+type Sandbox struct {
+	BaseURL     string            `json:"baseUrl"`
+	Credentials string            `json:"credentials"` // как получить демо-доступ, например "use API key demo-key"
+	SeedData    map[string]string `json:"seedData"`    // id заранее созданных объектов по имени параметра
+	Operation   string            `json:"operation"`   // operationId или "METHOD /path" запроса-примера
 }
 
 func DefaultConfig() *Config {
 	return &Config{
 		Output:   "./llms",
-		Language: "en",
-		GroupBy:  "tag",
+		Language: LanguageEN,
+		GroupBy:  GroupByTag,
 	}
 }
 
@@ -38,9 +292,107 @@ func LoadFromFile(path string) (*Config, error) {
 	return cfg, nil
 }
 
+// ProfileNames возвращает имена профилей по алфавиту
+func (c *Config) ProfileNames() []string {
+	return slices.Sorted(maps.Keys(c.Profiles))
+}
+
+// Profile возвращает конфиг профиля name: копию основного конфига, поверх которой разобран
+// JSON профиля. Поля-объекты (filter, placeholders) дополняются, списки заменяются целиком
+func (c *Config) Profile(name string) (*Config, error) {
+	raw, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (available: %s)", ErrUnknownProfile, name, strings.Join(c.ProfileNames(), ", "))
+	}
+	base := *c
+	base.Profiles = nil
+	data, err := json.Marshal(&base)
+	if err != nil {
+		return nil, err
+	}
+	profile := &Config{}
+	if err := json.Unmarshal(data, profile); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, profile); err != nil {
+		return nil, fmt.Errorf("invalid profile %q: %w", name, err)
+	}
+	profile.Profiles = nil
+	return profile, nil
+}
+
 func (c *Config) Validate() error {
 	if c.Source == "" {
 		return ErrSourceRequired
 	}
+	switch c.GroupBy {
+	case "", GroupByTag, GroupByPath, GroupByXGroup, GroupByNone:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidGroupBy, c.GroupBy)
+	}
+	switch c.Sort {
+	case "", SortPath, SortMethod, SortOperationID, SortSpecOrder:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidSort, c.Sort)
+	}
+	switch c.Language {
+	case "", LanguageEN, LanguageRU, LanguageAuto:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidLanguage, c.Language)
+	}
+	switch c.Format {
+	case "", FormatText, FormatHTML, FormatJSON, FormatChunks:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidFormat, c.Format)
+	}
+	switch c.FileNaming {
+	case "", FileNamingPath, FileNamingOperationID:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidFileNaming, c.FileNaming)
+	}
+	switch c.FileExtension {
+	case "", FileExtensionTxt, FileExtensionMD:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidFileExtension, c.FileExtension)
+	}
+	if dir := filepath.ToSlash(filepath.Clean(c.EndpointsDir)); c.EndpointsDir != "" && (filepath.IsAbs(c.EndpointsDir) || dir == ".." || strings.HasPrefix(dir, "../")) {
+		return fmt.Errorf("%w: %q", ErrInvalidEndpointsDir, c.EndpointsDir)
+	}
+	switch c.IndexLayout {
+	case "", IndexLayoutGroups, IndexLayoutMatrix:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidIndexLayout, c.IndexLayout)
+	}
+	switch c.Tokenizer {
+	case "", TokenizerCL100K, TokenizerO200K, TokenizerLlama, TokenizerBytes:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidTokenizer, c.Tokenizer)
+	}
+	for _, s := range c.Sections {
+		anchor, id, hasID := strings.Cut(s.Position, ":")
+		switch {
+		case s.Position == "", s.Position == "top", s.Position == "end":
+		case (anchor == "before" || anchor == "after") && hasID && id != "":
+		default:
+			return fmt.Errorf("%w: %q", ErrInvalidSectionPosition, s.Position)
+		}
+	}
+	if c.Sandbox != nil && c.Sandbox.BaseURL == "" {
+		return ErrSandboxBaseURLRequired
+	}
+	for _, format := range c.Examples {
+		switch format {
+		case ExampleCurl, ExamplePowerShell, ExampleHTTPie:
+		default:
+			return fmt.Errorf("%w: %q", ErrInvalidExampleFormat, format)
+		}
+	}
+	if c.Publish.URL != "" {
+		scheme, rest, _ := strings.Cut(c.Publish.URL, "://")
+		switch {
+		case !slices.Contains([]string{"s3", "gs", "az", "http", "https"}, scheme), strings.Trim(rest, "/") == "":
+			return fmt.Errorf("%w: %q", ErrInvalidPublishURL, c.Publish.URL)
+		}
+	}
 	return nil
 }
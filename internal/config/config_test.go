@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec2llms.json")
+	data := `{
+  "source": "openapi.yaml",
+  "output": "./llms",
+  "language": "ru",
+  "filter": {"excludePaths": ["/debug/**"]},
+  "profiles": {
+    "public": {"output": "./public", "filter": {"excludeTags": ["internal"]}},
+    "internal": {"output": "./internal", "examples": ["httpie"]}
+  }
+}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+
+	if names := cfg.ProfileNames(); !slices.Equal(names, []string{"internal", "public"}) {
+		t.Errorf("unexpected profile names %v", names)
+	}
+
+	public, err := cfg.Profile("public")
+	if err != nil {
+		t.Fatalf("Profile failed: %v", err)
+	}
+	if public.Output != "./public" || public.Source != "openapi.yaml" || public.Language != LanguageRU {
+		t.Errorf("profile should override output and inherit the rest, got %+v", public)
+	}
+	// Поля-объекты дополняются: исключения путей из основного конфига сохраняются
+	if !slices.Equal(public.Filter.ExcludePaths, []string{"/debug/**"}) || !slices.Equal(public.Filter.ExcludeTags, []string{"internal"}) {
+		t.Errorf("unexpected merged filter %+v", public.Filter)
+	}
+	if public.Profiles != nil {
+		t.Error("profile config should not carry profiles")
+	}
+
+	internal, _ := cfg.Profile("internal")
+	if !slices.Equal(internal.Examples, []string{"httpie"}) || len(internal.Filter.ExcludeTags) != 0 {
+		t.Errorf("profiles should not leak into each other, got %+v", internal)
+	}
+	if cfg.Output != "./llms" || len(cfg.Filter.ExcludeTags) != 0 {
+		t.Errorf("base config should stay unchanged, got %+v", cfg)
+	}
+
+	if _, err := cfg.Profile("partner"); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("expected ErrUnknownProfile, got %v", err)
+	}
+}
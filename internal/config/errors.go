@@ -3,5 +3,20 @@ package config
 import "errors"
 
 var (
-	ErrSourceRequired = errors.New("source is required")
+	ErrSourceRequired       = errors.New("source is required")
+	ErrInvalidGroupBy       = errors.New("invalid groupBy (expected tag, path, x-group or none)")
+	ErrInvalidSort          = errors.New("invalid sort (expected path, method, operationId or spec-order)")
+	ErrInvalidFormat        = errors.New("invalid format (expected text, html, json or chunks)")
+	ErrInvalidLanguage      = errors.New("invalid language (expected en, ru or auto)")
+	ErrInvalidTokenizer     = errors.New("invalid tokenizer (expected cl100k, o200k, llama or bytes)")
+	ErrInvalidFileNaming    = errors.New("invalid fileNaming (expected path or operationId)")
+	ErrInvalidIndexLayout   = errors.New("invalid indexLayout (expected groups or matrix)")
+	ErrInvalidFileExtension = errors.New("invalid fileExtension (expected txt or md)")
+	ErrInvalidEndpointsDir  = errors.New("invalid endpointsDir (expected a relative directory inside the output)")
+
+	ErrInvalidExampleFormat   = errors.New("invalid example format (expected curl, powershell or httpie)")
+	ErrSandboxBaseURLRequired = errors.New("sandbox.baseUrl is required")
+	ErrInvalidSectionPosition = errors.New("invalid section position (expected top, end, before:<id> or after:<id>)")
+	ErrUnknownProfile         = errors.New("unknown profile")
+	ErrInvalidPublishURL      = errors.New("invalid publish URL (expected s3://, gs://, az:// or https://)")
 )
@@ -0,0 +1,234 @@
+// Package enrich переписывает короткие и отсутствующие описания операций с помощью LLM
+// (OpenAI-совместимый API) и сохраняет их как правки overrides, которые объединяет генератор
+package enrich
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// DefaultMinLength — описания короче этого числа символов считаются слишком краткими
+const DefaultMinLength = 40
+
+// systemPrompt задаёт стиль переписанных описаний
+const systemPrompt = "You write API reference documentation for LLM agents. " +
+	"Rewrite the operation description into one or two short paragraphs of plain prose: " +
+	"what the operation does, when to call it, and what it returns. " +
+	"Use only facts present in the input, do not invent fields or behavior. " +
+	"Reply with the description text only, without headings or code."
+
+// Options — настройки обогащения
+type Options struct {
+	Endpoint  string // базовый URL OpenAI-совместимого API, например https://api.openai.com/v1
+	Model     string
+	APIKey    string
+	MinLength int    // 0 — DefaultMinLength
+	CacheFile string // JSON-кеш ответов модели; пусто — без кеша
+
+	Client *http.Client // nil — клиент с таймаутом 60 секунд
+}
+
+// Result — итог обогащения
+type Result struct {
+	Descriptions map[string]string // ключ операции (operationId или "METHOD /path") → новое описание
+	Cached       int               // сколько описаний взято из кеша
+}
+
+// Run переписывает описания операций, которые отсутствуют или короче MinLength.
+// Ключи в skip (уже заданные вручную правки) пропускаются
+func Run(ctx context.Context, api *parser.API, opts Options, skip map[string]bool) (*Result, error) {
+	if opts.Endpoint == "" || opts.Model == "" {
+		return nil, fmt.Errorf("enrich requires an LLM endpoint and model")
+	}
+	minLength := opts.MinLength
+	if minLength == 0 {
+		minLength = DefaultMinLength
+	}
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{Timeout: 60 * time.Second}
+	}
+
+	cache, err := loadCache(opts.CacheFile)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Descriptions: make(map[string]string)}
+	for _, ep := range api.Endpoints {
+		key := OperationKey(ep)
+		if skip[key] || len([]rune(strings.TrimSpace(ep.Description))) >= minLength {
+			continue
+		}
+
+		prompt := operationPrompt(ep)
+		hash := cacheKey(opts.Model, prompt)
+		if text, ok := cache[hash]; ok {
+			result.Descriptions[key] = text
+			result.Cached++
+			continue
+		}
+
+		text, err := complete(ctx, client, opts, prompt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to enrich %s: %w", key, err)
+		}
+		cache[hash] = text
+		result.Descriptions[key] = text
+		// Кеш сохраняется после каждого ответа, чтобы прерванный запуск не платил за них повторно
+		if err := saveCache(opts.CacheFile, cache); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// OperationKey возвращает ключ правки операции: operationId, а без него — "METHOD /path"
+func OperationKey(ep parser.Endpoint) string {
+	if ep.OperationID != "" {
+		return ep.OperationID
+	}
+	return ep.Method + " " + ep.Path
+}
+
+// operationPrompt описывает операцию для модели: метод, путь, summary, описание и параметры
+func operationPrompt(ep parser.Endpoint) string {
+	var sb strings.Builder
+	sb.WriteString("Operation: " + ep.Method + " " + ep.Path + "\n")
+	if ep.OperationID != "" {
+		sb.WriteString("operationId: " + ep.OperationID + "\n")
+	}
+	if ep.Summary != "" {
+		sb.WriteString("Summary: " + ep.Summary + "\n")
+	}
+	if len(ep.Tags) > 0 {
+		sb.WriteString("Tags: " + strings.Join(ep.Tags, ", ") + "\n")
+	}
+	if ep.Description != "" {
+		sb.WriteString("Current description: " + ep.Description + "\n")
+	}
+	for _, p := range ep.Parameters {
+		line := fmt.Sprintf("Parameter: %s (%s, %s)", p.Name, p.In, p.Type)
+		if p.Required {
+			line += " required"
+		}
+		if p.Description != "" {
+			line += " — " + p.Description
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
+
+// chatRequest и chatResponse — используемые поля Chat Completions API
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// complete отправляет запрос в /chat/completions и возвращает текст ответа
+func complete(ctx context.Context, client *http.Client, opts Options, prompt string) (string, error) {
+	body, err := json.Marshal(chatRequest{
+		Model: opts.Model,
+		Messages: []chatMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: prompt},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	url := strings.TrimSuffix(opts.Endpoint, "/") + "/chat/completions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if opts.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.APIKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP error: %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	var parsed chatResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return "", fmt.Errorf("invalid response: %w", err)
+	}
+	if len(parsed.Choices) == 0 || strings.TrimSpace(parsed.Choices[0].Message.Content) == "" {
+		return "", fmt.Errorf("empty response")
+	}
+	return strings.TrimSpace(parsed.Choices[0].Message.Content), nil
+}
+
+// cacheKey — хеш модели и запроса: изменение операции или модели инвалидирует запись
+func cacheKey(model, prompt string) string {
+	sum := sha256.Sum256([]byte(model + "\x00" + prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+func loadCache(path string) (map[string]string, error) {
+	cache := make(map[string]string)
+	if path == "" {
+		return cache, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read enrich cache: %w", err)
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("failed to parse enrich cache %s: %w", path, err)
+	}
+	return cache, nil
+}
+
+func saveCache(path string, cache map[string]string) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode enrich cache: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write enrich cache: %w", err)
+	}
+	return nil
+}
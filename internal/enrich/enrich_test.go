@@ -0,0 +1,61 @@
+package enrich
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+func TestRun(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/v1/chat/completions" || r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("unexpected request %s with auth %q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		var req chatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		if req.Model != "test-model" || !strings.Contains(req.Messages[1].Content, "Operation: GET /users") {
+			t.Errorf("unexpected request body: %+v", req)
+		}
+		w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": " Lists users page by page. \n"}}]}`))
+	}))
+	defer server.Close()
+
+	api := &parser.API{Endpoints: []parser.Endpoint{
+		{Method: "GET", Path: "/users", OperationID: "listUsers", Description: "Users"},
+		{Method: "GET", Path: "/users/{id}", OperationID: "getUser", Description: "Returns a single user with profile, settings and the list of roles."},
+		{Method: "DELETE", Path: "/users/{id}", OperationID: "deleteUser"},
+	}}
+	opts := Options{
+		Endpoint:  server.URL + "/v1/",
+		Model:     "test-model",
+		APIKey:    "secret",
+		CacheFile: filepath.Join(t.TempDir(), "cache.json"),
+	}
+
+	result, err := Run(context.Background(), api, opts, map[string]bool{"deleteUser": true})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(result.Descriptions) != 1 || result.Descriptions["listUsers"] != "Lists users page by page." {
+		t.Errorf("unexpected descriptions: %v", result.Descriptions)
+	}
+
+	// Повторный запуск берёт ответ из кеша, не обращаясь к модели
+	result, err = Run(context.Background(), api, opts, map[string]bool{"deleteUser": true})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if calls != 1 || result.Cached != 1 {
+		t.Errorf("expected cached second run, got %d calls and %d cached", calls, result.Cached)
+	}
+}
@@ -0,0 +1,40 @@
+package generator
+
+import "strings"
+
+// generateAbout описывает контакты поддержки, лицензию и условия использования API,
+// чтобы агент мог подсказать пользователю, куда обращаться
+func (g *Generator) generateAbout() string {
+	var lines []string
+
+	if c := g.api.Contact; c != nil {
+		var parts []string
+		if c.Name != "" {
+			parts = append(parts, c.Name)
+		}
+		if c.Email != "" {
+			parts = append(parts, "["+c.Email+"](mailto:"+c.Email+")")
+		}
+		if c.URL != "" {
+			parts = append(parts, "<"+c.URL+">")
+		}
+		lines = append(lines, "- "+g.heading("Contact")+": "+strings.Join(parts, ", "))
+	}
+
+	if l := g.api.License; l != nil {
+		license := l.Name
+		if l.URL != "" {
+			license = "[" + l.Name + "](" + l.URL + ")"
+		}
+		lines = append(lines, "- "+g.heading("License")+": "+license)
+	}
+
+	if g.api.TermsOfService != "" {
+		lines = append(lines, "- "+g.heading("Terms of service")+": <"+g.api.TermsOfService+">")
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return "## " + g.heading("About") + "\n\n" + strings.Join(lines, "\n") + "\n\n"
+}
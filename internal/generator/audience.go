@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// extensionAudiences возвращает аудитории из x-audience: список или одна строка
+func extensionAudiences(ext map[string]any) []string {
+	var audiences []string
+	switch v := ext["x-audience"].(type) {
+	case string:
+		audiences = append(audiences, v)
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				audiences = append(audiences, s)
+			}
+		}
+	}
+	for i, a := range audiences {
+		audiences[i] = strings.ToLower(strings.TrimSpace(a))
+	}
+	return audiences
+}
+
+// audiences возвращает аудитории операции; без своей отметки операция наследует
+// аудитории всех своих тегов. Пустой список — операция не размечена
+func (g *Generator) audiences(ep parser.Endpoint) []string {
+	if audiences := extensionAudiences(ep.Extensions); len(audiences) > 0 {
+		return audiences
+	}
+	var audiences []string
+	for _, name := range ep.Tags {
+		for _, tag := range g.api.Tags {
+			if tag.Name != name {
+				continue
+			}
+			for _, a := range extensionAudiences(tag.Extensions) {
+				if !slices.Contains(audiences, a) {
+					audiences = append(audiences, a)
+				}
+			}
+		}
+	}
+	return audiences
+}
+
+// audienceExclusion возвращает причину исключения операции, не предназначенной выбранной аудитории.
+// Неразмеченные операции доступны всем аудиториям
+func (g *Generator) audienceExclusion(ep parser.Endpoint) string {
+	audience := strings.ToLower(g.cfg.Audience)
+	if audience == "" {
+		return ""
+	}
+	audiences := g.audiences(ep)
+	if len(audiences) == 0 || slices.Contains(audiences, audience) {
+		return ""
+	}
+	return fmt.Sprintf("audience %q not in x-audience [%s]", audience, strings.Join(audiences, ", "))
+}
@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// binaryExtensions — расширения файлов для сохранения двоичных ответов в примерах
+var binaryExtensions = map[string]string{
+	"application/gzip": ".gz",
+	"application/pdf":  ".pdf",
+	"application/zip":  ".zip",
+	"image/gif":        ".gif",
+	"image/jpeg":       ".jpg",
+	"image/png":        ".png",
+	"image/svg+xml":    ".svg",
+	"image/webp":       ".webp",
+}
+
+// isBinaryContent сообщает, что содержимое — двоичные данные, а не документ со схемой:
+// application/octet-stream, изображения, аудио, видео, PDF, архивы или схема с format: binary
+func isBinaryContent(contentType string, schema *parser.Schema) bool {
+	contentType = strings.ToLower(contentType)
+	if contentType == "application/octet-stream" || binaryExtensions[contentType] != "" ||
+		strings.HasPrefix(contentType, "image/") || strings.HasPrefix(contentType, "audio/") || strings.HasPrefix(contentType, "video/") {
+		return true
+	}
+	return schema != nil && schema.Type == "string" && schema.Format == "binary"
+}
+
+// binaryOutputFile возвращает имя файла для сохранения ответа в примере запроса,
+// если успешный ответ операции в основном формате — двоичные данные. Пустая строка — ответ
+// можно вывести в терминал
+func (g *Generator) binaryOutputFile(ep parser.Endpoint) string {
+	types := g.responseContentTypes(ep)
+	if len(types) == 0 {
+		return ""
+	}
+	resp, _ := successResponse(ep)
+	return outputFile(types[0], resp.Content[types[0]].Schema)
+}
+
+// outputFile возвращает имя файла для двоичного ответа типа contentType или пустую строку
+func outputFile(contentType string, schema *parser.Schema) string {
+	if !isBinaryContent(contentType, schema) {
+		return ""
+	}
+	if ext, ok := binaryExtensions[strings.ToLower(contentType)]; ok {
+		return "output" + ext
+	}
+	return "output.bin"
+}
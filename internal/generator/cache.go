@@ -0,0 +1,142 @@
+package generator
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+
+	"github.com/mdwit/spec2llms/internal/config"
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// sectionCache — секции эндпоинтов в памяти процесса. Ключ адресует содержимое,
+// поэтому кэш общий для всех генераторов (аудиторий, языков, профилей) одного запуска
+var sectionCache sync.Map
+
+// cachedEndpoint возвращает секцию эндпоинта из кэша или рендерит и сохраняет её.
+// С cacheDir секции переиспользуются между запусками (кроме сборок из изменённого рабочего дерева)
+func (g *Generator) cachedEndpoint(ep parser.Endpoint) string {
+	key := g.sectionKey(ep)
+	if key == "" {
+		return g.renderEndpoint(ep)
+	}
+	if section, ok := sectionCache.Load(key); ok {
+		return section.(string)
+	}
+
+	var path string
+	if _, persistent := buildFingerprint(); g.cfg.CacheDir != "" && persistent {
+		path = filepath.Join(g.cfg.CacheDir, key[:2], key+".md")
+		if data, err := os.ReadFile(path); err == nil {
+			sectionCache.Store(key, string(data))
+			return string(data)
+		}
+	}
+
+	section := g.renderEndpoint(ep)
+	sectionCache.Store(key, section)
+	if path != "" && g.memory == nil {
+		// Кэш — оптимизация: ошибки записи не должны ломать генерацию
+		if os.MkdirAll(filepath.Dir(path), 0755) == nil {
+			_ = os.WriteFile(path, []byte(section), 0644)
+		}
+	}
+	return section
+}
+
+// sectionKey — хеш всего, от чего зависит секция эндпоинта: модели операции, её ссылок на другие
+// операции и контекста генерации. Контекст вычисляется один раз за generate
+func (g *Generator) sectionKey(ep parser.Endpoint) string {
+	if g.sectionContext == "" {
+		g.sectionContext = g.sectionContextHash()
+	}
+	data, err := json.Marshal(struct {
+		Endpoint parser.Endpoint
+		Links    map[string]string
+	}{ep, g.sectionLinks(ep)})
+	if err != nil || g.sectionContext == "-" {
+		return ""
+	}
+	sum := sha256.Sum256(append([]byte(g.sectionContext), data...))
+	return hex.EncodeToString(sum[:])
+}
+
+// sectionLinks возвращает ссылки секции на другие операции (замена устаревшей операции, links ответов):
+// operationId или "METHOD /path" → ссылка. Только они зависят от набора генерируемых операций
+// и имён их файлов, поэтому аудитории с общей операцией разделяют кэш её секции
+func (g *Generator) sectionLinks(ep parser.Endpoint) map[string]string {
+	links := map[string]string{}
+	add := func(op string) {
+		if op != "" {
+			links[op] = g.operationReference(op)
+		}
+	}
+	if d, _ := deprecationInfo(ep); d.Alternative != "" {
+		add(d.Alternative)
+	}
+	for _, resp := range ep.Responses {
+		for _, link := range resp.Links {
+			add(cmp.Or(link.OperationID, operationRefTarget(link.OperationRef)))
+		}
+	}
+	return links
+}
+
+// sectionContextHash хеширует контекст, общий для секций всех эндпоинтов: сборку spec2llms,
+// настройки оформления, модель API без списка операций и вынесенные в llms.txt фрагменты.
+// Настройки, не влияющие на секции (источник, вывод, фильтр и аудитория), не учитываются, чтобы
+// аудитории с общей операцией разделяли кэш. "-" означает, что кэшировать нельзя
+func (g *Generator) sectionContextHash() string {
+	cfg := *g.cfg
+	cfg.Source, cfg.Output, cfg.CacheDir = "", "", ""
+	cfg.Filter, cfg.Audience, cfg.ExcludedReport, cfg.Incremental = config.Filter{}, "", false, false
+	cfg.SearchIndex, cfg.PublishManifest, cfg.Versioned, cfg.SchemaGlossary = false, false, false, false
+	cfg.Hooks.PreParse, cfg.Hooks.PostGenerate = nil, nil
+	cfg.Tokenizer, cfg.SkipValidation, cfg.BestEffort, cfg.Offline = "", false, false, false
+
+	api := *g.api
+	api.Endpoints, api.Schemas = nil, nil
+	build, _ := buildFingerprint()
+
+	data, err := json.Marshal(struct {
+		Build     string
+		Config    any
+		API       any
+		Language  string
+		Fragments []string
+		Errors    []sharedError
+		Examples  map[string]responseExample
+		Overrides map[string]parser.ExampleOverrides
+	}{build, cfg, api, g.language(), g.fragments, g.sharedErrors, g.responseExamples, g.exampleOverrides})
+	if err != nil {
+		return "-"
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// buildFingerprint идентифицирует сборку, чтобы кэш с диска не переживал изменения рендеринга.
+// persistent = false для сборки из изменённого рабочего дерева (vcs.modified): её рендеринг
+// не определяется ревизией, и секции с диска могли остаться от другого кода
+var buildFingerprint = sync.OnceValues(func() (fingerprint string, persistent bool) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", true
+	}
+	fingerprint = info.Main.Version
+	persistent = true
+	for _, s := range info.Settings {
+		if s.Key == "vcs.revision" || s.Key == "vcs.modified" {
+			fingerprint += " " + s.Value
+		}
+		if s.Key == "vcs.modified" && s.Value == "true" {
+			persistent = false
+		}
+	}
+	return fingerprint, persistent
+})
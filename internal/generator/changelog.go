@@ -0,0 +1,26 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ChangelogFile — журнал изменений API, который пишет команда changelog. Генератор его не создаёт
+// и не удаляет, а только ссылается на него из llms.txt, поэтому check и --incremental не видят расхождений
+const ChangelogFile = "changelog.txt"
+
+// hasChangelog сообщает, что в директории вывода есть журнал изменений
+func (g *Generator) hasChangelog() bool {
+	if g.isHTML() {
+		return false
+	}
+	info, err := os.Stat(filepath.Join(g.cfg.Output, ChangelogFile))
+	return err == nil && info.Mode().IsRegular()
+}
+
+// generateChangelogLink — секция llms.txt со ссылкой на журнал изменений
+func (g *Generator) generateChangelogLink() string {
+	heading := g.heading("Changelog")
+	return fmt.Sprintf("## %s\n\n- [%s](./%s): API changes since an earlier spec revision\n\n", heading, heading, ChangelogFile)
+}
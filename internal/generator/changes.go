@@ -0,0 +1,57 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Виды изменений директории вывода
+const (
+	ChangeCreate = "create"
+	ChangeUpdate = "update"
+	ChangeDelete = "delete" // файл прошлого запуска (ownedFiles) больше не генерируется и будет удалён
+)
+
+// Change — отличие сгенерированного в память файла от содержимого директории вывода
+type Change struct {
+	Path string // относительно директории вывода, через /
+	Kind string
+	Old  []byte
+	New  []byte
+}
+
+// Changes сравнивает файлы из Render с директорией вывода так, как их записал бы Generate:
+// удалёнными считаются только файлы прошлых запусков, остальные файлы директории не трогаются.
+// Результат отсортирован по пути
+func Changes(output string, files map[string][]byte) ([]Change, error) {
+	var changes []Change
+	for rel, data := range files {
+		old, err := os.ReadFile(filepath.Join(output, filepath.FromSlash(rel)))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			changes = append(changes, Change{Path: rel, Kind: ChangeCreate, New: data})
+		case err != nil:
+			return nil, err
+		case !bytes.Equal(old, data):
+			changes = append(changes, Change{Path: rel, Kind: ChangeUpdate, Old: old, New: data})
+		}
+	}
+
+	for _, rel := range staleFiles(ownedFiles(output), files) {
+		old, err := os.ReadFile(filepath.Join(output, filepath.FromSlash(rel)))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		changes = append(changes, Change{Path: rel, Kind: ChangeDelete, Old: old})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+	"github.com/mdwit/spec2llms/internal/tokens"
+)
+
+// ChunksFile — документация, нарезанная на фрагменты для векторных баз (--format chunks)
+const ChunksFile = "chunks.jsonl"
+
+// chunk — запись chunks.jsonl: самодостаточный фрагмент документации с метаданными для фильтрации
+type chunk struct {
+	ID          string   `json:"id"`   // operation:<operationId или METHOD /path>, schema:<имя>
+	Kind        string   `json:"kind"` // operation, schema
+	Tag         string   `json:"tag,omitempty"`
+	Method      string   `json:"method,omitempty"`
+	Path        string   `json:"path,omitempty"`
+	OperationID string   `json:"operationId,omitempty"`
+	Schema      string   `json:"schema,omitempty"`
+	Safety      []string `json:"safety,omitempty"` // safe, idempotent, idempotency-key, destructive
+	Tokens      int      `json:"tokens"`
+	Text        string   `json:"text"`
+}
+
+// generateChunks записывает chunks.jsonl: по записи на операцию и на именованную схему тел
+// запросов и ответов. Фрагменты не ссылаются на общие секции llms.txt, поэтому их можно
+// индексировать и выдавать агенту по отдельности
+func (g *Generator) generateChunks(endpoints []parser.Endpoint) error {
+	estimator, err := tokens.Get(g.cfg.Tokenizer)
+	if err != nil {
+		return err
+	}
+	if err := g.mkdirAll(g.cfg.Output); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	var records []chunk
+	for _, ep := range endpoints {
+		text := strings.TrimSpace(g.generateEndpoint(ep))
+		records = append(records, chunk{
+			ID:          "operation:" + endpointID(ep),
+			Kind:        "operation",
+			Tag:         g.groupName(ep),
+			Method:      ep.Method,
+			Path:        ep.Path,
+			OperationID: ep.OperationID,
+			Safety:      safetyInfo(ep).Labels(),
+			Tokens:      estimator.Count(text),
+			Text:        text,
+		})
+	}
+
+	schemas := namedSchemas(endpoints)
+	for _, name := range slices.Sorted(maps.Keys(schemas)) {
+		schema := schemas[name]
+		var sb strings.Builder
+		sb.WriteString("## " + name + "\n\n")
+		if desc := g.description(schema.Description); desc != "" {
+			sb.WriteString(desc + "\n\n")
+		}
+		sb.WriteString(g.generateFieldsTable(schema, ""))
+		text := strings.TrimSpace(sb.String())
+		records = append(records, chunk{
+			ID:     "schema:" + name,
+			Kind:   "schema",
+			Schema: name,
+			Tokens: estimator.Count(text),
+			Text:   text,
+		})
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("failed to encode chunk %s: %w", record.ID, err)
+		}
+	}
+	path := filepath.Join(g.cfg.Output, ChunksFile)
+	if err := g.writeFile(path, buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ChunksFile, err)
+	}
+	return nil
+}
+
+// namedSchemas собирает компоненты-схемы тел запросов и ответов (и элементов массивов) по имени
+func namedSchemas(endpoints []parser.Endpoint) map[string]*parser.Schema {
+	schemas := make(map[string]*parser.Schema)
+	add := func(content map[string]parser.MediaType) {
+		for _, media := range content {
+			schema := media.Schema
+			if schema != nil && schema.Type == "array" && schema.Items != nil && schema.RefName() == "" {
+				schema = schema.Items
+			}
+			if name := schema.RefName(); name != "" && len(schema.Properties) > 0 {
+				schemas[name] = schema
+			}
+		}
+	}
+	for _, ep := range endpoints {
+		if ep.RequestBody != nil {
+			add(ep.RequestBody.Content)
+		}
+		for _, resp := range ep.Responses {
+			add(resp.Content)
+		}
+	}
+	return schemas
+}
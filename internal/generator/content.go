@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// orderContentTypes упорядочивает типы содержимого: сначала перечисленные в contentTypes
+// конфига в заданном порядке, затем application/json, другие JSON-типы (+json) и остальные
+// по алфавиту. Первый тип используется в примере запроса
+func orderContentTypes(content map[string]parser.MediaType, preferred []string) []string {
+	rank := func(contentType string) int {
+		for i, p := range preferred {
+			if strings.EqualFold(p, contentType) {
+				return i
+			}
+		}
+		switch base := mediaTypeBase(contentType); {
+		case base == "application/json":
+			return len(preferred)
+		case strings.HasSuffix(base, "+json"):
+			return len(preferred) + 1
+		}
+		return len(preferred) + 2
+	}
+
+	types := make([]string, 0, len(content))
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if ri, rj := rank(types[i]), rank(types[j]); ri != rj {
+			return ri < rj
+		}
+		return types[i] < types[j]
+	})
+	return types
+}
+
+// mediaTypeBase возвращает тип содержимого без параметров: application/json; charset=utf-8 → application/json
+func mediaTypeBase(contentType string) string {
+	base, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(base))
+}
+
+// isXMLContentType сообщает, что тело передаётся в XML (application/xml, text/xml, *+xml)
+func isXMLContentType(contentType string) bool {
+	base := mediaTypeBase(contentType)
+	return base == "application/xml" || base == "text/xml" || strings.HasSuffix(base, "+xml")
+}
+
+// xmlRootName возвращает имя корневого элемента XML-примера: имя компонента схемы или fallback
+func xmlRootName(schema *parser.Schema, fallback string) string {
+	if schema.Type == "array" && schema.Items != nil && schema.Items.RefName() != "" {
+		return schema.Items.RefName() + "List"
+	}
+	if name := schema.RefName(); name != "" {
+		return name
+	}
+	return fallback
+}
+
+// stripJSONComments убирает из JSON-примера строки-комментарии о скрытых полях,
+// чтобы его можно было разобрать как JSON
+func stripJSONComments(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "// ") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// jsonToXML переводит JSON-пример тела в XML: поля объекта становятся вложенными элементами,
+// элементы массива — повторяющимися элементами item
+func jsonToXML(text, root string) string {
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return text
+	}
+
+	var sb strings.Builder
+	writeXMLElement(&sb, root, value, "")
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// writeXMLElement выводит значение элементом name с отступом indent
+func writeXMLElement(sb *strings.Builder, name string, value any, indent string) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			sb.WriteString(indent + "<" + name + "/>\n")
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		sb.WriteString(indent + "<" + name + ">\n")
+		for _, key := range keys {
+			writeXMLElement(sb, key, v[key], indent+"  ")
+		}
+		sb.WriteString(indent + "</" + name + ">\n")
+	case []any:
+		sb.WriteString(indent + "<" + name + ">\n")
+		for _, item := range v {
+			writeXMLElement(sb, "item", item, indent+"  ")
+		}
+		sb.WriteString(indent + "</" + name + ">\n")
+	case nil:
+		sb.WriteString(indent + "<" + name + "/>\n")
+	default:
+		var text strings.Builder
+		_ = xml.EscapeText(&text, []byte(fmt.Sprint(v)))
+		sb.WriteString(indent + "<" + name + ">" + text.String() + "</" + name + ">\n")
+	}
+}
@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// indexSection — секция llms.txt: kind и id используются маркерами секций
+type indexSection struct {
+	kind    string
+	id      string
+	content string
+	render  func() string // рендерит содержимое при записи вместо content (операции плоского режима)
+}
+
+// customSection — пользовательская секция с уже прочитанным содержимым
+type customSection struct {
+	position string
+	section  indexSection
+}
+
+// loadCustomSections читает пользовательские секции из конфига (текст или файл Markdown)
+func (g *Generator) loadCustomSections() error {
+	g.customSections = nil
+	for i, s := range g.cfg.Sections {
+		content := s.Content
+		if s.File != "" {
+			data, err := os.ReadFile(s.File)
+			if err != nil {
+				return fmt.Errorf("failed to read section %s: %w", s.File, err)
+			}
+			content = string(data)
+		}
+
+		var sb strings.Builder
+		if s.Title != "" {
+			sb.WriteString("## " + s.Title + "\n\n")
+		}
+		if content = strings.TrimSpace(content); content != "" {
+			sb.WriteString(content + "\n\n")
+		}
+
+		id := fmt.Sprintf("custom-%d", i+1)
+		if slug := slugify(s.Title); slug != "" {
+			id = "custom-" + slug
+		}
+		g.customSections = append(g.customSections, customSection{s.Position, indexSection{kind: "section", id: id, content: sb.String()}})
+	}
+	return nil
+}
+
+// insertCustomSections вставляет пользовательские секции в точки привязки: top — перед первой
+// секцией после заголовка, before:<id> / after:<id> — рядом с секцией индекса, end (по умолчанию) — в конец.
+// Секция, привязанная к отсутствующей в этом выводе секции, идёт в конец
+func (g *Generator) insertCustomSections(sections []indexSection) []indexSection {
+	if len(g.customSections) == 0 {
+		return sections
+	}
+
+	before := make(map[string][]indexSection)
+	after := make(map[string][]indexSection)
+	var top, end []indexSection
+	present := make(map[string]bool)
+	for _, s := range sections {
+		present[s.id] = true
+	}
+
+	for _, c := range g.customSections {
+		anchor, id, _ := strings.Cut(c.position, ":")
+		switch {
+		case anchor == "top":
+			top = append(top, c.section)
+		case anchor == "before" && present[id]:
+			before[id] = append(before[id], c.section)
+		case anchor == "after" && present[id]:
+			after[id] = append(after[id], c.section)
+		default:
+			end = append(end, c.section)
+		}
+	}
+
+	result := top
+	for _, s := range sections {
+		result = append(result, before[s.id]...)
+		result = append(result, s)
+		result = append(result, after[s.id]...)
+		// Каждая группа привязанных секций выводится один раз, даже если id повторяется
+		delete(before, s.id)
+		delete(after, s.id)
+	}
+	return append(result, end...)
+}
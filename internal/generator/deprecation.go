@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// deprecation — сведения об устаревании операции из deprecated и расширений
+type deprecation struct {
+	Since       string // x-deprecated-at: когда операция объявлена устаревшей
+	Sunset      string // x-sunset: после этой даты операция перестанет работать
+	Alternative string // x-alternative: operationId или "METHOD /path" замены
+}
+
+// deprecationInfo возвращает сведения об устаревании. Операция считается устаревшей,
+// если у неё deprecated: true или задано любое из расширений x-deprecated-at, x-sunset, x-alternative
+func deprecationInfo(ep parser.Endpoint) (deprecation, bool) {
+	d := deprecation{
+		Since:       extensionString(ep, "x-deprecated-at"),
+		Sunset:      extensionString(ep, "x-sunset"),
+		Alternative: extensionString(ep, "x-alternative"),
+	}
+	return d, ep.Deprecated || d != deprecation{}
+}
+
+// extensionString возвращает значение x-* расширения операции строкой
+func extensionString(ep parser.Endpoint, name string) string {
+	v, ok := ep.Extensions[name]
+	if !ok || v == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(v))
+}
+
+// generateDeprecationNote генерирует заметку об устаревании: с какой даты, когда операция
+// перестанет работать и какой операцией её заменить
+func (g *Generator) generateDeprecationNote(ep parser.Endpoint) string {
+	d, _ := deprecationInfo(ep)
+	if d == (deprecation{}) {
+		return ""
+	}
+
+	line := "> ⚠️ **Deprecated"
+	if d.Since != "" {
+		line += " since " + d.Since
+	}
+	line += ".**"
+	if d.Sunset != "" {
+		line += " Stops working after " + d.Sunset + "."
+	}
+	if d.Alternative != "" {
+		line += " Use " + g.operationReference(d.Alternative) + " instead."
+	} else {
+		line += " Avoid it in new integrations."
+	}
+	return line + "\n\n"
+}
+
+// operationReference ссылается на операцию по operationId или "METHOD /path":
+// ссылкой на её документацию, если операция есть среди генерируемых, иначе кодом
+func (g *Generator) operationReference(op string) string {
+	ep, ok := g.findOperation(op)
+	if !ok {
+		return "`" + op + "`"
+	}
+	label := "`" + ep.Method + " " + ep.Path + "`"
+	if link := g.operationLink(ep); link != "" {
+		return "[" + label + "](" + link + ")"
+	}
+	return label
+}
+
+// findOperation ищет среди генерируемых эндпоинтов операцию по operationId или "METHOD /path"
+func (g *Generator) findOperation(op string) (parser.Endpoint, bool) {
+	for _, ep := range g.api.Endpoints {
+		if matchesOperation(ep, op) {
+			return ep, true
+		}
+	}
+	return parser.Endpoint{}, false
+}
+
+// operationLink возвращает ссылку на секцию операции из секции другой операции.
+// В плоском режиме обе секции в llms.txt — достаточно якоря
+func (g *Generator) operationLink(ep parser.Endpoint) string {
+	anchor := "#" + endpointAnchor(ep)
+	switch {
+	case g.isFlat():
+		return anchor
+	case g.isHTML():
+		return g.groupPageName(g.groupName(ep)) + anchor
+	case g.cfg.DocsBaseURL != "":
+		return strings.TrimSuffix(g.cfg.DocsBaseURL, "/") + "/" + g.endpointPath(ep) + anchor
+	}
+	// Файлы эндпоинтов лежат в одной директории
+	return g.getEndpointFilename(ep) + anchor
+}
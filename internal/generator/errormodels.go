@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// sharedError — схема ошибки, на которую ссылаются ответы нескольких эндпоинтов
+type sharedError struct {
+	Ref    string
+	Name   string
+	Schema *parser.Schema
+	Codes  []string
+}
+
+// isErrorStatus сообщает, описывает ли код ответа ошибку (4xx, 5xx, 4XX, default)
+func isErrorStatus(code string) bool {
+	return strings.HasPrefix(code, "4") || strings.HasPrefix(code, "5") || code == "default"
+}
+
+// detectSharedErrors собирает схемы ошибок (компоненты), которые используются
+// в ответах хотя бы двух эндпоинтов
+func detectSharedErrors(endpoints []parser.Endpoint) []sharedError {
+	byRef := make(map[string]*sharedError)
+	usage := make(map[string]int)
+
+	for _, ep := range endpoints {
+		seen := make(map[string]bool)
+		for code, resp := range ep.Responses {
+			if !isErrorStatus(code) {
+				continue
+			}
+			for _, media := range resp.Content {
+				if media.Schema == nil || media.Schema.Ref == "" {
+					continue
+				}
+				ref := media.Schema.Ref
+				e, ok := byRef[ref]
+				if !ok {
+					e = &sharedError{Ref: ref, Name: media.Schema.RefName(), Schema: media.Schema}
+					byRef[ref] = e
+				}
+				if !slices.Contains(e.Codes, code) {
+					e.Codes = append(e.Codes, code)
+				}
+				if !seen[ref] {
+					seen[ref] = true
+					usage[ref]++
+				}
+			}
+		}
+	}
+
+	var shared []sharedError
+	for ref, e := range byRef {
+		if usage[ref] < 2 {
+			continue
+		}
+		sort.Strings(e.Codes)
+		shared = append(shared, *e)
+	}
+	sort.Slice(shared, func(i, j int) bool {
+		return shared[i].Name < shared[j].Name
+	})
+	return shared
+}
+
+// sharedErrorFor возвращает общую схему ошибки для ответа, если она вынесена в llms.txt
+func (g *Generator) sharedErrorFor(code string, schema *parser.Schema) (sharedError, bool) {
+	if !isErrorStatus(code) || schema == nil || schema.Ref == "" {
+		return sharedError{}, false
+	}
+	for _, e := range g.sharedErrors {
+		if e.Ref == schema.Ref {
+			return e, true
+		}
+	}
+	return sharedError{}, false
+}
+
+// formatSharedErrorReference оформляет ссылку на общую схему ошибки вместо повторения схемы
+func (g *Generator) formatSharedErrorReference(e sharedError) string {
+	return fmt.Sprintf("%s: [`%s`](%s#%s) (%s %s)\n\n", g.heading("Error body"), e.Name, g.indexLink(), slugify(e.Name), g.heading("see"), g.heading("Errors"))
+}
+
+// generateErrors генерирует секцию Errors с каноническими схемами ошибок
+func (g *Generator) generateErrors() string {
+	var sb strings.Builder
+	sb.WriteString("## " + g.heading("Errors") + "\n\n")
+	sb.WriteString("Error responses share these body shapes. Endpoint files link here instead of repeating them.\n\n")
+
+	for _, e := range g.sharedErrors {
+		sb.WriteString("### " + e.Name + "\n\n")
+		if desc := g.description(e.Schema.Description); desc != "" {
+			sb.WriteString(desc + "\n\n")
+		}
+		sb.WriteString("Status codes: `" + strings.Join(e.Codes, "`, `") + "`\n\n")
+		sb.WriteString(g.generateSchemaDoc(e.Schema, 0))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// responseExample представляет готовый пример ответа из внешней директории
+type responseExample struct {
+	Lang    string // язык блока кода: json, yaml, xml...
+	Content string
+}
+
+// loadResponseExamples загружает примеры ответов из директории.
+// Файлы именуются как <operationId>.<status>.<ext>, например getUser.200.json
+func loadResponseExamples(dir string) (map[string]responseExample, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response examples directory: %w", err)
+	}
+
+	examples := make(map[string]responseExample)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		ext := filepath.Ext(name)
+		key := strings.TrimSuffix(name, ext)
+		// Ключ должен содержать и operationId, и статус
+		if ext == "" || !strings.Contains(key, ".") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response example %s: %w", name, err)
+		}
+
+		examples[key] = responseExample{
+			Lang:    strings.TrimPrefix(strings.ToLower(ext), "."),
+			Content: strings.TrimRight(string(data), "\n"),
+		}
+	}
+
+	return examples, nil
+}
+
+// getResponseExample возвращает пример ответа для операции и кода статуса:
+// из overrides, а затем из responseExamplesDir
+func (g *Generator) getResponseExample(ep parser.Endpoint, code string) (responseExample, bool) {
+	if example, ok := g.overrideResponseExample(ep, code); ok {
+		return example, true
+	}
+	if ep.OperationID == "" || g.responseExamples == nil {
+		return responseExample{}, false
+	}
+	example, ok := g.responseExamples[ep.OperationID+"."+code]
+	return example, ok
+}
+
+// formatResponseExample оформляет пример ответа как блок кода
+func formatResponseExample(example responseExample) string {
+	return "```" + example.Lang + "\n" + example.Content + "\n```\n\n"
+}
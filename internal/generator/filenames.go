@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/mdwit/spec2llms/internal/config"
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// assignFilenames назначает эндпоинтам и страницам групп уникальные имена файлов.
+// Разные пути могут дать одно имя (/users/{id} и /users/id, /a-b и /a/b), а разные теги —
+// один slug (Orders и orders); при совпадении к имени добавляется суффикс -2, -3...
+// Сравнение без учёта регистра, чтобы файлы не перезаписывались на macOS и Windows
+func (g *Generator) assignFilenames(endpoints []parser.Endpoint) {
+	g.endpointFiles = make(map[string]string)
+	used := make(map[string]bool)
+	if g.endpointsDir() == "." {
+		// Файлы эндпоинтов лежат рядом с индексом и не должны его перезаписать
+		used["llms.txt"] = true
+	}
+	for _, ep := range endpoints {
+		g.endpointFiles[ep.Method+" "+ep.Path] = uniqueFilename(used, g.baseEndpointFilename(ep))
+	}
+
+	g.groupPages = make(map[string]string)
+	used = make(map[string]bool)
+	for _, group := range g.groupEndpoints(endpoints) {
+		g.groupPages[group.Name] = uniqueFilename(used, g.baseGroupPageName(group.Name))
+	}
+}
+
+// fileExtension возвращает расширение файлов эндпоинтов с точкой (по умолчанию .txt)
+func (g *Generator) fileExtension() string {
+	if g.cfg.FileExtension == "" {
+		return "." + config.FileExtensionTxt
+	}
+	return "." + g.cfg.FileExtension
+}
+
+// endpointsDir возвращает директорию файлов эндпоинтов относительно вывода (по умолчанию endpoints)
+func (g *Generator) endpointsDir() string {
+	if g.cfg.EndpointsDir == "" {
+		return "endpoints"
+	}
+	return path.Clean(filepath.ToSlash(g.cfg.EndpointsDir))
+}
+
+// endpointPath возвращает путь файла эндпоинта относительно директории вывода
+func (g *Generator) endpointPath(ep parser.Endpoint) string {
+	return path.Join(g.endpointsDir(), g.getEndpointFilename(ep))
+}
+
+// uniqueFilename возвращает name или name-N с тем же расширением, если имя уже занято
+func uniqueFilename(used map[string]bool, name string) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	candidate := name
+	for i := 2; used[strings.ToLower(candidate)]; i++ {
+		candidate = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// transliteration — замены символов вне ASCII: кириллица (русская и украинская),
+// латиница с диакритикой и знаки, у которых есть понятное словесное прочтение
+var transliteration = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ą': "a", 'æ': "ae",
+	'ç': "c", 'ć': "c", 'č': "c", 'ď': "d", 'đ': "d", 'ð': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ę': "e", 'ě': "e", 'ğ': "g",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ı': "i", 'ł': "l", 'ñ': "n", 'ń': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ő': "o", 'œ': "oe",
+	'ř': "r", 'ś': "s", 'š': "s", 'ş': "s", 'ß': "ss", 'ť': "t", 'þ': "th",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ů': "u", 'ű': "u", 'ý': "y", 'ÿ': "y",
+	'ź': "z", 'ż': "z", 'ž': "z",
+
+	'&': "-and-", '+': "-plus-", '@': "-at-",
+}
+
+// windowsReserved — имена устройств, недопустимые как имя файла в Windows
+var windowsReserved = []string{
+	"con", "prn", "aux", "nul",
+	"com1", "com2", "com3", "com4", "com5", "com6", "com7", "com8", "com9",
+	"lpt1", "lpt2", "lpt3", "lpt4", "lpt5", "lpt6", "lpt7", "lpt8", "lpt9",
+}
+
+// maxFilenameLength ограничивает длину имени файла без расширения
+const maxFilenameLength = 80
+
+// sanitizeFilename превращает имя тега в имя файла, допустимое на всех ОС: транслитерирует
+// кириллицу и диакритику, оставляет только a-z, 0-9, точку, _ и -, остальное заменяет дефисом.
+// Пустая строка — в имени не нашлось ни одного допустимого символа
+func sanitizeFilename(name string) string {
+	return cleanFilename(strings.ToLower(name))
+}
+
+// cleanFilename — sanitizeFilename с сохранением регистра латиницы: имена файлов эндпоинтов
+// повторяют путь (/users/{userId} -> get-users-userId.txt)
+func cleanFilename(name string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			sb.WriteRune(r)
+		default:
+			if replacement, ok := transliteration[unicode.ToLower(r)]; ok {
+				sb.WriteString(replacement)
+			} else {
+				sb.WriteByte('-')
+			}
+		}
+	}
+
+	// Повторяющиеся дефисы схлопываются; точка в начале скрывает файл, в конце — отбрасывается Windows
+	parts := strings.FieldsFunc(sb.String(), func(r rune) bool { return r == '-' })
+	result := strings.Trim(strings.Join(parts, "-"), ".-")
+	if len(result) > maxFilenameLength {
+		result = strings.TrimRight(result[:maxFilenameLength], ".-")
+	}
+	if slices.Contains(windowsReserved, strings.ToLower(result)) {
+		result += "-"
+	}
+	return result
+}
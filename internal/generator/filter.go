@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// excludedEndpoint — операция, не попавшая в документацию, с причиной исключения
+type excludedEndpoint struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	OperationID string `json:"operationId,omitempty"`
+	Reason      string `json:"reason"`
+}
+
+// applyFilter оставляет в модели только операции, прошедшие фильтр и отбор по аудитории,
+// и при включённом excludedReport записывает исключённые в excluded.json
+func (g *Generator) applyFilter() error {
+	var kept []parser.Endpoint
+	excluded := []excludedEndpoint{}
+	for _, ep := range g.api.Endpoints {
+		if reason := g.exclusionReason(ep); reason != "" {
+			excluded = append(excluded, excludedEndpoint{ep.Method, ep.Path, ep.OperationID, reason})
+			continue
+		}
+		kept = append(kept, ep)
+	}
+
+	if len(excluded) > 0 {
+		// Копируем модель, чтобы не менять API вызывающего кода
+		api := *g.api
+		api.Endpoints = kept
+		g.api = &api
+	}
+
+	if !g.cfg.ExcludedReport {
+		return nil
+	}
+	if err := g.mkdirAll(g.cfg.Output); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	data, err := json.MarshalIndent(struct {
+		Excluded []excludedEndpoint `json:"excluded"`
+	}{excluded}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode excluded endpoints: %w", err)
+	}
+	if err := g.writeFile(filepath.Join(g.cfg.Output, "excluded.json"), append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write excluded.json: %w", err)
+	}
+	return nil
+}
+
+// exclusionReason возвращает причину исключения операции фильтром или аудиторией, иначе пустую строку
+func (g *Generator) exclusionReason(ep parser.Endpoint) string {
+	f := g.cfg.Filter
+	for _, pattern := range f.ExcludePaths {
+		if matchPath(pattern, ep.Path) {
+			return fmt.Sprintf("path matches excludePaths pattern %q", pattern)
+		}
+	}
+	for _, tag := range ep.Tags {
+		if slices.Contains(f.ExcludeTags, tag) {
+			return fmt.Sprintf("tag %q is in excludeTags", tag)
+		}
+	}
+	if len(f.IncludePaths) > 0 && !slices.ContainsFunc(f.IncludePaths, func(pattern string) bool {
+		return matchPath(pattern, ep.Path)
+	}) {
+		return "path does not match any includePaths pattern"
+	}
+	if len(f.IncludeTags) > 0 && !slices.ContainsFunc(ep.Tags, func(tag string) bool {
+		return slices.Contains(f.IncludeTags, tag)
+	}) {
+		return "no tag is in includeTags"
+	}
+	return g.audienceExclusion(ep)
+}
+
+// matchPath сопоставляет путь операции с шаблоном: * — один сегмент пути,
+// /** в конце — любое число сегментов (/internal/** совпадает с /internal и /internal/users/{id})
+func matchPath(pattern, p string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		return p == prefix || strings.HasPrefix(p, prefix+"/")
+	}
+	ok, _ := path.Match(pattern, p)
+	return ok
+}
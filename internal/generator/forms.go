@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// Типы содержимого HTML-форм: тело передаётся полями, а не JSON
+const (
+	contentTypeURLEncoded = "application/x-www-form-urlencoded"
+	contentTypeMultipart  = "multipart/form-data"
+)
+
+// formField — поле формы в примере запроса
+type formField struct {
+	Name  string
+	Value string // пример значения; для файла — путь к загружаемому файлу
+	File  bool
+}
+
+// isFormContentType сообщает, что тело запроса передаётся полями формы
+func isFormContentType(contentType string) bool {
+	return contentType == contentTypeURLEncoded || contentType == contentTypeMultipart
+}
+
+// isFileField сообщает, что поле формы — загружаемый файл (type: string, format: binary или base64),
+// в том числе массив файлов
+func isFileField(schema *parser.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	if schema.Type == "array" {
+		return isFileField(schema.Items)
+	}
+	return schema.Type == "string" && (schema.Format == "binary" || schema.Format == "base64")
+}
+
+// formFields возвращает поля формы с примерами значений в порядке имён
+func (g *Generator) formFields(schema *parser.Schema) []formField {
+	if schema == nil {
+		return nil
+	}
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]formField, 0, len(names))
+	for _, name := range names {
+		prop := schema.Properties[name]
+		if isFileField(prop) {
+			fields = append(fields, formField{Name: name, Value: "/path/to/file", File: true})
+			continue
+		}
+		// Строки передаются без JSON-кавычек, остальные значения — как JSON
+		value := g.renderPropertyValue(prop, 0, g.schemaDepth(), 0)
+		var str string
+		if err := json.Unmarshal([]byte(value), &str); err == nil {
+			value = str
+		}
+		fields = append(fields, formField{Name: name, Value: value})
+	}
+	return fields
+}
+
+// generateFormDoc документирует тело-форму: обязательные поля и таблица полей,
+// где загружаемые файлы отмечены типом file
+func (g *Generator) generateFormDoc(schema *parser.Schema) string {
+	if schema == nil || len(schema.Properties) == 0 {
+		return ""
+	}
+	return g.requiredFieldsLine(schema) + g.generateFieldsTable(schema, "")
+}
+
+// fieldType возвращает тип поля для таблицы полей
+func fieldType(prop *parser.Schema) string {
+	switch {
+	case prop.Type == "array" && isFileField(prop.Items):
+		return "array[file]"
+	case isFileField(prop):
+		return "file"
+	case prop.Type == "array" && prop.Items != nil:
+		return "array[" + prop.Items.Type + "]"
+	case prop.Format != "":
+		return prop.Type + " (" + prop.Format + ")"
+	}
+	return prop.Type
+}
@@ -0,0 +1,124 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+const (
+	// minFragmentLength — минимальная длина абзаца, который имеет смысл выносить
+	minFragmentLength = 200
+	// minFragmentOccurrences — в скольких эндпоинтах должен повторяться абзац
+	minFragmentOccurrences = 2
+)
+
+// detectSharedFragments находит крупные абзацы описаний, повторяющиеся в нескольких эндпоинтах.
+// Возвращает абзацы в порядке первого появления
+func detectSharedFragments(endpoints []parser.Endpoint) []string {
+	counts := make(map[string]int)
+	var order []string
+
+	for _, ep := range endpoints {
+		seen := make(map[string]bool)
+		for _, p := range splitParagraphs(ep.Description) {
+			if len(p) < minFragmentLength || seen[p] {
+				continue
+			}
+			seen[p] = true
+			if counts[p] == 0 {
+				order = append(order, p)
+			}
+			counts[p]++
+		}
+	}
+
+	var fragments []string
+	for _, p := range order {
+		if counts[p] >= minFragmentOccurrences {
+			fragments = append(fragments, p)
+		}
+	}
+	return fragments
+}
+
+// splitParagraphs разбивает текст на абзацы по пустым строкам
+func splitParagraphs(text string) []string {
+	var paragraphs []string
+	for _, p := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			paragraphs = append(paragraphs, p)
+		}
+	}
+	return paragraphs
+}
+
+// fragmentAnchor возвращает якорь общего фрагмента в llms.txt
+func fragmentAnchor(n int) string {
+	return fmt.Sprintf("shared-note-%d", n)
+}
+
+// renderDescription заменяет общие фрагменты в описании ссылками на llms.txt
+func (g *Generator) renderDescription(desc string) string {
+	if len(g.fragments) == 0 {
+		return g.description(desc)
+	}
+
+	paragraphs := splitParagraphs(desc)
+	replaced := false
+	for i, p := range paragraphs {
+		for n, fragment := range g.fragments {
+			if p == fragment {
+				paragraphs[i] = fmt.Sprintf("_See [Shared note %d](%s#%s)._", n+1, g.indexLink(), fragmentAnchor(n+1))
+				replaced = true
+				break
+			}
+		}
+	}
+	if !replaced {
+		return g.description(desc)
+	}
+	return g.description(strings.Join(paragraphs, "\n\n"))
+}
+
+// generateSharedFragments генерирует секцию с общими фрагментами для llms.txt
+func (g *Generator) generateSharedFragments() string {
+	var sb strings.Builder
+	sb.WriteString("## " + g.heading("Shared Notes") + "\n\n")
+	sb.WriteString("Text repeated across several endpoints. Endpoint files link here instead of repeating it.\n\n")
+	for n, fragment := range g.fragments {
+		sb.WriteString(fmt.Sprintf("### Shared note %d\n\n", n+1))
+		sb.WriteString(g.description(fragment) + "\n\n")
+	}
+	return sb.String()
+}
+
+// indexLink возвращает ссылку на llms.txt (index.html в HTML-режиме) из файла эндпоинта
+func (g *Generator) indexLink() string {
+	if g.isFlat() {
+		// Эндпоинты находятся в самом llms.txt
+		return ""
+	}
+	if g.isHTML() {
+		return "../index.html"
+	}
+	return g.rootLink("llms.txt")
+}
+
+// rootLink возвращает ссылку на файл в корне вывода из секции эндпоинта (не HTML)
+func (g *Generator) rootLink(name string) string {
+	switch {
+	case g.isFlat():
+		// Секции эндпоинтов находятся в самом llms.txt
+		return "./" + name
+	case g.cfg.DocsBaseURL != "":
+		return strings.TrimSuffix(g.cfg.DocsBaseURL, "/") + "/" + name
+	}
+	// Из директории эндпоинтов поднимаемся к корню вывода на каждый уровень вложенности
+	depth := 0
+	if dir := g.endpointsDir(); dir != "." {
+		depth = strings.Count(dir, "/") + 1
+	}
+	return strings.Repeat("../", depth) + name
+}
@@ -1,10 +1,15 @@
 package generator
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
-	"os"
+	"io"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/mdwit/spec2llms/internal/config"
@@ -15,65 +20,344 @@ import (
 type Generator struct {
 	cfg *config.Config
 	api *parser.API
+	ctx context.Context // отмена прерывает генерацию перед записью очередного файла
+
+	responseExamples map[string]responseExample         // ключ: <operationId>.<status>
+	exampleOverrides map[string]parser.ExampleOverrides // примеры из overrides, ключ: "METHOD /path"
+	workflows        []parser.Workflow                  // сценарии из x-workflows и документа Arazzo
+	fragments        []string                           // общие абзацы описаний, вынесенные в llms.txt
+	sharedErrors     []sharedError                      // общие схемы ошибок, вынесенные в llms.txt
+	detectedLanguage string                             // язык, определённый по описаниям для lang: auto
+	previous         map[string]string                  // хеши файлов из манифеста прошлого запуска
+	written          map[string]string                  // хеши файлов, сгенерированных в этом запуске
+	owned            map[string]bool                    // файлы прошлых запусков из манифестов (ownedFiles)
+	published        map[string]GeneratedFile           // файлы этого запуска для manifest.json и Files
+	sectionContext   string                             // хеш контекста для ключей кэша секций
+	memory           map[string][]byte                  // файлы, сгенерированные в память (Render)
+	customSections   []customSection                    // пользовательские секции llms.txt из конфига
+	pathPrefixes     map[string]string                  // общий префикс путей группы для tags.<имя>.filename
+	progress         func(Progress)                     // индикатор прогресса (SetProgress)
+	endpointFiles    map[string]string                  // "METHOD /path" → уникальное имя файла эндпоинта
+	groupPages       map[string]string                  // имя группы → уникальное имя HTML-страницы
 }
 
 // New создаёт новый генератор
 func New(cfg *config.Config, api *parser.API) *Generator {
-	return &Generator{cfg: cfg, api: api}
+	return &Generator{cfg: cfg, api: api, ctx: context.Background()}
 }
 
 // Generate генерирует все файлы
 func (g *Generator) Generate() error {
-	// Создаём директории
-	endpointsDir := filepath.Join(g.cfg.Output, "endpoints")
-	if err := os.MkdirAll(endpointsDir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+	return g.GenerateContext(context.Background())
+}
+
+// GenerateContext генерирует все файлы, как Generate; после отмены ctx следующий файл не записывается
+// и возвращается ошибка ctx
+func (g *Generator) GenerateContext(ctx context.Context) error {
+	g.ctx = ctx
+	defer func() { g.ctx = context.Background() }()
+
+	g.loadManifest()
+	if err := g.generateAll(); err != nil {
+		return err
 	}
+	return g.saveManifest()
+}
 
-	// Сортируем эндпоинты
+// Render генерирует все файлы в память, не трогая директорию вывода.
+// Ключ — путь относительно директории вывода через /
+func (g *Generator) Render() (map[string][]byte, error) {
+	files := map[string][]byte{}
+	g.memory = files
+	defer func() { g.memory = nil }()
+
+	if err := g.generateAll(); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// generateAll генерирует документацию и, если включено, манифест публикации со всеми её файлами
+func (g *Generator) generateAll() error {
+	g.published = make(map[string]GeneratedFile)
+	if err := g.generate(); err != nil {
+		return err
+	}
+	if g.cfg.PublishManifest {
+		return g.writePublishManifest()
+	}
+	return nil
+}
+
+// EndpointFiles возвращает пути файлов эндпоинтов относительно директории вывода: "METHOD /path" → endpoints/<имя>
+func (g *Generator) EndpointFiles() map[string]string {
 	endpoints := g.sortEndpoints()
+	g.assignFilenames(endpoints)
+	files := make(map[string]string, len(endpoints))
+	for _, ep := range endpoints {
+		files[ep.Method+" "+ep.Path] = g.endpointPath(ep)
+	}
+	return files
+}
+
+// EndpointSection возвращает секцию одной операции (operationId или "METHOD /path")
+// в том же виде, что и в сгенерированной документации
+func (g *Generator) EndpointSection(op string) (string, error) {
+	if err := g.applyOverrides(); err != nil {
+		return "", err
+	}
+	if g.cfg.ResponseExamplesDir != "" {
+		examples, err := loadResponseExamples(g.cfg.ResponseExamplesDir)
+		if err != nil {
+			return "", err
+		}
+		g.responseExamples = examples
+	}
 
-	// Генерируем файл для каждого эндпоинта
+	endpoints := g.sortEndpoints()
+	g.prepare(endpoints)
 	for _, ep := range endpoints {
-		filename := g.getEndpointFilename(ep)
-		path := filepath.Join(endpointsDir, filename)
-		content := g.generateSingleEndpointFile(ep)
-		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		if matchesOperation(ep, op) {
+			return g.wrapSection("operation", endpointID(ep), g.generateEndpoint(ep)), nil
+		}
+	}
+	return "", fmt.Errorf("operation %q not found in spec", op)
+}
+
+func (g *Generator) generate() error {
+	// Контекст кэша секций зависит от конфига и спецификации, которые могли измениться с прошлого запуска
+	g.sectionContext = ""
+
+	// Проверяем, что выбранный сервер существует в спецификации
+	if _, err := g.selectedServer(); err != nil {
+		return err
+	}
+
+	// Правки из файла overrides применяются к спецификации до фильтра
+	if err := g.applyOverrides(); err != nil {
+		return err
+	}
+
+	// Исключаем операции, не прошедшие фильтр, до любой генерации
+	if err := g.applyFilter(); err != nil {
+		return err
+	}
+
+	// Сценарии из спецификации дополняются сценариями документа Arazzo
+	g.workflows = g.api.Workflows
+	if g.cfg.Workflows != "" {
+		workflows, err := parser.ParseWorkflowsFile(g.cfg.Workflows)
+		if err != nil {
+			return err
+		}
+		g.workflows = append(slices.Clip(g.workflows), workflows...)
+	}
+
+	// JSON-модель не зависит от настроек оформления
+	if g.cfg.Format == config.FormatJSON {
+		return g.generateJSON()
+	}
+
+	// Проверяем, что операция для быстрого старта существует
+	if g.cfg.Sandbox != nil {
+		if _, err := g.quickstartEndpoint(g.api.Endpoints); err != nil {
+			return err
+		}
+	}
+
+	// Загружаем готовые примеры ответов
+	if g.cfg.ResponseExamplesDir != "" {
+		examples, err := loadResponseExamples(g.cfg.ResponseExamplesDir)
+		if err != nil {
+			return err
+		}
+		g.responseExamples = examples
+	}
+
+	// Читаем пользовательские секции llms.txt
+	if err := g.loadCustomSections(); err != nil {
+		return err
+	}
+
+	// Сортируем эндпоинты
+	endpoints := g.sortEndpoints()
+
+	g.prepare(endpoints)
+
+	// Исполняемые примеры curl не зависят от формата документации
+	if g.cfg.CurlScripts {
+		if err := g.generateCurlScripts(endpoints); err != nil {
+			return err
+		}
+	}
+
+	if g.cfg.Renderer != "" {
+		return g.generateWithRenderer(endpoints)
+	}
+
+	if g.cfg.Format == config.FormatChunks {
+		return g.generateChunks(endpoints)
+	}
+
+	// Индекс ключевых слов ссылается на файлы встроенных форматов
+	if g.cfg.SearchIndex {
+		if err := g.generateSearchIndex(endpoints); err != nil {
+			return err
+		}
+	}
+
+	if g.isHTML() {
+		return g.generateHTML(endpoints)
+	}
+
+	// В плоском режиме отдельных файлов эндпоинтов нет — только llms.txt
+	var tracked []parser.Endpoint
+	if !g.isFlat() {
+		tracked = endpoints
+	}
+	progress := g.newProgressTracker(tracked, 1)
+
+	if g.isFlat() {
+		// Всё содержимое попадает в llms.txt, директория endpoints не нужна
+		if err := g.mkdirAll(g.cfg.Output); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	} else {
+
+		// Создаём директории
+		endpointsDir := filepath.Join(g.cfg.Output, filepath.FromSlash(g.endpointsDir()))
+		if err := g.mkdirAll(endpointsDir); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		// Генерируем файл для каждого эндпоинта
+		for _, ep := range endpoints {
+			filename := g.getEndpointFilename(ep)
+			path := filepath.Join(endpointsDir, filename)
+			if err := g.streamFile(path, func(w io.StringWriter) { g.writeSingleEndpointFile(w, ep) }); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			progress.endpointDone(g.groupName(ep))
+		}
+	}
+
+	// Глоссарий именованных схем рядом с llms.txt
+	if g.hasSchemaGlossary() {
+		path := filepath.Join(g.cfg.Output, g.schemaGlossaryFile())
+		if err := g.writeFile(path, []byte(g.generateSchemaGlossary())); err != nil {
 			return fmt.Errorf("failed to write %s: %w", path, err)
 		}
 	}
 
-	// Генерируем индексный файл llms.txt
+	// Генерируем индексный файл llms.txt; в плоском режиме операции пишутся в него по одной
 	indexPath := filepath.Join(g.cfg.Output, "llms.txt")
-	indexContent := g.generateIndex(endpoints)
-	if err := os.WriteFile(indexPath, []byte(indexContent), 0644); err != nil {
+	if err := g.streamFile(indexPath, func(w io.StringWriter) { g.writeIndex(w, endpoints) }); err != nil {
 		return fmt.Errorf("failed to write llms.txt: %w", err)
 	}
+	progress.fileDone()
 
 	return nil
 }
 
-// getEndpointFilename генерирует имя файла для endpoint'а
+// prepare собирает общие для всех файлов данные: синтезированные summary, вынесенные фрагменты и схемы ошибок
+func (g *Generator) prepare(endpoints []parser.Endpoint) {
+	// Операциям без summary подставляем синтезированное, чтобы строки индекса не были пустыми
+	g.fillSummaries(endpoints)
+
+	// Фрагменты для RAG самодостаточны: общих секций llms.txt, на которые ссылались бы эндпоинты, нет
+	if g.cfg.Format != config.FormatChunks {
+		// Выносим повторяющиеся абзацы описаний в общую секцию
+		if g.cfg.SharedFragments {
+			g.fragments = detectSharedFragments(endpoints)
+		}
+
+		// Выносим повторяющиеся схемы ошибок в секцию Errors
+		g.sharedErrors = detectSharedErrors(endpoints)
+	}
+
+	// Назначаем уникальные имена файлов, чтобы совпадающие имена не перезаписывали друг друга
+	g.assignFilenames(endpoints)
+}
+
+// getEndpointFilename возвращает имя файла для endpoint'а с учётом разрешённых коллизий
 func (g *Generator) getEndpointFilename(ep parser.Endpoint) string {
+	if name, ok := g.endpointFiles[ep.Method+" "+ep.Path]; ok {
+		return name
+	}
+	return g.baseEndpointFilename(ep)
+}
+
+// baseEndpointFilename генерирует имя файла для endpoint'а по operationId или методу и пути
+func (g *Generator) baseEndpointFilename(ep parser.Endpoint) string {
+	if g.cfg.FileNaming == config.FileNamingOperationID && ep.OperationID != "" {
+		return unsafeFilenameChars.ReplaceAllString(ep.OperationID, "-") + g.fileExtension()
+	}
+
 	// GET /v1.4/person/search -> get-v1.4-person-search.txt
 	path := strings.TrimPrefix(ep.Path, "/")
-	path = strings.ReplaceAll(path, "/", "-")
-	path = strings.ReplaceAll(path, "{", "")
-	path = strings.ReplaceAll(path, "}", "")
-	return strings.ToLower(ep.Method) + "-" + path + ".txt"
+
+	// tags.<группа>.filename заменяет общий префикс путей группы:
+	// filename people для /v1.4/person/... -> get-people-search.txt
+	if group := g.groupName(ep); g.cfg.Tags[group].Filename != "" {
+		rest := strings.TrimPrefix(strings.TrimPrefix(ep.Path, g.groupPathPrefix(group)), "/")
+		path = g.cfg.Tags[group].Filename
+		if rest != "" {
+			path += "/" + rest
+		}
+	}
+
+	// Сегменты пути и имя из tags.<группа>.filename приводятся к именам, допустимым на всех ОС:
+	// /things/{id}:cancel -> things-id-cancel, /товары -> tovary
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.NewReplacer("{", "", "}", "").Replace(segment)
+		if name := cleanFilename(segment); name != "" {
+			segments = append(segments, name)
+		}
+	}
+	return strings.ToLower(ep.Method) + "-" + strings.Join(segments, "-") + g.fileExtension()
 }
 
-// sortEndpoints сортирует эндпоинты по пути и методу
+// sortEndpoints сортирует эндпоинты согласно стратегии sort (по умолчанию — по пути и методу)
 func (g *Generator) sortEndpoints() []parser.Endpoint {
 	endpoints := make([]parser.Endpoint, len(g.api.Endpoints))
 	copy(endpoints, g.api.Endpoints)
 
-	sort.Slice(endpoints, func(i, j int) bool {
-		if endpoints[i].Path == endpoints[j].Path {
-			return methodOrder(endpoints[i].Method) < methodOrder(endpoints[j].Method)
+	byPath := func(a, b parser.Endpoint) bool {
+		if a.Path == b.Path {
+			return methodOrder(a.Method) < methodOrder(b.Method)
 		}
-		return endpoints[i].Path < endpoints[j].Path
-	})
+		return a.Path < b.Path
+	}
+
+	switch g.cfg.Sort {
+	case config.SortSpecOrder:
+		// Парсер уже отдаёт эндпоинты в порядке спецификации
+	case config.SortMethod:
+		sort.SliceStable(endpoints, func(i, j int) bool {
+			mi, mj := methodOrder(endpoints[i].Method), methodOrder(endpoints[j].Method)
+			if mi != mj {
+				return mi < mj
+			}
+			return byPath(endpoints[i], endpoints[j])
+		})
+	case config.SortOperationID:
+		sort.SliceStable(endpoints, func(i, j int) bool {
+			oi, oj := endpoints[i].OperationID, endpoints[j].OperationID
+			if oi != oj {
+				// Операции без operationId — в конце
+				if oi == "" || oj == "" {
+					return oj == ""
+				}
+				return oi < oj
+			}
+			return byPath(endpoints[i], endpoints[j])
+		})
+	default:
+		sort.SliceStable(endpoints, func(i, j int) bool {
+			return byPath(endpoints[i], endpoints[j])
+		})
+	}
 
 	return endpoints
 }
@@ -81,14 +365,18 @@ func (g *Generator) sortEndpoints() []parser.Endpoint {
 // generateSingleEndpointFile генерирует содержимое файла для одного endpoint'а
 func (g *Generator) generateSingleEndpointFile(ep parser.Endpoint) string {
 	var sb strings.Builder
+	g.writeSingleEndpointFile(&sb, ep)
+	return sb.String()
+}
 
-	// Заголовок с тегом если есть
-	if len(ep.Tags) > 0 {
-		sb.WriteString("# " + ep.Tags[0] + "\n\n")
+// writeSingleEndpointFile пишет файл одного endpoint'а
+func (g *Generator) writeSingleEndpointFile(w io.StringWriter, ep parser.Endpoint) {
+	// Заголовок с группой если есть
+	if group := g.groupName(ep); group != "" {
+		w.WriteString("# " + g.groupTitle(group) + "\n\n")
 	}
 
-	sb.WriteString(g.generateEndpoint(ep))
-	return sb.String()
+	w.WriteString(g.wrapSection("operation", endpointID(ep), g.generateEndpoint(ep)))
 }
 
 func methodOrder(method string) int {
@@ -101,6 +389,14 @@ func methodOrder(method string) int {
 
 func (g *Generator) generateIndex(endpoints []parser.Endpoint) string {
 	var sb strings.Builder
+	g.writeIndex(&sb, endpoints)
+	return sb.String()
+}
+
+// writeIndex пишет llms.txt. Операции плоского режима рендерятся по одной в момент записи,
+// чтобы для больших спецификаций не держать в памяти их все сразу
+func (g *Generator) writeIndex(w io.StringWriter, endpoints []parser.Endpoint) {
+	var sb strings.Builder
 
 	// Заголовок
 	title := g.cfg.Title
@@ -110,17 +406,26 @@ func (g *Generator) generateIndex(endpoints []parser.Endpoint) string {
 	sb.WriteString("# " + title + "\n\n")
 
 	// Описание
-	if g.api.Description != "" {
-		sb.WriteString("> " + g.api.Description + "\n\n")
+	// Первый абзац описания — краткая сводка в цитате, остальные абзацы идут следом
+	if desc := g.description(g.api.Description); desc != "" {
+		summary, details, _ := strings.Cut(desc, "\n\n")
+		sb.WriteString("> " + strings.ReplaceAll(summary, "\n", "\n> ") + "\n\n")
+		if details != "" {
+			sb.WriteString(details + "\n\n")
+		}
 	}
+	sb.WriteString(formatExternalDocs(g.api.ExternalDocs))
 
 	// Базовый URL
-	baseURL := g.cfg.BaseURL
-	if baseURL == "" {
-		baseURL = g.api.BaseURL
-	}
-	if baseURL != "" {
+	if baseURL := g.baseURL(); baseURL != "" {
 		sb.WriteString("Base URL: `" + baseURL + "`\n\n")
+
+		// Для единственного сервера с переменными документируем их рядом с базовым URL
+		if len(g.api.Servers) == 1 && len(g.api.Servers[0].Variables) > 0 && g.cfg.BaseURL == "" {
+			sb.WriteString("Server variables (`" + g.api.Servers[0].URL + "`):\n\n")
+			sb.WriteString(formatServerVariables(g.api.Servers[0], ""))
+			sb.WriteString("\n")
+		}
 	}
 
 	// Версия
@@ -128,38 +433,295 @@ func (g *Generator) generateIndex(endpoints []parser.Endpoint) string {
 		sb.WriteString("Version: " + g.api.Version + "\n\n")
 	}
 
+	// Секции индекса в порядке вывода; пользовательские секции вставляются между ними
+	var sections []indexSection
+	add := func(id, content string) {
+		if content != "" {
+			sections = append(sections, indexSection{kind: "section", id: id, content: content})
+		}
+	}
+
+	// Быстрый старт на sandbox
+	if g.cfg.Sandbox != nil {
+		ep, _ := g.quickstartEndpoint(endpoints)
+		add("try-it", g.generateQuickstart(ep))
+	}
+
+	// Серверы (окружения)
+	if len(g.api.Servers) > 1 {
+		add("servers", g.generateServers())
+	}
+
 	// Аутентификация
 	if len(g.api.SecuritySchemes) > 0 {
-		sb.WriteString("## Authentication\n\n")
+		var auth strings.Builder
+		auth.WriteString("## " + g.heading("Authentication") + "\n\n")
 		for _, scheme := range g.api.SecuritySchemes {
-			sb.WriteString(g.formatSecurityScheme(scheme))
+			auth.WriteString(g.formatSecurityScheme(scheme))
 		}
-		sb.WriteString("\n")
+		auth.WriteString("\n")
+		add("authentication", auth.String())
+	}
+
+	// Опасные операции
+	add("destructive-operations", g.generateDestructiveOperations(endpoints))
+
+	// Легенда бейджей зрелости
+	add("stability", g.generateStabilityLegend(endpoints))
+
+	// Ограничения частоты запросов
+	add("rate-limits", g.generateRateLimits(endpoints))
+
+	// Общие схемы ошибок
+	if len(g.sharedErrors) > 0 {
+		add("errors", g.generateErrors())
+	}
+
+	// Общие фрагменты описаний
+	if len(g.fragments) > 0 {
+		add("shared-notes", g.generateSharedFragments())
+	}
+
+	// Сценарии из нескольких вызовов
+	add("workflows", g.generateWorkflows())
+
+	// Ссылка на глоссарий схем идёт после эндпоинтов
+	var glossary string
+	if g.hasSchemaGlossary() {
+		glossary = g.generateSchemaGlossaryLink()
+	}
+
+	// В плоском режиме эндпоинты целиком идут в llms.txt, иначе — список ссылок
+	if g.isFlat() {
+		for _, ep := range endpoints {
+			sections = append(sections, indexSection{kind: "operation", id: endpointID(ep), render: func() string { return g.generateEndpoint(ep) }})
+		}
+		add("schemas", glossary)
+	} else {
+		if g.cfg.IndexLayout == config.IndexLayoutMatrix {
+			add("endpoints", g.generateEndpointMatrix(endpoints))
+		} else {
+			add("endpoints", g.generateEndpointsList(endpoints))
+		}
+		add("schemas", glossary)
+		if optional := g.generateOptionalList(endpoints); optional != "" {
+			add("optional", optional)
+		}
+	}
+
+	// Журнал изменений из команды changelog
+	if g.hasChangelog() {
+		add("changelog", g.generateChangelogLink())
+	}
+
+	// Контакты, лицензия и условия использования
+	add("about", g.generateAbout())
+
+	w.WriteString(sb.String())
+	separated := strings.HasSuffix(sb.String(), "\n\n")
+	for _, section := range g.insertCustomSections(sections) {
+		content := section.content
+		if section.render != nil {
+			content = section.render()
+		}
+		// Секции отделяются пустой строкой, даже если предыдущая (список ссылок) её не оставила
+		if section.kind == "section" && !separated {
+			w.WriteString("\n")
+		}
+		wrapped := g.wrapSection(section.kind, section.id, content)
+		w.WriteString(wrapped)
+		separated = strings.HasSuffix(wrapped, "\n\n") || (wrapped == "" && separated)
+	}
+}
+
+// isFlat сообщает, что все эндпоинты выводятся в единственный файл llms.txt (groupBy: none)
+func (g *Generator) isFlat() bool {
+	return g.cfg.GroupBy == config.GroupByNone
+}
+
+// generateEndpointsList генерирует список ссылок на файлы эндпоинтов обязательных групп
+func (g *Generator) generateEndpointsList(endpoints []parser.Endpoint) string {
+	groups := g.groupEndpoints(endpoints)
+	primary, _ := g.splitOptionalGroups(groups)
+
+	var sb strings.Builder
+	sb.WriteString("## " + g.heading("Endpoints") + "\n\n")
+	g.writeGroupLinks(&sb, primary, len(groups) > 1)
+	return sb.String()
+}
+
+// generateOptionalList генерирует секцию "## Optional" из спецификации llms.txt: группы,
+// которые агент может пропустить при нехватке контекста. Пустая строка, если таких групп нет
+func (g *Generator) generateOptionalList(endpoints []parser.Endpoint) string {
+	_, optional := g.splitOptionalGroups(g.groupEndpoints(endpoints))
+	if len(optional) == 0 {
+		return ""
 	}
 
-	// Список эндпоинтов
-	sb.WriteString("## Endpoints\n\n")
+	// Заголовок не переводится: по спецификации llms.txt секция распознаётся по имени
+	var sb strings.Builder
+	sb.WriteString("## Optional\n\n")
+	g.writeGroupLinks(&sb, optional, true)
+	return sb.String()
+}
+
+// writeGroupLinks выводит группы со ссылками на файлы эндпоинтов.
+// Подзаголовки групп нужны, только если эндпоинты вообще сгруппированы
+func (g *Generator) writeGroupLinks(sb *strings.Builder, groups []endpointGroup, grouped bool) {
+	for i, group := range groups {
+		if grouped || group.Name != "" {
+			name := group.Title
+			if name == "" {
+				name = g.heading("Other")
+			}
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString("### " + name + stabilityBadge(g.tagStability(group.Name)) + "\n\n")
+			if desc := g.description(group.Description); desc != "" {
+				sb.WriteString(desc + "\n\n")
+			}
+			sb.WriteString(formatExternalDocs(group.ExternalDocs))
+			if url, ok := g.groupBaseURL(group.Name); ok {
+				sb.WriteString("Base URL: `" + url + "`\n\n")
+			}
+		}
+
+		for _, ep := range group.Endpoints {
+			link := g.endpointLink(ep, group.Name)
+			summary := ep.Summary
+			if summary == "" {
+				summary = ep.Path
+			}
+			sb.WriteString(fmt.Sprintf("- [%s %s](%s) — %s%s\n",
+				ep.Method, ep.Path, link, summary, stabilityBadge(g.stability(ep))))
+		}
+	}
+}
+
+// endpointLink возвращает ссылку из индекса на документацию эндпоинта
+func (g *Generator) endpointLink(ep parser.Endpoint, group string) string {
+	if g.isHTML() {
+		// В HTML эндпоинты группы собраны на одной странице
+		return "groups/" + g.groupPageName(group) + "#" + endpointAnchor(ep)
+	}
 
 	// Формируем базовый путь для ссылок на документацию
-	linksBase := "./endpoints"
+	linksBase := "."
 	if g.cfg.DocsBaseURL != "" {
-		linksBase = strings.TrimSuffix(g.cfg.DocsBaseURL, "/") + "/endpoints"
+		linksBase = strings.TrimSuffix(g.cfg.DocsBaseURL, "/")
 	}
+	return linksBase + "/" + g.endpointPath(ep) + "#" + endpointAnchor(ep)
+}
 
-	for _, ep := range endpoints {
-		filename := g.getEndpointFilename(ep)
-		summary := ep.Summary
-		if summary == "" {
-			summary = ep.Path
+// selectedServer возвращает сервер, выбранный через --server (по умолчанию первый)
+func (g *Generator) selectedServer() (*parser.Server, error) {
+	servers := g.api.Servers
+	if len(servers) == 0 {
+		return nil, nil
+	}
+	if g.cfg.Server == "" {
+		return &servers[0], nil
+	}
+
+	if idx, err := strconv.Atoi(g.cfg.Server); err == nil {
+		if idx < 0 || idx >= len(servers) {
+			return nil, fmt.Errorf("server index %d out of range (spec has %d servers)", idx, len(servers))
 		}
-		sb.WriteString(fmt.Sprintf("- [%s %s](%s/%s) — %s\n",
-			ep.Method, ep.Path, linksBase, filename, summary))
+		return &servers[idx], nil
 	}
 
+	for i := range servers {
+		s := &servers[i]
+		if strings.EqualFold(s.Description, g.cfg.Server) || s.URL == g.cfg.Server || s.ResolvedURL() == g.cfg.Server {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("server %q not found in spec", g.cfg.Server)
+}
+
+// baseURL возвращает базовый URL API: из конфига или выбранного сервера
+func (g *Generator) baseURL() string {
+	if g.cfg.BaseURL != "" {
+		return g.cfg.BaseURL
+	}
+	if server, err := g.selectedServer(); err == nil && server != nil {
+		return server.ResolvedURL()
+	}
+	return g.api.BaseURL
+}
+
+// endpointBaseURL возвращает базовый URL эндпоинта с учётом переопределения для его группы
+func (g *Generator) endpointBaseURL(ep parser.Endpoint) string {
+	if url, ok := g.groupBaseURL(g.groupName(ep)); ok {
+		return url
+	}
+	return g.baseURL()
+}
+
+// groupBaseURL возвращает базовый URL, переопределённый для группы в groupBaseUrls
+func (g *Generator) groupBaseURL(group string) (string, bool) {
+	if group == "" {
+		return "", false
+	}
+	url, ok := g.cfg.GroupBaseURLs[group]
+	return url, ok && url != ""
+}
+
+// generateServers генерирует список всех серверов с описаниями и переменными
+func (g *Generator) generateServers() string {
+	var sb strings.Builder
+	sb.WriteString("## " + g.heading("Servers") + "\n\n")
+
+	selected, _ := g.selectedServer()
+	for i := range g.api.Servers {
+		server := &g.api.Servers[i]
+		line := "- `" + server.ResolvedURL() + "`"
+		if server.Description != "" {
+			line += " — " + g.inlineDescription(server.Description)
+		}
+		if server == selected && g.cfg.BaseURL == "" {
+			line += " (used in examples)"
+		}
+		sb.WriteString(line + "\n")
+		sb.WriteString(formatServerVariables(*server, "  "))
+	}
+	sb.WriteString("\n")
+
 	return sb.String()
 }
 
+// formatServerVariables документирует переменные URL сервера: значение по умолчанию и допустимые значения
+func formatServerVariables(server parser.Server, indent string) string {
+	names := make([]string, 0, len(server.Variables))
+	for name := range server.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		v := server.Variables[name]
+		line := fmt.Sprintf("%s- `{%s}`", indent, name)
+		if v.Description != "" {
+			line += " — " + v.Description + "."
+		}
+		line += fmt.Sprintf(" Default: `%s`", v.Value())
+		if len(v.Enum) > 0 {
+			line += fmt.Sprintf(". Values: `%s`", strings.Join(v.Enum, "`, `"))
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
+
+// generateEndpoint возвращает секцию эндпоинта, переиспользуя ранее отрендеренную
 func (g *Generator) generateEndpoint(ep parser.Endpoint) string {
+	return g.cachedEndpoint(ep)
+}
+
+// renderEndpoint рендерит секцию эндпоинта
+func (g *Generator) renderEndpoint(ep parser.Endpoint) string {
 	var sb strings.Builder
 
 	// Заголовок: METHOD /path - Summary
@@ -167,96 +729,253 @@ func (g *Generator) generateEndpoint(ep parser.Endpoint) string {
 	if ep.Summary != "" {
 		header += " - " + ep.Summary
 	}
-	if ep.Deprecated {
+	header += stabilityBadge(g.stability(ep))
+	if _, deprecated := deprecationInfo(ep); deprecated {
 		header += " ⚠️ DEPRECATED"
 	}
+	sb.WriteString(g.headingAnchor(ep))
 	sb.WriteString(header + "\n\n")
 
+	// Когда операция перестанет работать и чем её заменить
+	sb.WriteString(g.generateDeprecationNote(ep))
+
+	// Предупреждение для операций, удаляющих данные
+	sb.WriteString(g.generateDestructiveWarning(ep))
+
+	// Базовый URL, если группа эндпоинта живёт на отдельном хосте
+	if url, ok := g.groupBaseURL(g.groupName(ep)); ok {
+		sb.WriteString("Base URL: `" + url + "`\n\n")
+	}
+
 	// Описание
 	if ep.Description != "" {
-		sb.WriteString(ep.Description + "\n\n")
+		desc := truncateDescription(g.renderDescription(ep.Description), g.cfg.MaxDescriptionLength, ep.ExternalDocs != nil)
+		sb.WriteString(desc + "\n\n")
 	}
+	sb.WriteString(formatExternalDocs(ep.ExternalDocs))
+
+	// Меняет ли операция состояние и можно ли её повторить
+	sb.WriteString(g.generateSafetyLine(ep))
+
+	// Примечания из overrides
+	if len(ep.Notes) > 0 {
+		sb.WriteString("### " + g.heading("Notes") + "\n\n")
+		for _, note := range ep.Notes {
+			sb.WriteString("- " + g.inlineDescription(note) + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	// Требуемая аутентификация
+	sb.WriteString(g.generateEndpointSecurity(ep))
 
 	// Параметры
 	if len(ep.Parameters) > 0 {
-		sb.WriteString("### Parameters\n\n")
-		sb.WriteString("| Name | In | Type | Required | Description |\n")
-		sb.WriteString("|------|-----|------|----------|-------------|\n")
+		sb.WriteString("### " + g.heading("Parameters") + "\n\n")
+		// Колонка Default только если у какого-то параметра есть значение по умолчанию:
+		// у большинства операций её не будет, и пустая колонка лишь тратит токены
+		withDefaults := slices.ContainsFunc(ep.Parameters, func(p parser.Parameter) bool { return p.Default != nil })
+		if withDefaults {
+			sb.WriteString("| Name | In | Type | Required | Default | Description |\n")
+			sb.WriteString("|------|-----|------|----------|---------|-------------|\n")
+		} else {
+			sb.WriteString("| Name | In | Type | Required | Description |\n")
+			sb.WriteString("|------|-----|------|----------|-------------|\n")
+		}
 
 		for _, p := range ep.Parameters {
 			required := ""
 			if p.Required {
 				required = "✓"
 			}
-			desc := p.Description
-			if len(p.Enum) > 0 {
+			desc := g.inlineDescription(p.Description)
+			// Значения с описаниями перечисляет таблица под параметрами, в строке они лишь повторились бы
+			if len(p.Enum) > 0 && len(p.EnumDescriptions) == 0 {
 				desc += fmt.Sprintf(" Enum: `%s`", strings.Join(p.Enum, "`, `"))
 			}
+			desc += serializationNote(p)
+			if withDefaults {
+				sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n",
+					p.Name, p.In, paramType(p), required, formatDefault(p.Default), strings.TrimSpace(desc)))
+				continue
+			}
 			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
-				p.Name, p.In, p.Type, required, desc))
+				p.Name, p.In, paramType(p), required, strings.TrimSpace(desc)))
 		}
 		sb.WriteString("\n")
+		for _, p := range ep.Parameters {
+			sb.WriteString(g.enumTable(p.Name, p.Enum, p.EnumDescriptions))
+		}
 	}
 
 	// Request Body
 	if ep.RequestBody != nil {
-		sb.WriteString("### Request Body\n\n")
-		if ep.RequestBody.Description != "" {
-			sb.WriteString(ep.RequestBody.Description + "\n\n")
+		sb.WriteString("### " + g.heading("Request Body") + "\n\n")
+		if desc := g.description(ep.RequestBody.Description); desc != "" {
+			sb.WriteString(desc + "\n\n")
 		}
-		for contentType, media := range ep.RequestBody.Content {
+		for _, contentType := range orderContentTypes(ep.RequestBody.Content, g.cfg.ContentTypes) {
+			media := ep.RequestBody.Content[contentType]
 			sb.WriteString("Content-Type: `" + contentType + "`\n\n")
-			if media.Schema != nil {
-				sb.WriteString(g.generateSchemaDoc(media.Schema, 0))
+			switch {
+			case media.Schema == nil:
+			case isFormContentType(contentType):
+				// Поля формы передаются по отдельности — JSON-пример только запутает
+				sb.WriteString(g.generateFormDoc(media.Schema))
+			default:
+				sb.WriteString(g.requiredFieldsLine(media.Schema))
+				sb.WriteString(g.generateMediaSchemaDoc(media.Schema, contentType, "request", 0))
 			}
 		}
 	}
 
+	// Таймаут и ограничение размера запроса
+	sb.WriteString(g.generateLimits(ep))
+
+	// Подключение WebSocket и сообщения в обе стороны
+	sb.WriteString(g.generateWebSocket(ep))
+
+	// Потоковый ответ: формат событий и когда поток заканчивается
+	sb.WriteString(g.generateStreamingNote(ep))
+
 	// Responses
 	if len(ep.Responses) > 0 {
-		sb.WriteString("### Responses\n\n")
+		sb.WriteString("### " + g.heading("Responses") + "\n\n")
+		sb.WriteString(g.generateResponseFormats(ep))
 
 		// Сортируем коды ответов
 		codes := make([]string, 0, len(ep.Responses))
 		for code := range ep.Responses {
 			codes = append(codes, code)
 		}
-		sort.Strings(codes)
+		sortResponseCodes(codes)
 
 		for _, code := range codes {
 			resp := ep.Responses[code]
-			sb.WriteString(fmt.Sprintf("**%s** - %s\n\n", code, resp.Description))
-
-			for contentType, media := range resp.Content {
+			sb.WriteString(g.responseHeading(code, g.inlineDescription(resp.Description)) + "\n\n")
+
+			// Готовый пример из responseExamplesDir заменяет синтезированный по схеме
+			example, hasExample := g.getResponseExample(ep, code)
+			for _, contentType := range orderContentTypes(resp.Content, g.cfg.ContentTypes) {
+				media := resp.Content[contentType]
+				if isBinaryContent(contentType, media.Schema) {
+					// Схему двоичных данных не показываем: агенту нужно только сохранить ответ в файл
+					sb.WriteString(g.heading("Returns binary data") + " (content-type `" + contentType + "`)\n\n")
+					continue
+				}
 				sb.WriteString("Content-Type: `" + contentType + "`\n\n")
-				if media.Schema != nil {
-					sb.WriteString(g.generateSchemaDoc(media.Schema, 0))
+				if hasExample {
+					sb.WriteString(formatResponseExample(example))
+					hasExample = false
+					if media.Schema != nil {
+						sb.WriteString(g.generateFieldsTable(media.Schema, ""))
+					}
+					continue
 				}
+				shared, isShared := g.sharedErrorFor(code, media.Schema)
+				switch {
+				case isShared:
+					sb.WriteString(g.formatSharedErrorReference(shared))
+				case isCSVContent(contentType) && recordSchema(media.Schema) != nil:
+					sb.WriteString(g.generateCSVDoc(media.Schema))
+				case isNDJSONContent(contentType) && recordSchema(media.Schema) != nil:
+					sb.WriteString(g.generateNDJSONDoc(media.Schema))
+				case media.Schema != nil:
+					sb.WriteString(g.generateMediaSchemaDoc(media.Schema, contentType, "response", 0))
+				}
+			}
+			if hasExample {
+				sb.WriteString(formatResponseExample(example))
 			}
+			sb.WriteString(g.generateResponseLinks(resp.Links))
 		}
 	}
 
-	// Пример curl
-	sb.WriteString("### Example\n\n")
-	sb.WriteString(g.generateCurlExample(ep))
+	// Примеры запроса (curl и выбранные в конфиге форматы)
+	sb.WriteString("### " + g.heading("Example") + "\n\n")
+	sb.WriteString(g.generateExamples(ep))
+	sb.WriteString(g.generateAlternateFormatExamples(ep))
 
 	return sb.String()
 }
 
-// maxNestedDepth — максимальная глубина раскрытия вложенных объектов
+// formatExternalDocs оформляет ссылку на внешнюю документацию
+func formatExternalDocs(docs *parser.ExternalDocs) string {
+	if docs == nil {
+		return ""
+	}
+	if docs.Description != "" {
+		return fmt.Sprintf("More info: [%s](%s)\n\n", docs.Description, docs.URL)
+	}
+	return "More info: <" + docs.URL + ">\n\n"
+}
+
+// maxNestedDepth — максимальная глубина раскрытия вложенных объектов по умолчанию
 const maxNestedDepth = 4
 
+// schemaDepth возвращает глубину раскрытия вложенных объектов (maxSchemaDepth или по умолчанию)
+func (g *Generator) schemaDepth() int {
+	if g.cfg.MaxSchemaDepth > 0 {
+		return g.cfg.MaxSchemaDepth
+	}
+	return maxNestedDepth
+}
+
+// truncationNote описывает поля, скрытые ограничением maxPropertiesPerObject: "... 3 more fields,
+// see schemas/User". С link и глоссарием схем schemas/User — ссылка на схему в нём
+func (g *Generator) truncationNote(schema *parser.Schema, hidden int, link bool) string {
+	note := fmt.Sprintf("... %d more fields", hidden)
+	if hidden == 1 {
+		note = "... 1 more field"
+	}
+	name := schema.RefName()
+	switch {
+	case name == "":
+		return note
+	case link && g.hasSchemaGlossary():
+		return note + ", see [" + schemaGlossaryName + "/" + name + "](" + g.rootLink(g.schemaGlossaryFile()) + "#" + slugify(name) + ")"
+	}
+	return note + ", see " + schemaGlossaryName + "/" + name
+}
+
+// requiredFieldsLine возвращает строку "Required: name, email" с обязательными полями тела запроса,
+// чтобы они оставались видны, даже если пример JSON урезан лимитами схемы
+func (g *Generator) requiredFieldsLine(schema *parser.Schema) string {
+	if schema.Type == "array" && schema.Items != nil {
+		schema = schema.Items
+	}
+	if len(schema.Required) == 0 {
+		return ""
+	}
+	return g.heading("Required") + ": `" + strings.Join(schema.Required, "`, `") + "`\n\n"
+}
+
 func (g *Generator) generateSchemaDoc(schema *parser.Schema, depth int) string {
-	if schema == nil || depth > 4 {
+	return g.generateMediaSchemaDoc(schema, "", "", depth)
+}
+
+// generateMediaSchemaDoc описывает схему тела с типом содержимого contentType: XML-тело
+// показывается XML-примером с корнем root для схемы без имени, остальные — JSON-примером
+func (g *Generator) generateMediaSchemaDoc(schema *parser.Schema, contentType, root string, depth int) string {
+	if schema == nil || depth > g.schemaDepth() {
 		return ""
 	}
 
 	var sb strings.Builder
 
 	if schema.Type == "object" && len(schema.Properties) > 0 {
-		sb.WriteString("```json\n")
-		sb.WriteString(g.renderJSONSchema(schema, 0, maxNestedDepth))
-		sb.WriteString("```\n\n")
+		// Скрытые лимитом поля отмечены комментарием: такой пример — JSON с комментариями
+		example := prettyJSON(g.renderJSONSchema(schema, 0, g.schemaDepth(), g.cfg.MaxPropertiesPerObject))
+		lang := "json"
+		switch {
+		case isXMLContentType(contentType):
+			// В XML комментарий о скрытых полях не переносится: их всё равно перечисляет таблица полей
+			example = jsonToXML(stripJSONComments(example), xmlRootName(schema, root))
+			lang = "xml"
+		case strings.Contains(example, "// ... "):
+			lang = "jsonc"
+		}
+		sb.WriteString("```" + lang + "\n" + example + "\n```\n\n")
 
 		// Добавляем описание полей в виде таблицы
 		sb.WriteString(g.generateFieldsTable(schema, ""))
@@ -267,14 +986,16 @@ func (g *Generator) generateSchemaDoc(schema *parser.Schema, depth int) string {
 		}
 		sb.WriteString(fmt.Sprintf("Array of `%s`\n\n", itemType))
 		if schema.Items.Type == "object" && len(schema.Items.Properties) > 0 {
-			sb.WriteString(g.generateSchemaDoc(schema.Items, depth+1))
+			sb.WriteString(g.generateMediaSchemaDoc(schema.Items, contentType, root, depth+1))
 		}
 	}
 
 	return sb.String()
 }
 
-func (g *Generator) renderJSONSchema(schema *parser.Schema, indent, maxDepth int) string {
+// renderJSONSchema рендерит пример JSON по схеме. maxProps ограничивает число полей
+// каждого объекта (0 — без ограничения), скрытые поля отмечаются комментарием
+func (g *Generator) renderJSONSchema(schema *parser.Schema, indent, maxDepth, maxProps int) string {
 	if schema == nil || indent > maxDepth*2 {
 		return ""
 	}
@@ -291,6 +1012,12 @@ func (g *Generator) renderJSONSchema(schema *parser.Schema, indent, maxDepth int
 		}
 		sort.Strings(props)
 
+		hidden := 0
+		if maxProps > 0 && len(props) > maxProps {
+			hidden = len(props) - maxProps
+			props = props[:maxProps]
+		}
+
 		for i, name := range props {
 			prop := schema.Properties[name]
 			comma := ","
@@ -299,7 +1026,7 @@ func (g *Generator) renderJSONSchema(schema *parser.Schema, indent, maxDepth int
 			}
 
 			sb.WriteString(prefix + "  \"" + name + "\": ")
-			value := g.renderPropertyValue(prop, indent+1, maxDepth)
+			value := g.renderPropertyValue(prop, indent+1, maxDepth, maxProps)
 			if value == "" {
 				// Fallback для пустых значений
 				if prop.Type == "array" {
@@ -313,12 +1040,15 @@ func (g *Generator) renderJSONSchema(schema *parser.Schema, indent, maxDepth int
 			sb.WriteString(value)
 			sb.WriteString(comma + "\n")
 		}
+		if hidden > 0 {
+			sb.WriteString(prefix + "  // " + g.truncationNote(schema, hidden, false) + "\n")
+		}
 
 		sb.WriteString(prefix + "}")
 	} else if schema.Type == "array" {
 		if schema.Items != nil && schema.Items.Type == "object" && len(schema.Items.Properties) > 0 {
 			sb.WriteString("[\n" + prefix + "  ")
-			sb.WriteString(g.renderJSONSchema(schema.Items, indent+1, maxDepth))
+			sb.WriteString(g.renderJSONSchema(schema.Items, indent+1, maxDepth, maxProps))
 			sb.WriteString("\n" + prefix + "]")
 		} else if schema.Items != nil {
 			sb.WriteString("[" + g.getTypeExample(schema.Items) + "]")
@@ -334,7 +1064,7 @@ func (g *Generator) renderJSONSchema(schema *parser.Schema, indent, maxDepth int
 	return sb.String()
 }
 
-func (g *Generator) renderPropertyValue(prop *parser.Schema, indent, maxDepth int) string {
+func (g *Generator) renderPropertyValue(prop *parser.Schema, indent, maxDepth, maxProps int) string {
 	if prop == nil {
 		return "null"
 	}
@@ -346,7 +1076,7 @@ func (g *Generator) renderPropertyValue(prop *parser.Schema, indent, maxDepth in
 
 	// Для объектов рекурсивно разворачиваем
 	if prop.Type == "object" && len(prop.Properties) > 0 && indent < maxDepth*2 {
-		return g.renderJSONSchema(prop, indent, maxDepth)
+		return g.renderJSONSchema(prop, indent, maxDepth, maxProps)
 	}
 
 	// Для массивов
@@ -354,7 +1084,7 @@ func (g *Generator) renderPropertyValue(prop *parser.Schema, indent, maxDepth in
 		if prop.Items != nil {
 			// Объект с properties - разворачиваем
 			if prop.Items.Type == "object" && len(prop.Items.Properties) > 0 {
-				return g.renderJSONSchema(prop, indent, maxDepth)
+				return g.renderJSONSchema(prop, indent, maxDepth, maxProps)
 			}
 			// Объект без properties или другой тип
 			example := g.getTypeExample(prop.Items)
@@ -383,9 +1113,16 @@ func (g *Generator) getTypeExample(schema *parser.Schema) string {
 		return g.formatExample(schema.Example)
 	}
 
-	// Если есть enum - показываем первое значение
+	// Если есть enum - показываем первое значение; нестроковые значения уже записаны как JSON
 	if len(schema.Enum) > 0 {
-		return fmt.Sprintf("\"%s\"", schema.Enum[0])
+		switch schema.Type {
+		case "integer", "number", "boolean":
+			// Значение, не совпадающее с типом (спецификация без валидации), выводится строкой
+			if json.Valid([]byte(schema.Enum[0])) {
+				return schema.Enum[0]
+			}
+		}
+		return g.formatExample(schema.Enum[0])
 	}
 
 	switch schema.Type {
@@ -425,16 +1162,28 @@ func (g *Generator) getTypeExample(schema *parser.Schema) string {
 }
 
 func (g *Generator) formatExample(example any) string {
-	switch v := example.(type) {
-	case string:
-		return fmt.Sprintf("\"%s\"", v)
-	case float64, float32, int, int64, int32:
-		return fmt.Sprintf("%v", v)
-	case bool:
-		return fmt.Sprintf("%v", v)
-	default:
-		return fmt.Sprintf("%v", v)
+	// Сериализуем в JSON, чтобы строки с кавычками, объекты и массивы давали валидный JSON
+	data, err := json.Marshal(example)
+	if err != nil {
+		return fmt.Sprintf("%v", example)
 	}
+	return string(data)
+}
+
+// prettyJSON единообразно форматирует JSON с отступом в два пробела.
+// Если текст не является валидным JSON, он возвращается без изменений
+func prettyJSON(text string) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(text), "", "  "); err != nil {
+		return text
+	}
+	return buf.String()
+}
+
+// shellQuote заключает строку в одинарные кавычки для POSIX shell.
+// Апостроф внутри закрывает кавычку, экранируется как \' и кавычка открывается снова
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
 }
 
 func (g *Generator) generateFieldsTable(schema *parser.Schema, prefix string) string {
@@ -452,6 +1201,12 @@ func (g *Generator) generateFieldsTable(schema *parser.Schema, prefix string) st
 	}
 	sort.Strings(props)
 
+	hidden := 0
+	if limit := g.cfg.MaxPropertiesPerObject; limit > 0 && len(props) > limit {
+		hidden = len(props) - limit
+		props = props[:limit]
+	}
+
 	for _, name := range props {
 		prop := schema.Properties[name]
 		fieldName := name
@@ -459,122 +1214,66 @@ func (g *Generator) generateFieldsTable(schema *parser.Schema, prefix string) st
 			fieldName = prefix + "." + name
 		}
 
-		typeStr := prop.Type
-		if prop.Format != "" {
-			typeStr += " (" + prop.Format + ")"
-		}
-		if prop.Type == "array" && prop.Items != nil {
-			typeStr = "array[" + prop.Items.Type + "]"
-		}
+		typeStr := fieldType(prop)
 
-		desc := prop.Description
-		if len(prop.Enum) > 0 {
+		desc := g.inlineDescription(prop.Description)
+		if len(prop.Enum) > 0 && len(prop.EnumDescriptions) == 0 {
 			desc += " Values: `" + strings.Join(prop.Enum, "`, `") + "`"
 		}
+		if prop.Default != nil {
+			desc += " Default: " + formatDefault(prop.Default)
+		}
 
 		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", fieldName, typeStr, desc))
 	}
+	if hidden > 0 {
+		sb.WriteString("| … | | " + g.truncationNote(schema, hidden, true) + " |\n")
+	}
 
 	sb.WriteString("\n")
+	for _, name := range props {
+		fieldName := name
+		if prefix != "" {
+			fieldName = prefix + "." + name
+		}
+		prop := schema.Properties[name]
+		sb.WriteString(g.enumTable(fieldName, prop.Enum, prop.EnumDescriptions))
+	}
 	return sb.String()
 }
 
-func sanitizeFilename(name string) string {
-	// Заменяем пробелы и спецсимволы на дефисы
-	name = strings.ToLower(name)
-	name = strings.ReplaceAll(name, " ", "-")
-	name = strings.ReplaceAll(name, "/", "-")
-	return name
-}
-
-func (g *Generator) generateCurlExample(ep parser.Endpoint) string {
-	var sb strings.Builder
-
-	baseURL := g.cfg.BaseURL
-	if baseURL == "" {
-		baseURL = g.api.BaseURL
-	}
-	if baseURL == "" || strings.HasPrefix(baseURL, "/") {
-		baseURL = "https://api.example.com" + baseURL
+// formatDefault форматирует значение по умолчанию для ячейки таблицы: строки как есть,
+// остальное — в JSON. Агент по нему видит, что подразумевает пропущенное поле
+func formatDefault(value any) string {
+	if value == nil {
+		return ""
 	}
-
-	// Убираем trailing slash
-	baseURL = strings.TrimSuffix(baseURL, "/")
-
-	// Формируем путь с примерами параметров
-	path := ep.Path
-	for _, p := range ep.Parameters {
-		if p.In == "path" {
-			var example string
-			if p.Example != nil {
-				example = fmt.Sprintf("%v", p.Example)
-			} else if p.Type == "integer" {
-				example = "1"
-			} else {
-				example = "example"
-			}
-			path = strings.ReplaceAll(path, "{"+p.Name+"}", example)
-		}
-	}
-
-	// Query параметры
-	var queryParams []string
-	for _, p := range ep.Parameters {
-		if p.In == "query" {
-			example := ""
-			if p.Example != nil {
-				example = fmt.Sprintf("%v", p.Example)
-			} else if len(p.Enum) > 0 {
-				example = p.Enum[0]
-			} else if p.Type == "integer" || p.Type == "number" {
-				example = "1"
-			} else if p.Type == "boolean" {
-				example = "true"
-			} else {
-				example = "value"
-			}
-			queryParams = append(queryParams, p.Name+"="+example)
+	text, ok := value.(string)
+	if !ok || text == "" {
+		data, err := json.Marshal(value)
+		if err != nil {
+			data = []byte(fmt.Sprint(value))
 		}
+		text = string(data)
 	}
+	return "`" + inlineText(text) + "`"
+}
 
-	url := baseURL + path
-	if len(queryParams) > 0 {
-		url += "?" + strings.Join(queryParams, "&")
-	}
-
-	sb.WriteString("```bash\n")
-	sb.WriteString(fmt.Sprintf("curl -X %s \"%s\"", ep.Method, url))
-
-	// Headers
-	sb.WriteString(" \\\n  -H \"Content-Type: application/json\"")
-
-	// Auth header (если есть security schemes)
-	if len(g.api.SecuritySchemes) > 0 {
-		for _, scheme := range g.api.SecuritySchemes {
-			if scheme.Type == "apiKey" && scheme.In == "header" {
-				sb.WriteString(fmt.Sprintf(" \\\n  -H \"%s: YOUR_API_KEY\"", scheme.ParamName))
-				break
-			} else if scheme.Type == "http" && scheme.Scheme == "bearer" {
-				sb.WriteString(" \\\n  -H \"Authorization: Bearer YOUR_TOKEN\"")
-				break
-			}
-		}
+// enumTable расшифровывает значения enum таблицей значение → смысл, если спецификация описывает
+// их через x-enum-descriptions или x-enumNames: по голому списку агент не поймёт, что вместо 1
+// нужно отправить ACTIVE. Без описаний возвращает пустую строку
+func (g *Generator) enumTable(name string, values []string, meanings map[string]string) string {
+	if len(meanings) == 0 {
+		return ""
 	}
-
-	// Request body
-	if ep.RequestBody != nil && (ep.Method == "POST" || ep.Method == "PUT" || ep.Method == "PATCH") {
-		for _, media := range ep.RequestBody.Content {
-			if media.Schema != nil {
-				body := g.renderJSONSchema(media.Schema, 0, maxNestedDepth)
-				if body != "" {
-					sb.WriteString(" \\\n  -d '" + body + "'")
-				}
-			}
-			break // Берём только первый content type
-		}
+	var sb strings.Builder
+	sb.WriteString(g.heading("Values of") + " `" + name + "`:\n\n")
+	sb.WriteString("| Value | Meaning |\n")
+	sb.WriteString("|-------|---------|\n")
+	for _, value := range values {
+		sb.WriteString("| `" + value + "` | " + g.inlineDescription(meanings[value]) + " |\n")
 	}
-
-	sb.WriteString("\n```\n\n")
+	sb.WriteString("\n")
 	return sb.String()
 }
 
@@ -583,8 +1282,8 @@ func (g *Generator) formatSecurityScheme(scheme parser.SecurityScheme) string {
 
 	sb.WriteString("### " + scheme.Name + "\n\n")
 
-	if scheme.Description != "" {
-		sb.WriteString(scheme.Description + "\n\n")
+	if desc := g.description(scheme.Description); desc != "" {
+		sb.WriteString(desc + "\n\n")
 	}
 
 	switch scheme.Type {
@@ -601,8 +1300,12 @@ func (g *Generator) formatSecurityScheme(scheme parser.SecurityScheme) string {
 		}
 	case "oauth2":
 		sb.WriteString("- **Type**: OAuth 2.0\n")
+		sb.WriteString(formatOAuthFlows(scheme.Flows))
 	case "openIdConnect":
 		sb.WriteString("- **Type**: OpenID Connect\n")
+		if scheme.OpenIDConnectURL != "" {
+			sb.WriteString(fmt.Sprintf("- **Discovery URL**: `%s`\n", scheme.OpenIDConnectURL))
+		}
 	}
 
 	sb.WriteString("\n")
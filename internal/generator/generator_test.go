@@ -1,13 +1,26 @@
 package generator
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"runtime"
+	"runtime/metrics"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mdwit/spec2llms/internal/config"
 	"github.com/mdwit/spec2llms/internal/parser"
+	"github.com/mdwit/spec2llms/internal/textdiff"
 )
 
 func TestGenerate(t *testing.T) {
@@ -34,10 +47,10 @@ func TestGenerate(t *testing.T) {
 				},
 			},
 			{
-				Method:      "POST",
-				Path:        "/users",
-				Summary:     "Create user",
-				Tags:        []string{"users"},
+				Method:  "POST",
+				Path:    "/users",
+				Summary: "Create user",
+				Tags:    []string{"users"},
 				RequestBody: &parser.RequestBody{
 					Description: "User data",
 					Content: map[string]parser.MediaType{
@@ -116,10 +129,10 @@ func TestGenerate(t *testing.T) {
 	if !strings.Contains(content, "X-API-Key") {
 		t.Error("llms.txt missing API key info")
 	}
-	if !strings.Contains(content, "[GET /users](./endpoints/get-users.txt)") {
+	if !strings.Contains(content, "[GET /users](./endpoints/get-users.txt#get-users)") {
 		t.Error("llms.txt missing GET /users link")
 	}
-	if !strings.Contains(content, "[POST /users](./endpoints/post-users.txt)") {
+	if !strings.Contains(content, "[POST /users](./endpoints/post-users.txt#post-users)") {
 		t.Error("llms.txt missing POST /users link")
 	}
 }
@@ -133,6 +146,20 @@ func TestSanitizeFilename(t *testing.T) {
 		{"User Operations", "user-operations"},
 		{"api/v1", "api-v1"},
 		{"UPPERCASE", "uppercase"},
+		{"Пользователи", "polzovateli"},
+		{"Счёт-фактура", "schet-faktura"},
+		{"Orders & Billing", "orders-and-billing"},
+		{"v2:beta", "v2-beta"},
+		{"Crème Brûlée", "creme-brulee"},
+		{"Straße", "strasse"},
+		{"  --Admin//Tools--  ", "admin-tools"},
+		{"v1.2_internal", "v1.2_internal"},
+		{".hidden.", "hidden"},
+		{"a<b>c|d?e*f\\g\"h", "a-b-c-d-e-f-g-h"},
+		{"CON", "con-"},
+		{"API 🚀", "api"},
+		{"日本語", ""},
+		{strings.Repeat("x", 100), strings.Repeat("x", 80)},
 	}
 
 	for _, tt := range tests {
@@ -177,6 +204,148 @@ func TestGenerateCurlExample(t *testing.T) {
 	if !strings.Contains(result, "Authorization: YOUR_API_KEY") {
 		t.Error("Missing auth header")
 	}
+	if strings.Contains(result, "Content-Type") {
+		t.Errorf("request without body should not send Content-Type:\n%s", result)
+	}
+
+	// Content-Type берётся из спецификации, а не подставляется JSON
+	ep = parser.Endpoint{
+		Method: "POST",
+		Path:   "/users",
+		RequestBody: &parser.RequestBody{Content: map[string]parser.MediaType{
+			"application/xml": {Schema: &parser.Schema{Type: "object", Properties: map[string]*parser.Schema{"name": {Type: "string"}}}},
+		}},
+	}
+	if result := gen.generateCurlExample(ep); !strings.Contains(result, `-H "Content-Type: application/xml"`) {
+		t.Errorf("expected the spec's content type:\n%s", result)
+	}
+}
+
+func TestExampleAuthLocations(t *testing.T) {
+	api := &parser.API{
+		BaseURL: "https://api.example.com",
+		SecuritySchemes: []parser.SecurityScheme{
+			{Name: "basic", Type: "http", Scheme: "basic"},
+			{Name: "query_key", Type: "apiKey", In: "query", ParamName: "api_key"},
+			{Name: "session", Type: "apiKey", In: "cookie", ParamName: "session"},
+			{Name: "oauth", Type: "oauth2"},
+			{Name: "oidc", Type: "openIdConnect"},
+		},
+	}
+	gen := New(&config.Config{Examples: []string{config.ExampleCurl, config.ExampleHTTPie, config.ExamplePowerShell}}, api)
+
+	ep := parser.Endpoint{
+		Method:     "GET",
+		Path:       "/users",
+		Parameters: []parser.Parameter{{Name: "limit", In: "query", Type: "integer"}},
+		Security:   []parser.SecurityRequirement{{"query_key": nil, "session": nil}},
+	}
+	result := gen.generateExamples(ep)
+	for _, expected := range []string{
+		`curl -X GET "https://api.example.com/users?limit=1&api_key=YOUR_API_KEY"`,
+		`-b "session=YOUR_API_KEY"`,
+		"Cookie:session=YOUR_API_KEY\n",
+		"'Cookie' = 'session=YOUR_API_KEY'",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("expected %q in:\n%s", expected, result)
+		}
+	}
+	if strings.Contains(result, "YOUR_USERNAME") {
+		t.Errorf("basic auth is not required by the operation:\n%s", result)
+	}
+
+	// Без security у операции используется первая поддерживаемая схема API
+	ep.Security = nil
+	result = gen.generateExamples(ep)
+	for _, expected := range []string{
+		`-u "YOUR_USERNAME:YOUR_PASSWORD"`,
+		"http -a YOUR_USERNAME:YOUR_PASSWORD GET",
+		"-Authentication Basic -Credential (Get-Credential)",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("expected %q in:\n%s", expected, result)
+		}
+	}
+
+	// Токен OAuth 2.0 и OpenID Connect передаётся как Bearer
+	for _, name := range []string{"oauth", "oidc"} {
+		ep.Security = []parser.SecurityRequirement{{name: {"read"}}}
+		result = gen.generateExamples(ep)
+		if !strings.Contains(result, `-H "Authorization: Bearer YOUR_TOKEN"`) {
+			t.Errorf("%s: expected bearer token in:\n%s", name, result)
+		}
+	}
+}
+
+func TestFormRequestBodies(t *testing.T) {
+	api := &parser.API{BaseURL: "https://api.example.com"}
+	gen := New(&config.Config{Examples: []string{config.ExampleCurl, config.ExampleHTTPie, config.ExamplePowerShell}}, api)
+
+	upload := parser.Endpoint{
+		Method: "POST",
+		Path:   "/avatars",
+		RequestBody: &parser.RequestBody{
+			Content: map[string]parser.MediaType{
+				"multipart/form-data": {Schema: &parser.Schema{
+					Type: "object",
+					Properties: map[string]*parser.Schema{
+						"file":    {Type: "string", Format: "binary", Description: "Image to upload"},
+						"caption": {Type: "string", Example: "My avatar"},
+					},
+					Required: []string{"file"},
+				}},
+			},
+		},
+	}
+	result := gen.generateExamples(upload)
+	for _, expected := range []string{
+		"-F 'caption=My avatar'",
+		"-F 'file=@/path/to/file'",
+		"http --multipart POST https://api.example.com/avatars 'caption=My avatar' file@/path/to/file",
+		"    'file' = Get-Item '/path/to/file'",
+		"-Form $form",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("expected %q in:\n%s", expected, result)
+		}
+	}
+	if strings.Contains(result, "Content-Type: multipart/form-data") || strings.Contains(result, "-d ") {
+		t.Errorf("multipart example should let curl set the boundary and skip the JSON body:\n%s", result)
+	}
+
+	section := gen.generateEndpoint(upload)
+	if !strings.Contains(section, "| file | file | Image to upload |") || strings.Contains(section, "```json") {
+		t.Errorf("expected file field documented without a JSON example:\n%s", section)
+	}
+
+	login := parser.Endpoint{
+		Method: "POST",
+		Path:   "/token",
+		RequestBody: &parser.RequestBody{
+			Content: map[string]parser.MediaType{
+				"application/x-www-form-urlencoded": {Schema: &parser.Schema{
+					Type: "object",
+					Properties: map[string]*parser.Schema{
+						"grant_type": {Type: "string", Enum: []string{"client_credentials"}},
+						"scope":      {Type: "string", Example: "read write"},
+					},
+				}},
+			},
+		},
+	}
+	result = gen.generateExamples(login)
+	for _, expected := range []string{
+		`-H "Content-Type: application/x-www-form-urlencoded"`,
+		"--data-urlencode 'grant_type=client_credentials'",
+		"--data-urlencode 'scope=read write'",
+		"http --form POST https://api.example.com/token grant_type=client_credentials 'scope=read write'",
+		"-ContentType 'application/x-www-form-urlencoded' -Body $body",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("expected %q in:\n%s", expected, result)
+		}
+	}
 }
 
 func TestGenerateSchemaDoc(t *testing.T) {
@@ -205,3 +374,3339 @@ func TestGenerateSchemaDoc(t *testing.T) {
 		t.Error("Missing fields table")
 	}
 }
+
+func TestGenerateServers(t *testing.T) {
+	api := &parser.API{
+		Title:   "Test API",
+		BaseURL: "https://{region}.api.test.com",
+		Servers: []parser.Server{
+			{
+				URL:         "https://{region}.api.test.com",
+				Description: "Production",
+				Variables: map[string]parser.ServerVariable{
+					"region": {Default: "eu"},
+				},
+			},
+			{URL: "https://sandbox.api.test.com", Description: "Sandbox"},
+		},
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users", Summary: "List users"},
+		},
+	}
+
+	cfg := &config.Config{Output: t.TempDir(), Server: "sandbox"}
+	gen := New(cfg, api)
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(cfg.Output, "llms.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read llms.txt: %v", err)
+	}
+	content := string(index)
+
+	if !strings.Contains(content, "## Servers") {
+		t.Error("llms.txt missing servers section")
+	}
+	if !strings.Contains(content, "`https://eu.api.test.com` — Production") {
+		t.Error("llms.txt missing production server with substituted variables")
+	}
+	if !strings.Contains(content, "`https://sandbox.api.test.com` — Sandbox (used in examples)") {
+		t.Error("llms.txt missing selected sandbox server")
+	}
+
+	curl := gen.generateCurlExample(api.Endpoints[0])
+	if !strings.Contains(curl, "https://sandbox.api.test.com/users") {
+		t.Errorf("curl example should use selected server, got:\n%s", curl)
+	}
+
+	cfg.Server = "staging"
+	if err := New(cfg, api).Generate(); err == nil {
+		t.Error("expected error for unknown server")
+	}
+}
+
+func TestResponseExamplesDir(t *testing.T) {
+	examplesDir := t.TempDir()
+	example := `{"id": 42, "name": "Fixture User"}`
+	if err := os.WriteFile(filepath.Join(examplesDir, "getUser.200.json"), []byte(example+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write example: %v", err)
+	}
+
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{
+				Method:      "GET",
+				Path:        "/users/{id}",
+				OperationID: "getUser",
+				Responses: map[string]parser.Response{
+					"200": {
+						Description: "Success",
+						Content: map[string]parser.MediaType{
+							"application/json": {
+								Schema: &parser.Schema{
+									Type: "object",
+									Properties: map[string]*parser.Schema{
+										"id":   {Type: "integer"},
+										"name": {Type: "string"},
+									},
+								},
+							},
+						},
+					},
+					"404": {Description: "Not found"},
+				},
+			},
+		},
+	}
+
+	cfg := &config.Config{Output: t.TempDir(), ResponseExamplesDir: examplesDir}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(cfg.Output, "endpoints", "get-users-id.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read endpoint file: %v", err)
+	}
+	content := string(data)
+
+	if !strings.Contains(content, "```json\n"+example+"\n```") {
+		t.Errorf("endpoint file missing verbatim example, got:\n%s", content)
+	}
+	if strings.Contains(content, `"name": "string"`) {
+		t.Error("synthesized example should be replaced by the fixture")
+	}
+	if !strings.Contains(content, "| Field | Type | Description |") {
+		t.Error("fields table should still be rendered")
+	}
+}
+
+func TestSharedFragments(t *testing.T) {
+	boilerplate := strings.Repeat("All requests must be authenticated with a valid API key. ", 5)
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users", Description: "List users.\n\n" + boilerplate},
+			{Method: "GET", Path: "/orders", Description: "List orders.\n\n" + boilerplate},
+			{Method: "GET", Path: "/health", Description: "Health check."},
+		},
+	}
+
+	cfg := &config.Config{Output: t.TempDir(), SharedFragments: true}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(cfg.Output, "llms.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read llms.txt: %v", err)
+	}
+	if !strings.Contains(string(index), "### Shared note 1\n\n"+strings.TrimSpace(boilerplate)) {
+		t.Error("llms.txt missing shared note")
+	}
+
+	users, err := os.ReadFile(filepath.Join(cfg.Output, "endpoints", "get-users.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read endpoint file: %v", err)
+	}
+	if strings.Contains(string(users), "valid API key") {
+		t.Error("shared paragraph should be replaced in endpoint file")
+	}
+	if !strings.Contains(string(users), "List users.\n\n_See [Shared note 1](../llms.txt#shared-note-1)._") {
+		t.Errorf("endpoint file missing shared note reference, got:\n%s", users)
+	}
+}
+
+func TestServerVariablesSubstitution(t *testing.T) {
+	api := &parser.API{
+		Title:   "Test API",
+		BaseURL: "https://{region}.api.test.com/{version}",
+		Servers: []parser.Server{
+			{
+				URL: "https://{region}.api.test.com/{version}",
+				Variables: map[string]parser.ServerVariable{
+					"region":  {Enum: []string{"eu", "us"}, Description: "Data center region"},
+					"version": {Default: "v2"},
+				},
+			},
+		},
+	}
+	gen := New(&config.Config{}, api)
+
+	index := gen.generateIndex(nil)
+	if !strings.Contains(index, "Base URL: `https://eu.api.test.com/v2`") {
+		t.Errorf("base URL should be substituted, got:\n%s", index)
+	}
+	if !strings.Contains(index, "- `{region}` — Data center region. Default: `eu`. Values: `eu`, `us`") {
+		t.Errorf("llms.txt missing region variable documentation, got:\n%s", index)
+	}
+}
+
+func TestSectionMarkers(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users/{id}", OperationID: "getUser", Summary: "Get user"},
+			{Method: "DELETE", Path: "/users/{id}", Summary: "Delete user"},
+		},
+		SecuritySchemes: []parser.SecurityScheme{
+			{Name: "bearer", Type: "http", Scheme: "bearer"},
+		},
+	}
+	gen := New(&config.Config{SectionMarkers: true}, api)
+
+	file := gen.generateSingleEndpointFile(api.Endpoints[0])
+	if !strings.HasPrefix(file, "<!-- spec2llms:begin operation=getUser -->\n<a id=\"getuser\"></a>\n\n## GET /users/{id}") {
+		t.Errorf("endpoint file missing begin marker, got:\n%s", file)
+	}
+	if !strings.Contains(file, "<!-- spec2llms:end operation=getUser -->") {
+		t.Error("endpoint file missing end marker")
+	}
+
+	file = gen.generateSingleEndpointFile(api.Endpoints[1])
+	if !strings.Contains(file, "<!-- spec2llms:begin operation=DELETE /users/{id} -->") {
+		t.Error("operation without operationId should use METHOD /path as id")
+	}
+
+	index := gen.generateIndex(api.Endpoints)
+	for _, marker := range []string{
+		"<!-- spec2llms:begin section=authentication -->",
+		"<!-- spec2llms:end section=authentication -->",
+		"<!-- spec2llms:begin section=endpoints -->",
+	} {
+		if !strings.Contains(index, marker) {
+			t.Errorf("llms.txt missing marker %q", marker)
+		}
+	}
+}
+
+func TestGroupByNone(t *testing.T) {
+	api := &parser.API{
+		Title: "Small API",
+		Endpoints: []parser.Endpoint{
+			{Method: "POST", Path: "/users", Summary: "Create user"},
+			{Method: "GET", Path: "/health", Summary: "Health check"},
+			{Method: "GET", Path: "/users", Summary: "List users"},
+		},
+	}
+
+	cfg := &config.Config{Output: t.TempDir(), GroupBy: config.GroupByNone}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(cfg.Output, "endpoints")); !os.IsNotExist(err) {
+		t.Error("endpoints directory should not be created in flat mode")
+	}
+
+	data, err := os.ReadFile(filepath.Join(cfg.Output, "llms.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read llms.txt: %v", err)
+	}
+	content := string(data)
+
+	health := strings.Index(content, "## GET /health - Health check")
+	list := strings.Index(content, "## GET /users - List users")
+	create := strings.Index(content, "## POST /users - Create user")
+	if health < 0 || list < 0 || create < 0 {
+		t.Fatalf("llms.txt should contain every operation, got:\n%s", content)
+	}
+	if health > list || list > create {
+		t.Error("operations should be ordered alphabetically by path")
+	}
+	if strings.Contains(content, "./endpoints/") {
+		t.Error("flat llms.txt should not link to endpoint files")
+	}
+}
+
+func TestGroupByXGroup(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Tags:  []parser.Tag{{Name: "internal-sdk", Description: "Tag used by SDK tooling"}},
+		Endpoints: []parser.Endpoint{
+			{
+				Method: "GET", Path: "/invoices", Summary: "List invoices",
+				Tags:       []string{"internal-sdk"},
+				Extensions: map[string]any{"x-group": "Billing"},
+			},
+			{Method: "GET", Path: "/users", Summary: "List users", Tags: []string{"internal-sdk"}},
+		},
+	}
+	gen := New(&config.Config{GroupBy: config.GroupByXGroup}, api)
+
+	index := gen.generateIndex(gen.sortEndpoints())
+	if !strings.Contains(index, "### Billing\n\n- [GET /invoices]") {
+		t.Errorf("llms.txt missing x-group section, got:\n%s", index)
+	}
+	if !strings.Contains(index, "### internal-sdk\n\nTag used by SDK tooling\n\n- [GET /users]") {
+		t.Errorf("operation without x-group should fall back to its tag, got:\n%s", index)
+	}
+
+	file := gen.generateSingleEndpointFile(api.Endpoints[0])
+	if !strings.HasPrefix(file, "# Billing\n\n") {
+		t.Errorf("endpoint file should be titled by x-group, got:\n%s", file)
+	}
+}
+
+func TestSortEndpoints(t *testing.T) {
+	api := &parser.API{
+		Endpoints: []parser.Endpoint{
+			{Method: "DELETE", Path: "/b", OperationID: "alpha"},
+			{Method: "GET", Path: "/c", OperationID: "bravo"},
+			{Method: "POST", Path: "/a"},
+		},
+	}
+
+	tests := []struct {
+		sort     string
+		expected []string
+	}{
+		{"", []string{"POST /a", "DELETE /b", "GET /c"}},
+		{config.SortMethod, []string{"GET /c", "POST /a", "DELETE /b"}},
+		{config.SortOperationID, []string{"DELETE /b", "GET /c", "POST /a"}},
+		{config.SortSpecOrder, []string{"DELETE /b", "GET /c", "POST /a"}},
+	}
+
+	for _, tt := range tests {
+		gen := New(&config.Config{Sort: tt.sort}, api)
+		var got []string
+		for _, ep := range gen.sortEndpoints() {
+			got = append(got, ep.Method+" "+ep.Path)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("sort %q: got %v, expected %v", tt.sort, got, tt.expected)
+		}
+	}
+}
+
+func TestGroupBaseURLs(t *testing.T) {
+	api := &parser.API{
+		Title:   "Test API",
+		BaseURL: "https://api.example.com",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/invoices", Summary: "List invoices", Tags: []string{"billing"}},
+			{Method: "GET", Path: "/users", Summary: "List users", Tags: []string{"users"}},
+		},
+	}
+	cfg := &config.Config{GroupBaseURLs: map[string]string{"billing": "https://billing.example.com"}}
+	gen := New(cfg, api)
+
+	if curl := gen.generateCurlExample(api.Endpoints[0]); !strings.Contains(curl, "https://billing.example.com/invoices") {
+		t.Errorf("billing curl should use group base URL, got:\n%s", curl)
+	}
+	if curl := gen.generateCurlExample(api.Endpoints[1]); !strings.Contains(curl, "https://api.example.com/users") {
+		t.Errorf("users curl should use default base URL, got:\n%s", curl)
+	}
+
+	file := gen.generateSingleEndpointFile(api.Endpoints[0])
+	if !strings.Contains(file, "Base URL: `https://billing.example.com`") {
+		t.Error("billing endpoint file missing base URL note")
+	}
+
+	index := gen.generateIndex(gen.sortEndpoints())
+	if !strings.Contains(index, "### billing\n\nBase URL: `https://billing.example.com`") {
+		t.Errorf("llms.txt missing group base URL, got:\n%s", index)
+	}
+}
+
+func TestExternalDocs(t *testing.T) {
+	api := &parser.API{
+		Title:        "Test API",
+		ExternalDocs: &parser.ExternalDocs{URL: "https://docs.example.com"},
+		Tags: []parser.Tag{
+			{Name: "users", ExternalDocs: &parser.ExternalDocs{Description: "User guide", URL: "https://docs.example.com/users"}},
+		},
+		Endpoints: []parser.Endpoint{
+			{
+				Method: "GET", Path: "/users", Tags: []string{"users"},
+				ExternalDocs: &parser.ExternalDocs{URL: "https://docs.example.com/users/list"},
+			},
+		},
+	}
+	gen := New(&config.Config{}, api)
+
+	index := gen.generateIndex(api.Endpoints)
+	if !strings.Contains(index, "More info: <https://docs.example.com>") {
+		t.Error("llms.txt missing root external docs")
+	}
+	if !strings.Contains(index, "More info: [User guide](https://docs.example.com/users)") {
+		t.Error("llms.txt missing tag external docs")
+	}
+
+	file := gen.generateSingleEndpointFile(api.Endpoints[0])
+	if !strings.Contains(file, "More info: <https://docs.example.com/users/list>") {
+		t.Error("endpoint file missing operation external docs")
+	}
+}
+
+func TestEndpointSecurity(t *testing.T) {
+	api := &parser.API{
+		SecuritySchemes: []parser.SecurityScheme{
+			{Name: "petstore_auth", Type: "oauth2"},
+			{Name: "api_key", Type: "apiKey", In: "header", ParamName: "X-API-Key"},
+		},
+	}
+	gen := New(&config.Config{}, api)
+
+	ep := parser.Endpoint{
+		Method: "POST",
+		Path:   "/pets",
+		Security: []parser.SecurityRequirement{
+			{"petstore_auth": {"write:pets", "read:pets"}},
+			{"api_key": nil},
+		},
+	}
+
+	result := gen.generateEndpoint(ep)
+	if !strings.Contains(result, "- [petstore_auth](../llms.txt#petstore_auth) (OAuth 2.0, scopes: `write:pets`, `read:pets`)") {
+		t.Errorf("missing oauth2 requirement, got:\n%s", result)
+	}
+	if !strings.Contains(result, "- [api_key](../llms.txt#api_key) (API key in header `X-API-Key`)") {
+		t.Errorf("missing api key requirement, got:\n%s", result)
+	}
+}
+
+func TestCurlBodyQuoting(t *testing.T) {
+	gen := New(&config.Config{}, &parser.API{BaseURL: "https://api.example.com"})
+
+	ep := parser.Endpoint{
+		Method: "POST",
+		Path:   "/notes",
+		RequestBody: &parser.RequestBody{
+			Content: map[string]parser.MediaType{
+				"application/json": {
+					Schema: &parser.Schema{
+						Type: "object",
+						Properties: map[string]*parser.Schema{
+							"text": {Type: "string", Example: `it's "quoted" $HOME`},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result := gen.generateCurlExample(ep)
+	expected := "-d '{\n  \"text\": \"it'\\''s \\\"quoted\\\" $HOME\"\n}'"
+	if !strings.Contains(result, expected) {
+		t.Errorf("body should be valid JSON safely quoted for shell, got:\n%s", result)
+	}
+
+	doc := gen.generateSchemaDoc(ep.RequestBody.Content["application/json"].Schema, 0)
+	if !strings.Contains(doc, "}\n```") {
+		t.Errorf("JSON block should end with a newline before the closing fence, got:\n%s", doc)
+	}
+}
+
+func TestRateLimits(t *testing.T) {
+	api := &parser.API{
+		Title:      "Test API",
+		Extensions: map[string]any{"x-ratelimit": map[string]any{"requests": 100, "period": "1m"}},
+		Endpoints: []parser.Endpoint{
+			{
+				Method: "GET", Path: "/search",
+				Extensions: map[string]any{"x-ratelimit-limit": 10},
+				Responses: map[string]parser.Response{
+					"200": {Description: "OK", Headers: map[string]parser.Header{
+						"X-RateLimit-Remaining": {Description: "Requests left in the window"},
+					}},
+					"429": {Description: "Too many requests", Headers: map[string]parser.Header{
+						"Retry-After": {Description: "Seconds to wait"},
+					}},
+				},
+			},
+			{Method: "GET", Path: "/users"},
+		},
+	}
+	gen := New(&config.Config{}, api)
+
+	index := gen.generateIndex(api.Endpoints)
+	for _, expected := range []string{
+		"## Rate Limits",
+		"- `x-ratelimit`: period=1m, requests=100",
+		"- `GET /search`: `x-ratelimit-limit`: 10",
+		"Endpoints that may respond with `429 Too Many Requests`: `GET /search`.",
+		"- `X-RateLimit-Remaining` — Requests left in the window",
+		"wait for the duration given in the `Retry-After` header",
+	} {
+		if !strings.Contains(index, expected) {
+			t.Errorf("llms.txt missing %q, got:\n%s", expected, index)
+		}
+	}
+
+	if strings.Contains(New(&config.Config{}, &parser.API{}).generateIndex(nil), "## Rate Limits") {
+		t.Error("rate limits section should be omitted when nothing is declared")
+	}
+}
+
+func TestSharedErrors(t *testing.T) {
+	errorSchema := &parser.Schema{
+		Type: "object",
+		Ref:  "#/components/schemas/Error",
+		Properties: map[string]*parser.Schema{
+			"code":    {Type: "integer"},
+			"message": {Type: "string"},
+		},
+	}
+	errorResponse := parser.Response{
+		Description: "Error",
+		Content:     map[string]parser.MediaType{"application/json": {Schema: errorSchema}},
+	}
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users", Responses: map[string]parser.Response{"500": errorResponse}},
+			{Method: "GET", Path: "/users/{id}", Responses: map[string]parser.Response{"404": errorResponse, "500": errorResponse}},
+		},
+	}
+
+	cfg := &config.Config{Output: t.TempDir()}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(cfg.Output, "llms.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read llms.txt: %v", err)
+	}
+	if !strings.Contains(string(index), "## Errors") || !strings.Contains(string(index), "### Error\n\nStatus codes: `404`, `500`") {
+		t.Errorf("llms.txt missing errors section, got:\n%s", index)
+	}
+
+	file, err := os.ReadFile(filepath.Join(cfg.Output, "endpoints", "get-users-id.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read endpoint file: %v", err)
+	}
+	if !strings.Contains(string(file), "Error body: [`Error`](../llms.txt#error) (see Errors)") {
+		t.Errorf("endpoint file should reference shared error, got:\n%s", file)
+	}
+	if strings.Contains(string(file), "\"message\"") {
+		t.Error("shared error schema should not be repeated in endpoint file")
+	}
+
+	cfg = &config.Config{Output: t.TempDir(), Language: config.LanguageRU}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	file, _ = os.ReadFile(filepath.Join(cfg.Output, "endpoints", "get-users-id.txt"))
+	if !strings.Contains(string(file), "Тело ошибки: [`Error`](../llms.txt#error) (см. Ошибки)") {
+		t.Errorf("shared error reference should be translated, got:\n%s", file)
+	}
+}
+
+func TestPowerShellExample(t *testing.T) {
+	api := &parser.API{
+		BaseURL: "https://api.example.com",
+		SecuritySchemes: []parser.SecurityScheme{
+			{Name: "apiKey", Type: "apiKey", In: "header", ParamName: "X-API-Key"},
+		},
+	}
+	cfg := &config.Config{Examples: []string{config.ExampleCurl, config.ExamplePowerShell}}
+	gen := New(cfg, api)
+
+	ep := parser.Endpoint{
+		Method: "POST",
+		Path:   "/users",
+		RequestBody: &parser.RequestBody{
+			Content: map[string]parser.MediaType{
+				"application/json": {Schema: &parser.Schema{
+					Type:       "object",
+					Properties: map[string]*parser.Schema{"name": {Type: "string"}},
+				}},
+			},
+		},
+	}
+
+	result := gen.generateExamples(ep)
+	if !strings.Contains(result, "```bash\ncurl -X POST") {
+		t.Error("missing curl example")
+	}
+	for _, expected := range []string{
+		"```powershell\n",
+		"$headers = @{\n    'X-API-Key' = 'YOUR_API_KEY'\n}",
+		"$body = @'\n{\n  \"name\": \"string\"\n}\n'@",
+		"Invoke-RestMethod -Method Post -Uri 'https://api.example.com/users' -Headers $headers -ContentType 'application/json' -Body $body",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("powershell example missing %q, got:\n%s", expected, result)
+		}
+	}
+}
+
+func TestHTTPieExample(t *testing.T) {
+	api := &parser.API{
+		BaseURL: "https://api.example.com",
+		SecuritySchemes: []parser.SecurityScheme{
+			{Name: "bearer", Type: "http", Scheme: "bearer"},
+		},
+	}
+	gen := New(&config.Config{Examples: []string{config.ExampleHTTPie}}, api)
+
+	ep := parser.Endpoint{
+		Method: "POST",
+		Path:   "/users",
+		RequestBody: &parser.RequestBody{
+			Content: map[string]parser.MediaType{
+				"application/json": {Schema: &parser.Schema{
+					Type: "object",
+					Properties: map[string]*parser.Schema{
+						"name":   {Type: "string", Example: "Joe Doe"},
+						"age":    {Type: "integer"},
+						"active": {Type: "boolean"},
+					},
+				}},
+			},
+		},
+	}
+
+	result := gen.generateExamples(ep)
+	expected := "http POST https://api.example.com/users 'Authorization:Bearer YOUR_TOKEN' active:=true age:=0 'name=Joe Doe'"
+	if !strings.Contains(result, expected) {
+		t.Errorf("httpie example mismatch, expected %q in:\n%s", expected, result)
+	}
+	if strings.Contains(result, "curl") {
+		t.Error("curl example should not be rendered when only httpie is selected")
+	}
+}
+
+func TestSchemaLimits(t *testing.T) {
+	schema := &parser.Schema{
+		Type: "object",
+		Ref:  "#/components/schemas/User",
+		Properties: map[string]*parser.Schema{
+			"a": {Type: "string"},
+			"b": {Type: "string"},
+			"c": {Type: "string"},
+			"d": {Type: "string"},
+			"nested": {Type: "object", Properties: map[string]*parser.Schema{
+				"deep": {Type: "object", Properties: map[string]*parser.Schema{
+					"deeper": {Type: "string"},
+				}},
+			}},
+		},
+	}
+
+	gen := New(&config.Config{MaxPropertiesPerObject: 2}, &parser.API{})
+	doc := gen.generateSchemaDoc(schema, 0)
+	if !strings.Contains(doc, "```jsonc\n{") || !strings.Contains(doc, "  // ... 3 more fields, see schemas/User\n}") {
+		t.Errorf("JSON example should be truncated with a marker in a jsonc block, got:\n%s", doc)
+	}
+	if strings.Contains(doc, "\"c\"") {
+		t.Error("fields beyond the limit should be hidden")
+	}
+	if !strings.Contains(doc, "| … | | ... 3 more fields, see schemas/User |") {
+		t.Errorf("fields table should be truncated with a marker, got:\n%s", doc)
+	}
+
+	gen = New(&config.Config{MaxPropertiesPerObject: 4}, &parser.API{})
+	doc = gen.generateSchemaDoc(schema, 0)
+	if !strings.Contains(doc, "// ... 1 more field, see schemas/User\n") {
+		t.Errorf("a single hidden field should be singular, got:\n%s", doc)
+	}
+
+	// С глоссарием схем отметка в таблице ссылается на схему в нём
+	api := &parser.API{Schemas: map[string]*parser.Schema{"User": schema}}
+	gen = New(&config.Config{MaxPropertiesPerObject: 2, SchemaGlossary: true}, api)
+	doc = gen.generateSchemaDoc(schema, 0)
+	if !strings.Contains(doc, "| … | | ... 3 more fields, see [schemas/User](../schemas.txt#user) |") {
+		t.Errorf("fields table should link to the schema glossary, got:\n%s", doc)
+	}
+
+	// Пример без скрытых полей остаётся валидным JSON
+	gen = New(&config.Config{}, &parser.API{})
+	doc = gen.generateSchemaDoc(schema, 0)
+	example, _, _ := strings.Cut(strings.TrimPrefix(doc, "```json\n"), "\n```")
+	if !strings.HasPrefix(doc, "```json\n") || !json.Valid([]byte(example)) {
+		t.Errorf("untruncated example should be a valid json block, got:\n%s", doc)
+	}
+
+	gen = New(&config.Config{MaxSchemaDepth: 1}, &parser.API{})
+	doc = gen.generateSchemaDoc(schema, 0)
+	if strings.Contains(doc, "deeper") {
+		t.Errorf("objects deeper than maxSchemaDepth should not be expanded, got:\n%s", doc)
+	}
+}
+
+func TestHTMLFormat(t *testing.T) {
+	api := &parser.API{
+		Title: "Pets <API>",
+		Tags:  []parser.Tag{{Name: "pets", Description: "Pet operations"}},
+		Endpoints: []parser.Endpoint{
+			{
+				Method: "GET", Path: "/pets/{id}", Summary: "Get pet", OperationID: "getPet",
+				Tags: []string{"pets"},
+				Responses: map[string]parser.Response{
+					"200": {Description: "OK", Content: map[string]parser.MediaType{
+						"application/json": {Schema: &parser.Schema{
+							Type:       "object",
+							Properties: map[string]*parser.Schema{"name": {Type: "string"}},
+						}},
+					}},
+				},
+			},
+			{Method: "GET", Path: "/health", Summary: "Health check"},
+		},
+	}
+
+	cfg := &config.Config{Output: t.TempDir(), Format: config.FormatHTML}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(cfg.Output, "llms.txt")); !os.IsNotExist(err) {
+		t.Error("llms.txt should not be written in HTML mode")
+	}
+
+	index, err := os.ReadFile(filepath.Join(cfg.Output, "index.html"))
+	if err != nil {
+		t.Fatalf("Failed to read index.html: %v", err)
+	}
+	if !strings.Contains(string(index), "<h1 id=\"pets-api\">Pets &lt;API&gt;</h1>") {
+		t.Errorf("index.html should contain escaped title, got:\n%s", index)
+	}
+	if !strings.Contains(string(index), `<a href="groups/pets.html#getpet">GET /pets/{id}</a>`) {
+		t.Errorf("index.html should link to the group page, got:\n%s", index)
+	}
+	if !strings.Contains(string(index), `<a href="groups/other.html#get-health">GET /health</a>`) {
+		t.Errorf("untagged endpoint should link to the Other page, got:\n%s", index)
+	}
+
+	page, err := os.ReadFile(filepath.Join(cfg.Output, "groups", "pets.html"))
+	if err != nil {
+		t.Fatalf("Failed to read group page: %v", err)
+	}
+	content := string(page)
+	for _, want := range []string{
+		`<section id="getpet">`,
+		`<tr><th>Field</th><th>Type</th><th>Description</th></tr>`,
+		`<code class="language-json">`,
+		`<span class="k">&#34;name&#34;:</span>`,
+		`<span class="f">-X</span>`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("group page missing %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestExamplePlaceholders(t *testing.T) {
+	api := &parser.API{
+		BaseURL:         "https://sandbox.example.com",
+		SecuritySchemes: []parser.SecurityScheme{{Name: "bearer", Type: "http", Scheme: "bearer"}},
+	}
+	cfg := &config.Config{Placeholders: config.Placeholders{
+		Token:  "${API_TOKEN}",
+		Params: map[string]string{"id": "usr_demo", "getOrder.id": "ord_42", "limit": "5"},
+	}}
+	gen := New(cfg, api)
+
+	user := gen.generateCurlExample(parser.Endpoint{
+		Method: "GET", Path: "/users/{id}", OperationID: "getUser",
+		Parameters: []parser.Parameter{
+			{Name: "id", In: "path", Type: "string", Example: "spec-id"},
+			{Name: "limit", In: "query", Type: "integer"},
+		},
+	})
+	if !strings.Contains(user, "https://sandbox.example.com/users/usr_demo?limit=5") {
+		t.Errorf("configured params should replace spec examples, got:\n%s", user)
+	}
+	if !strings.Contains(user, "Authorization: Bearer ${API_TOKEN}") {
+		t.Errorf("configured token should replace YOUR_TOKEN, got:\n%s", user)
+	}
+
+	order := gen.generateCurlExample(parser.Endpoint{
+		Method: "GET", Path: "/orders/{id}", OperationID: "getOrder",
+		Parameters: []parser.Parameter{{Name: "id", In: "path", Type: "string"}},
+	})
+	if !strings.Contains(order, "/orders/ord_42") {
+		t.Errorf("operation-specific param should win over the generic one, got:\n%s", order)
+	}
+}
+
+func TestSandboxQuickstart(t *testing.T) {
+	api := &parser.API{
+		Title:   "Test API",
+		BaseURL: "https://api.example.com",
+		Endpoints: []parser.Endpoint{
+			{Method: "POST", Path: "/users", Summary: "Create user"},
+			{
+				Method: "GET", Path: "/orders/{orderId}", Summary: "Get order",
+				Parameters: []parser.Parameter{{Name: "orderId", In: "path", Required: true, Type: "string"}},
+			},
+			{
+				Method: "GET", Path: "/users/{userId}", Summary: "Get user",
+				Parameters: []parser.Parameter{{Name: "userId", In: "path", Required: true, Type: "string"}},
+			},
+		},
+	}
+	cfg := &config.Config{Sandbox: &config.Sandbox{
+		BaseURL:     "https://sandbox.example.com",
+		Credentials: "Use the API key `demo-key`.",
+		SeedData:    map[string]string{"userId": "usr_demo"},
+	}}
+	gen := New(cfg, api)
+
+	index := gen.generateIndex(gen.sortEndpoints())
+	for _, want := range []string{
+		"## Try it\n\nSandbox: `https://sandbox.example.com`\n\nUse the API key `demo-key`.\n\n",
+		"- `userId`: `usr_demo`\n",
+		"First request — `GET /users/{userId}` (Get user):",
+		"curl -X GET \"https://sandbox.example.com/users/usr_demo\"",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("llms.txt missing %q, got:\n%s", want, index)
+		}
+	}
+	if strings.Index(index, "## Try it") > strings.Index(index, "## Endpoints") {
+		t.Error("Try it section should come before the endpoint list")
+	}
+
+	cfg.Output = t.TempDir()
+	cfg.Sandbox.Operation = "deleteEverything"
+	if err := New(cfg, api).Generate(); err == nil {
+		t.Error("expected error for unknown sandbox operation")
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{
+				Method: "GET", Path: "/users/{id}", OperationID: "getUser",
+				Parameters: []parser.Parameter{{Name: "id", In: "path", Required: true, Type: "string"}},
+				Responses: map[string]parser.Response{
+					"200": {Description: "OK", Content: map[string]parser.MediaType{
+						"application/json": {Schema: &parser.Schema{Type: "object", Ref: "#/components/schemas/User"}},
+					}},
+				},
+			},
+		},
+	}
+
+	cfg := &config.Config{Output: t.TempDir(), Format: config.FormatJSON}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(cfg.Output, "api.json"))
+	if err != nil {
+		t.Fatalf("Failed to read api.json: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.Output, "llms.txt")); !os.IsNotExist(err) {
+		t.Error("llms.txt should not be written in JSON mode")
+	}
+
+	var decoded parser.API
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("api.json is not valid JSON: %v", err)
+	}
+	if decoded.Endpoints[0].OperationID != "getUser" || decoded.Endpoints[0].Parameters[0].Name != "id" {
+		t.Errorf("api.json should round-trip the model, got %+v", decoded.Endpoints[0])
+	}
+	for _, want := range []string{`"operationId": "getUser"`, `"ref": "#/components/schemas/User"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("api.json missing %s, got:\n%s", want, data)
+		}
+	}
+}
+
+func TestLanguageAuto(t *testing.T) {
+	api := &parser.API{
+		Title:       "Кинопоиск API",
+		Description: "Неофициальный API для поиска фильмов",
+		Endpoints: []parser.Endpoint{
+			{
+				Method: "GET", Path: "/movie", Summary: "Поиск фильмов", Description: "Возвращает список фильмов по фильтрам",
+				Parameters: []parser.Parameter{{Name: "limit", In: "query", Type: "integer", Description: "Количество результатов"}},
+			},
+		},
+	}
+
+	gen := New(&config.Config{Language: config.LanguageAuto}, api)
+	index := gen.generateIndex(gen.sortEndpoints())
+	if !strings.Contains(index, "## Эндпоинты\n") {
+		t.Errorf("Russian spec should get Russian headings, got:\n%s", index)
+	}
+	endpoint := gen.generateEndpoint(api.Endpoints[0])
+	if !strings.Contains(endpoint, "### Параметры\n") || !strings.Contains(endpoint, "### Пример\n") {
+		t.Errorf("endpoint headings should be Russian, got:\n%s", endpoint)
+	}
+
+	api.Description = "Unofficial API for searching movies, actors and reviews across the whole catalog"
+	api.Endpoints[0].Description = "Returns a paginated list of movies matching the given filters"
+	gen = New(&config.Config{Language: config.LanguageAuto}, api)
+	if index := gen.generateIndex(gen.sortEndpoints()); !strings.Contains(index, "## Endpoints\n") {
+		t.Errorf("predominantly English spec should keep English headings, got:\n%s", index)
+	}
+}
+
+func TestDestructiveOperations(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "DELETE", Path: "/users/{id}", Summary: "Delete user"},
+			{
+				Method: "POST", Path: "/accounts/{id}/close", Summary: "Close account",
+				Extensions: map[string]any{"x-destructive": "Closes the account and cancels all subscriptions."},
+			},
+			{
+				Method: "DELETE", Path: "/cache", Summary: "Flush cache",
+				Extensions: map[string]any{"x-destructive": false},
+			},
+			{Method: "GET", Path: "/users", Summary: "List users"},
+		},
+	}
+	gen := New(&config.Config{}, api)
+
+	index := gen.generateIndex(gen.sortEndpoints())
+	for _, want := range []string{
+		"## Destructive Operations\n\n",
+		"- [`POST /accounts/{id}/close`](./endpoints/post-accounts-id-close.txt#post-accounts-id-close) — Close account. Effect: Closes the account and cancels all subscriptions.\n",
+		"- [`DELETE /users/{id}`](./endpoints/delete-users-id.txt#delete-users-id) — Delete user\n",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("llms.txt missing %q, got:\n%s", want, index)
+		}
+	}
+	// В плоском режиме операции в том же файле — ссылка на якорь
+	flat := New(&config.Config{GroupBy: config.GroupByNone}, api)
+	if index := flat.generateIndex(flat.sortEndpoints()); !strings.Contains(index, "- [`DELETE /users/{id}`](#delete-users-id) — Delete user\n") {
+		t.Errorf("flat llms.txt should link destructive operations to anchors, got:\n%s", index)
+	}
+	if strings.Contains(index, "`DELETE /cache`") {
+		t.Error("x-destructive: false should exclude DELETE from the list")
+	}
+
+	endpoint := gen.generateEndpoint(api.Endpoints[0])
+	if !strings.Contains(endpoint, "> ⚠️ **Destructive operation.**") {
+		t.Errorf("DELETE endpoint should carry a warning, got:\n%s", endpoint)
+	}
+	if strings.Contains(gen.generateEndpoint(api.Endpoints[3]), "Destructive") {
+		t.Error("GET endpoint should not carry a warning")
+	}
+}
+
+func TestOperationSafety(t *testing.T) {
+	tests := []struct {
+		ep     parser.Endpoint
+		line   string
+		labels []string
+	}{
+		{parser.Endpoint{Method: "GET", Path: "/users"}, "Safety: safe (read-only), idempotent", []string{"safe", "idempotent"}},
+		{parser.Endpoint{Method: "PUT", Path: "/users/{id}"}, "Safety: modifies data, idempotent", []string{"idempotent"}},
+		{parser.Endpoint{Method: "DELETE", Path: "/users/{id}"}, "Safety: modifies data, idempotent, destructive", []string{"idempotent", "destructive"}},
+		{parser.Endpoint{Method: "POST", Path: "/orders"}, "Safety: modifies data, not idempotent, do not retry blindly", nil},
+		{
+			parser.Endpoint{Method: "POST", Path: "/payments", Parameters: []parser.Parameter{{Name: "Idempotency-Key", In: "header"}}},
+			"Safety: modifies data, retry safely with the same `Idempotency-Key` header", []string{"idempotency-key"},
+		},
+		{
+			parser.Endpoint{Method: "POST", Path: "/search", Extensions: map[string]any{"x-safe": true}},
+			"Safety: safe (read-only), idempotent", []string{"safe", "idempotent"},
+		},
+		{
+			parser.Endpoint{Method: "PUT", Path: "/counters/{id}/increment", Extensions: map[string]any{"x-idempotent": false}},
+			"Safety: modifies data, not idempotent, do not retry blindly", nil,
+		},
+	}
+	gen := New(&config.Config{}, &parser.API{})
+	for _, tt := range tests {
+		if section := gen.generateEndpoint(tt.ep); !strings.Contains(section, tt.line+"\n\n") {
+			t.Errorf("%s %s: expected %q in:\n%s", tt.ep.Method, tt.ep.Path, tt.line, section)
+		}
+		if labels := safetyInfo(tt.ep).Labels(); !slices.Equal(labels, tt.labels) {
+			t.Errorf("%s %s: labels = %v, expected %v", tt.ep.Method, tt.ep.Path, labels, tt.labels)
+		}
+	}
+}
+
+func TestStabilityBadges(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Tags: []parser.Tag{
+			{Name: "search", Extensions: map[string]any{"x-maturity": "beta"}},
+			{Name: "users"},
+		},
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/search", Summary: "Search", Tags: []string{"search"}},
+			{
+				Method: "GET", Path: "/search/v2", Summary: "Search v2", Tags: []string{"search"},
+				Extensions: map[string]any{"x-stability": "experimental"},
+			},
+			{
+				Method: "GET", Path: "/users", Summary: "List users", Tags: []string{"users"},
+				Extensions: map[string]any{"x-stability": "stable"},
+			},
+		},
+	}
+	gen := New(&config.Config{}, api)
+
+	index := gen.generateIndex(gen.sortEndpoints())
+	for _, want := range []string{
+		"## Stability\n\n",
+		"- `GA` — stable, safe to rely on\n- `beta` — feature-complete, may still change\n- `experimental`",
+		"### search `beta`\n",
+		"— Search `beta`\n",
+		"— Search v2 `experimental`\n",
+		"— List users `GA`\n",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("llms.txt missing %q, got:\n%s", want, index)
+		}
+	}
+
+	if endpoint := gen.generateEndpoint(api.Endpoints[0]); !strings.HasPrefix(endpoint, "<a id=\"get-search\"></a>\n\n## GET /search - Search `beta`\n") {
+		t.Errorf("operation should inherit tag badge, got:\n%s", endpoint)
+	}
+}
+
+// csvRenderer — тестовый рендерер: по строке CSV на эндпоинт и индекс групп
+type csvRenderer struct{}
+
+func (csvRenderer) RenderIndex(api *parser.API, groups []Group) ([]File, error) {
+	var names []string
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	return []File{{Path: "index.csv", Content: strings.Join(names, ",")}}, nil
+}
+
+func (csvRenderer) RenderGroup(api *parser.API, group Group) ([]File, error) {
+	return nil, nil
+}
+
+func (csvRenderer) RenderEndpoint(api *parser.API, ep parser.Endpoint) ([]File, error) {
+	return []File{{Path: "ops/" + strings.ToLower(ep.Method) + ".csv", Content: ep.Method + "," + ep.Path}}, nil
+}
+
+func TestCustomRenderer(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users", Tags: []string{"users"}},
+			{Method: "POST", Path: "/orders", Tags: []string{"orders"}},
+		},
+	}
+
+	RegisterRenderer("csv", csvRenderer{})
+	cfg := &config.Config{Output: t.TempDir(), Renderer: "csv"}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	index, _ := os.ReadFile(filepath.Join(cfg.Output, "index.csv"))
+	if string(index) != "orders,users" {
+		t.Errorf("unexpected index: %q", index)
+	}
+	if op, _ := os.ReadFile(filepath.Join(cfg.Output, "ops", "post.csv")); string(op) != "POST,/orders" {
+		t.Errorf("unexpected endpoint file: %q", op)
+	}
+
+	// Внешняя программа получает модель на stdin и возвращает файлы на stdout
+	script := filepath.Join(t.TempDir(), "renderer.sh")
+	body := "#!/bin/sh\ncat > /dev/null\necho '{\"files\": [{\"path\": \"out/hello.txt\", \"content\": \"hello\"}]}'\n"
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+	cfg = &config.Config{Output: t.TempDir(), Renderer: "exec:" + script}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate with exec renderer failed: %v", err)
+	}
+	if hello, _ := os.ReadFile(filepath.Join(cfg.Output, "out", "hello.txt")); string(hello) != "hello" {
+		t.Errorf("unexpected exec renderer output: %q", hello)
+	}
+
+	gen := New(&config.Config{Output: t.TempDir()}, api)
+	if err := gen.writeRenderedFiles([]File{{Path: "../escape.txt"}}); err == nil {
+		t.Error("expected error for path outside output directory")
+	}
+}
+
+func TestFilterExcludedReport(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users", Tags: []string{"users"}},
+			{Method: "GET", Path: "/internal/metrics", OperationID: "getMetrics", Tags: []string{"ops"}},
+			{Method: "DELETE", Path: "/users/{id}", Tags: []string{"admin"}},
+		},
+	}
+
+	cfg := &config.Config{
+		Output:         t.TempDir(),
+		Filter:         config.Filter{ExcludePaths: []string{"/internal/**"}, ExcludeTags: []string{"admin"}},
+		ExcludedReport: true,
+	}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	index, _ := os.ReadFile(filepath.Join(cfg.Output, "llms.txt"))
+	if strings.Contains(string(index), "/internal/metrics") || strings.Contains(string(index), "DELETE") {
+		t.Errorf("excluded operations leaked into llms.txt:\n%s", index)
+	}
+	if len(api.Endpoints) != 3 {
+		t.Errorf("filter must not modify the caller's API, got %d endpoints", len(api.Endpoints))
+	}
+
+	data, err := os.ReadFile(filepath.Join(cfg.Output, "excluded.json"))
+	if err != nil {
+		t.Fatalf("excluded.json not written: %v", err)
+	}
+	var report struct {
+		Excluded []excludedEndpoint `json:"excluded"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("invalid excluded.json: %v", err)
+	}
+	want := []excludedEndpoint{
+		{"GET", "/internal/metrics", "getMetrics", `path matches excludePaths pattern "/internal/**"`},
+		{"DELETE", "/users/{id}", "", `tag "admin" is in excludeTags`},
+	}
+	if fmt.Sprint(report.Excluded) != fmt.Sprint(want) {
+		t.Errorf("unexpected excluded list: %+v", report.Excluded)
+	}
+}
+
+func TestIncrementalGeneration(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users", Summary: "List users", Tags: []string{"users"}},
+			{Method: "GET", Path: "/orders", Summary: "List orders", Tags: []string{"orders"}},
+		},
+	}
+	cfg := &config.Config{Output: t.TempDir(), Incremental: true}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	// Помечаем файлы старым временем, чтобы увидеть перезапись
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	usersPath := filepath.Join(cfg.Output, "endpoints", "get-users.txt")
+	ordersPath := filepath.Join(cfg.Output, "endpoints", "get-orders.txt")
+	for _, path := range []string{usersPath, ordersPath} {
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatalf("Chtimes failed: %v", err)
+		}
+	}
+
+	api.Endpoints[0].Summary = "List all users"
+	api.Endpoints = api.Endpoints[:1]
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if info, err := os.Stat(usersPath); err != nil || info.ModTime().Equal(old) {
+		t.Errorf("changed file was not rewritten")
+	}
+	if _, err := os.Stat(ordersPath); !os.IsNotExist(err) {
+		t.Errorf("stale file from previous run was not removed")
+	}
+
+	if err := os.Chtimes(usersPath, old, old); err != nil {
+		t.Fatalf("Chtimes failed: %v", err)
+	}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if info, _ := os.Stat(usersPath); !info.ModTime().Equal(old) {
+		t.Errorf("unchanged file was rewritten")
+	}
+	if _, err := os.Stat(filepath.Join(cfg.Output, ManifestFile)); err != nil {
+		t.Errorf("manifest not written: %v", err)
+	}
+
+	// Обычный запуск меняет файл без смены размера и удаляет манифест, которому больше нельзя верить
+	api.Title = "Test APJ"
+	plain := *cfg
+	plain.Incremental = false
+	if err := New(&plain, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.Output, ManifestFile)); !os.IsNotExist(err) {
+		t.Errorf("non-incremental run should remove the manifest")
+	}
+	api.Title = "Test API"
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if index, _ := os.ReadFile(filepath.Join(cfg.Output, "llms.txt")); !strings.Contains(string(index), "# Test API\n") {
+		t.Errorf("incremental run after a plain run should rewrite llms.txt, got:\n%s", index)
+	}
+
+	// Файл, изменённый вручную с тем же размером, перезаписывается, хотя манифест совпадает
+	data, _ := os.ReadFile(usersPath)
+	edited := strings.Replace(string(data), "List all users", "List all usors", 1)
+	if err := os.WriteFile(usersPath, []byte(edited), 0644); err != nil {
+		t.Fatalf("Failed to edit file: %v", err)
+	}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if got, _ := os.ReadFile(usersPath); string(got) != string(data) {
+		t.Errorf("file edited on disk should be rewritten, got:\n%s", got)
+	}
+}
+
+func TestSectionCache(t *testing.T) {
+	api := &parser.API{
+		Title:     "Test API",
+		Endpoints: []parser.Endpoint{{Method: "GET", Path: "/users", Summary: "List users", Tags: []string{"users"}}},
+	}
+	sectionCache.Clear()
+	cacheDir := t.TempDir()
+	cfg := &config.Config{Output: t.TempDir(), CacheDir: cacheDir}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	var cached []string
+	filepath.WalkDir(cacheDir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			cached = append(cached, path)
+		}
+		return err
+	})
+	if len(cached) != 1 {
+		t.Fatalf("expected 1 cached section, got %d", len(cached))
+	}
+
+	// Следующий запуск (с другой директорией вывода и фильтром) берёт секцию с диска
+	sectionCache.Clear()
+	if err := os.WriteFile(cached[0], []byte("## GET /users - from cache\n"), 0644); err != nil {
+		t.Fatalf("Failed to write cache: %v", err)
+	}
+	cfg = &config.Config{Output: t.TempDir(), CacheDir: cacheDir, Filter: config.Filter{ExcludeTags: []string{"admin"}}}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content, _ := os.ReadFile(filepath.Join(cfg.Output, "endpoints", "get-users.txt"))
+	if !strings.Contains(string(content), "from cache") {
+		t.Errorf("cached section was not reused:\n%s", content)
+	}
+
+	// Изменение операции меняет ключ
+	api.Endpoints[0].Summary = "List all users"
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content, _ = os.ReadFile(filepath.Join(cfg.Output, "endpoints", "get-users.txt"))
+	if !strings.Contains(string(content), "List all users") {
+		t.Errorf("changed operation served from stale cache:\n%s", content)
+	}
+
+	// Повторный Generate того же генератора видит изменённый конфиг
+	gen := New(cfg, api)
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	cfg.BaseURL = "https://changed.example.com"
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content, _ = os.ReadFile(filepath.Join(cfg.Output, "endpoints", "get-users.txt"))
+	if !strings.Contains(string(content), "https://changed.example.com/users") {
+		t.Errorf("reused generator served a section rendered for the previous config:\n%s", content)
+	}
+}
+
+func TestSectionCacheSharedAcrossAudiences(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/shared", Summary: "Shared"},
+			{Method: "GET", Path: "/public", Summary: "Public", Extensions: map[string]any{"x-audience": "public"}},
+			{Method: "GET", Path: "/partner", Summary: "Partner", Extensions: map[string]any{"x-audience": "partner"}},
+		},
+	}
+	sectionCache.Clear()
+	cacheDir := t.TempDir()
+	for _, audience := range []string{"public", "partner"} {
+		cfg := &config.Config{Output: t.TempDir(), CacheDir: cacheDir, Audience: audience}
+		if err := New(cfg, api).Generate(); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+	}
+
+	cached := 0
+	filepath.WalkDir(cacheDir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			cached++
+		}
+		return err
+	})
+	if cached != 3 {
+		t.Errorf("expected 3 cached sections (shared stored once), got %d", cached)
+	}
+}
+
+func TestRenderAndChanges(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users", Summary: "List users", Tags: []string{"users"}},
+			{Method: "GET", Path: "/orders", Summary: "List orders", Tags: []string{"orders"}},
+		},
+	}
+	output := filepath.Join(t.TempDir(), "llms")
+	cfg := &config.Config{Output: output}
+
+	files, err := New(cfg, api).Render()
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Fatalf("Render must not create the output directory")
+	}
+	if _, ok := files["endpoints/get-users.txt"]; !ok {
+		t.Errorf("expected endpoints/get-users.txt in rendered files, got %d files", len(files))
+	}
+
+	changes, err := Changes(output, files)
+	if err != nil {
+		t.Fatalf("Changes failed: %v", err)
+	}
+	if len(changes) != len(files) || changes[0].Kind != ChangeCreate {
+		t.Errorf("expected every file to be created, got %+v", changes)
+	}
+
+	// Удалёнными считаются только файлы, записанные прошлым запуском по манифесту
+	cfg.Incremental = true
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	api.Endpoints[0].Summary = "List all users"
+	api.Endpoints = api.Endpoints[:1]
+	files, err = New(cfg, api).Render()
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	changes, err = Changes(output, files)
+	if err != nil {
+		t.Fatalf("Changes failed: %v", err)
+	}
+
+	kinds := map[string]string{}
+	for _, c := range changes {
+		kinds[c.Path] = c.Kind
+	}
+	want := map[string]string{
+		"endpoints/get-orders.txt": ChangeDelete,
+		"endpoints/get-users.txt":  ChangeUpdate,
+		"llms.txt":                 ChangeUpdate,
+	}
+	if fmt.Sprint(kinds) != fmt.Sprint(want) {
+		t.Errorf("unexpected changes: %v", kinds)
+	}
+}
+
+func TestStaleFiles(t *testing.T) {
+	newAPI := func() *parser.API {
+		return &parser.API{
+			Title: "Test API",
+			Endpoints: []parser.Endpoint{
+				{Method: "GET", Path: "/a", Summary: "A"},
+				{Method: "GET", Path: "/b", Summary: "B"},
+			},
+		}
+	}
+	stalePath := "endpoints/get-b.txt"
+
+	// Без манифеста генерация не знает, какие файлы её: ничего не удаляет, и check не падает
+	cfg := &config.Config{Output: t.TempDir()}
+	api := newAPI()
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	api.Endpoints = api.Endpoints[:1]
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	files, err := New(cfg, api).Render()
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if changes, err := Changes(cfg.Output, files); err != nil || len(changes) != 0 {
+		t.Errorf("expected no changes after regenerating, got %+v (%v)", changes, err)
+	}
+
+	// С манифестом (--manifest или --incremental) dry run и обычный запуск удаляют одно и то же
+	for _, mode := range []string{"manifest", "incremental"} {
+		t.Run(mode, func(t *testing.T) {
+			cfg := &config.Config{Output: t.TempDir(), PublishManifest: mode == "manifest", Incremental: mode == "incremental"}
+			api := newAPI()
+			if err := New(cfg, api).Generate(); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			userFile := filepath.Join(cfg.Output, "notes.md")
+			if err := os.WriteFile(userFile, []byte("hand-written\n"), 0644); err != nil {
+				t.Fatalf("Failed to write user file: %v", err)
+			}
+
+			api.Endpoints = api.Endpoints[:1]
+			files, err := New(cfg, api).Render()
+			if err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			changes, err := Changes(cfg.Output, files)
+			if err != nil {
+				t.Fatalf("Changes failed: %v", err)
+			}
+			var deleted []string
+			for _, c := range changes {
+				if c.Kind == ChangeDelete {
+					deleted = append(deleted, c.Path)
+				}
+			}
+			if fmt.Sprint(deleted) != fmt.Sprint([]string{stalePath}) {
+				t.Errorf("dry run deletes %v, want [%s]", deleted, stalePath)
+			}
+
+			if err := New(cfg, api).Generate(); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(cfg.Output, filepath.FromSlash(stalePath))); !os.IsNotExist(err) {
+				t.Errorf("real run kept %s that dry run reported as deleted", stalePath)
+			}
+			if _, err := os.Stat(userFile); err != nil {
+				t.Errorf("user file was removed: %v", err)
+			}
+
+			files, err = New(cfg, api).Render()
+			if err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			if changes, err := Changes(cfg.Output, files); err != nil || len(changes) != 0 {
+				t.Errorf("expected no changes after regenerating, got %+v (%v)", changes, err)
+			}
+		})
+	}
+}
+
+func TestManifestOutsideOutput(t *testing.T) {
+	root := t.TempDir()
+	outside := filepath.Join(root, "outside.txt")
+	if err := os.WriteFile(outside, []byte("keep\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	output := filepath.Join(root, "llms")
+	if err := os.MkdirAll(output, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	m := `{"files": {"../outside.txt": "x", "/etc/hostname": "x"}}`
+	if err := os.WriteFile(filepath.Join(output, ManifestFile), []byte(m), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	api := &parser.API{Title: "Test API", Endpoints: []parser.Endpoint{{Method: "GET", Path: "/a"}}}
+	cfg := &config.Config{Output: output, Incremental: true}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(outside); err != nil {
+		t.Errorf("manifest entry outside the output directory was removed: %v", err)
+	}
+}
+
+func TestEndpointSection(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users/{id}", OperationID: "getUserById", Summary: "Get user"},
+			{Method: "DELETE", Path: "/users/{id}", Summary: "Delete user"},
+		},
+	}
+	gen := New(&config.Config{}, api)
+
+	section, err := gen.EndpointSection("getUserById")
+	if err != nil {
+		t.Fatalf("EndpointSection failed: %v", err)
+	}
+	if !strings.HasPrefix(section, "<a id=\"getuserbyid\"></a>\n\n## GET /users/{id} - Get user") || strings.Contains(section, "DELETE") {
+		t.Errorf("unexpected section:\n%s", section)
+	}
+
+	section, err = gen.EndpointSection("delete /users/{id}")
+	if err != nil {
+		t.Fatalf("EndpointSection by method and path failed: %v", err)
+	}
+	if !strings.HasPrefix(section, "<a id=\"delete-users-id\"></a>\n\n## DELETE /users/{id} - Delete user") {
+		t.Errorf("unexpected section:\n%s", section)
+	}
+
+	if _, err := gen.EndpointSection("missing"); err == nil {
+		t.Error("expected error for unknown operation")
+	}
+}
+
+func TestAudienceFilter(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Tags: []parser.Tag{
+			{Name: "partners", Extensions: map[string]any{"x-audience": []any{"partner", "internal"}}},
+		},
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users", Tags: []string{"users"}},
+			{Method: "GET", Path: "/reports", Tags: []string{"partners"}},
+			{Method: "POST", Path: "/reports", Tags: []string{"partners"}, Extensions: map[string]any{"x-audience": "internal"}},
+		},
+	}
+
+	cfg := &config.Config{Output: t.TempDir(), Audience: "partner", ExcludedReport: true}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	index, _ := os.ReadFile(filepath.Join(cfg.Output, "llms.txt"))
+	for _, want := range []string{"GET /users", "GET /reports"} {
+		if !strings.Contains(string(index), want) {
+			t.Errorf("expected %s for partner audience", want)
+		}
+	}
+	if strings.Contains(string(index), "POST /reports") {
+		t.Error("internal operation leaked into partner docs")
+	}
+
+	report, _ := os.ReadFile(filepath.Join(cfg.Output, "excluded.json"))
+	if !strings.Contains(string(report), `audience \"partner\" not in x-audience [internal]`) {
+		t.Errorf("expected audience reason in excluded.json:\n%s", report)
+	}
+}
+
+func TestCurlScripts(t *testing.T) {
+	api := &parser.API{
+		Title:   "Test API",
+		BaseURL: "https://api.test.com",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users", OperationID: "listUsers", Tags: []string{"users"}},
+			{Method: "GET", Path: "/orders", Tags: []string{"orders"}},
+			{Method: "DELETE", Path: "/users/{id}", OperationID: "deleteUser", Tags: []string{"users"}},
+		},
+	}
+	cfg := &config.Config{Output: t.TempDir(), CurlScripts: true}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	script, err := os.ReadFile(filepath.Join(cfg.Output, "examples", "listUsers.sh"))
+	if err != nil {
+		t.Fatalf("listUsers.sh not written: %v", err)
+	}
+	if !strings.Contains(string(script), `curl "$@" -X GET "https://api.test.com/users"`) {
+		t.Errorf("unexpected script:\n%s", script)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.Output, "examples", "get-orders.sh")); err != nil {
+		t.Errorf("expected script named after method and path without operationId: %v", err)
+	}
+
+	smoke, _ := os.ReadFile(filepath.Join(cfg.Output, "examples", "smoke.sh"))
+	if !strings.Contains(string(smoke), "for script in get-orders.sh listUsers.sh; do") {
+		t.Errorf("smoke runner must iterate only GET examples:\n%s", smoke)
+	}
+}
+
+func TestOptionalSection(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Tags: []parser.Tag{
+			{Name: "users"},
+			{Name: "legacy", Extensions: map[string]any{"x-llms-optional": true}},
+			{Name: "admin"},
+		},
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users", Tags: []string{"users"}},
+			{Method: "GET", Path: "/v1/users", Tags: []string{"legacy"}},
+			{Method: "GET", Path: "/admin/stats", Tags: []string{"admin"}},
+		},
+	}
+	cfg := &config.Config{OptionalTags: []string{"admin"}}
+	index := New(cfg, api).generateIndex(api.Endpoints)
+
+	endpoints := strings.Index(index, "## Endpoints")
+	optional := strings.Index(index, "## Optional")
+	if endpoints < 0 || optional < endpoints {
+		t.Fatalf("expected Optional section after Endpoints:\n%s", index)
+	}
+	if strings.Contains(index[optional:], "get-users.txt") || !strings.Contains(index[optional:], "### legacy") || !strings.Contains(index[optional:], "### admin") {
+		t.Errorf("unexpected Optional section:\n%s", index[optional:])
+	}
+	if strings.Contains(index[endpoints:optional], "legacy") {
+		t.Errorf("optional group listed under Endpoints:\n%s", index)
+	}
+}
+
+func TestCustomSections(t *testing.T) {
+	api := &parser.API{
+		Title:           "Test API",
+		SecuritySchemes: []parser.SecurityScheme{{Name: "bearer", Type: "http", Scheme: "bearer"}},
+		Endpoints:       []parser.Endpoint{{Method: "GET", Path: "/users", Tags: []string{"users"}}},
+	}
+	terms := filepath.Join(t.TempDir(), "terms.md")
+	if err := os.WriteFile(terms, []byte("Use at most 10 requests per second.\n"), 0644); err != nil {
+		t.Fatalf("Failed to write section file: %v", err)
+	}
+
+	cfg := &config.Config{
+		Output: t.TempDir(),
+		Sections: []config.Section{
+			{Title: "Terms of Use", File: terms},
+			{Title: "Introduction", Content: "Start here.", Position: "top"},
+			{Title: "Getting a token", Content: "Create one in the dashboard.", Position: "after:authentication"},
+			{Title: "Missing anchor", Content: "Falls back to the end.", Position: "before:errors"},
+		},
+	}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	index, _ := os.ReadFile(filepath.Join(cfg.Output, "llms.txt"))
+
+	order := []string{"## Introduction", "## Authentication", "## Getting a token", "## Endpoints", "## Terms of Use", "Use at most 10 requests", "## Missing anchor"}
+	last := -1
+	for _, heading := range order {
+		pos := strings.Index(string(index), heading)
+		if pos <= last {
+			t.Fatalf("expected %q after previous sections:\n%s", heading, index)
+		}
+		last = pos
+	}
+}
+
+func TestRequiredRequestFields(t *testing.T) {
+	api := &parser.API{Title: "Test API"}
+	ep := parser.Endpoint{
+		Method: "POST",
+		Path:   "/users",
+		RequestBody: &parser.RequestBody{
+			Content: map[string]parser.MediaType{
+				"application/json": {Schema: &parser.Schema{
+					Type: "object",
+					Properties: map[string]*parser.Schema{
+						"name":  {Type: "string"},
+						"email": {Type: "string"},
+						"bio":   {Type: "string"},
+					},
+					Required: []string{"name", "email"},
+				}},
+			},
+		},
+	}
+
+	// Даже при урезанном примере обязательные поля перечислены до JSON
+	section := New(&config.Config{MaxPropertiesPerObject: 1}, api).generateEndpoint(ep)
+	line := strings.Index(section, "Required: `name`, `email`")
+	if line < 0 || line > strings.Index(section, "```json") {
+		t.Errorf("expected required fields before the JSON example:\n%s", section)
+	}
+
+	section = New(&config.Config{Language: config.LanguageRU}, api).generateEndpoint(ep)
+	if !strings.Contains(section, "Обязательные поля: `name`, `email`") {
+		t.Errorf("expected translated label:\n%s", section)
+	}
+}
+
+func TestTagOverrides(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Tags:  []parser.Tag{{Name: "movie", Description: "Movie operations"}, {Name: "person"}},
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/v1.4/movie/search", Tags: []string{"movie"}},
+			{Method: "GET", Path: "/v1.4/movie/{id}", Tags: []string{"movie"}},
+			{Method: "GET", Path: "/v1.4/person", Tags: []string{"person"}},
+		},
+	}
+	cfg := &config.Config{
+		Output: t.TempDir(),
+		Tags: map[string]config.TagOverride{
+			"movie":  {Title: "Movies", Description: "Search and fetch movies", Filename: "movies", Order: 2},
+			"person": {Order: 1},
+		},
+	}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, name := range []string{"get-movies-search.txt", "get-movies-id.txt", "get-v1.4-person.txt"} {
+		if _, err := os.Stat(filepath.Join(cfg.Output, "endpoints", name)); err != nil {
+			t.Errorf("expected endpoints/%s: %v", name, err)
+		}
+	}
+
+	index, _ := os.ReadFile(filepath.Join(cfg.Output, "llms.txt"))
+	person := strings.Index(string(index), "### person")
+	movies := strings.Index(string(index), "### Movies\n\nSearch and fetch movies")
+	if person < 0 || movies < person {
+		t.Errorf("expected pinned order person, Movies with overridden description:\n%s", index)
+	}
+
+	file, _ := os.ReadFile(filepath.Join(cfg.Output, "endpoints", "get-movies-search.txt"))
+	if !strings.HasPrefix(string(file), "# Movies\n") {
+		t.Errorf("expected overridden title in endpoint file:\n%s", file)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users", Tags: []string{"users"}},
+			{Method: "POST", Path: "/users", Tags: []string{"users"}},
+			{Method: "GET", Path: "/orders", Tags: []string{"orders"}},
+		},
+	}
+	gen := New(&config.Config{Output: t.TempDir()}, api)
+	var updates []Progress
+	gen.SetProgress(func(p Progress) { updates = append(updates, p) })
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if len(updates) != 4 {
+		t.Fatalf("expected an update per file (3 endpoints + llms.txt), got %d", len(updates))
+	}
+	if last := updates[len(updates)-1]; last != (Progress{Files: 4, TotalFiles: 4, Groups: 2, TotalGroups: 2}) {
+		t.Errorf("unexpected final progress: %+v", last)
+	}
+}
+
+func TestLocalizedStatusText(t *testing.T) {
+	api := &parser.API{Title: "Test API"}
+	ep := parser.Endpoint{
+		Method: "GET",
+		Path:   "/users",
+		Responses: map[string]parser.Response{
+			"200":     {},
+			"404":     {Description: "User not found"},
+			"4xx":     {},
+			"default": {},
+		},
+	}
+
+	section := New(&config.Config{Language: config.LanguageRU}, api).generateEndpoint(ep)
+	for _, want := range []string{"**200 Успешно**\n", "**404 Не найдено** - User not found", "**4xx Ошибка клиента** (любой статус 400–499)\n", "**default** (любой другой статус)\n"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("expected %q in:\n%s", want, section)
+		}
+	}
+
+	section = New(&config.Config{}, api).generateEndpoint(ep)
+	if !strings.Contains(section, "**200 OK**\n") || !strings.Contains(section, "**4xx Client error** (any status 400–499)\n") {
+		t.Errorf("expected English status texts:\n%s", section)
+	}
+}
+
+func TestResponseCodeOrder(t *testing.T) {
+	codes := []string{"default", "4XX", "500", "201", "404", "2XX", "200", "400"}
+	sortResponseCodes(codes)
+	expected := []string{"200", "201", "2XX", "400", "404", "4XX", "500", "default"}
+	if !slices.Equal(codes, expected) {
+		t.Errorf("sortResponseCodes = %v, expected %v", codes, expected)
+	}
+}
+
+func TestEndpointFilenamesPortable(t *testing.T) {
+	api := &parser.API{Endpoints: []parser.Endpoint{
+		{Method: "POST", Path: "/things/{id}:cancel"},
+		{Method: "GET", Path: "/товары/{id}"},
+		{Method: "GET", Path: "/users/{userId}"},
+		{Method: "GET", Path: "/reports/a<b>|c?", Tags: []string{"reports"}},
+		{Method: "GET", Path: "/reports/weekly", Tags: []string{"reports"}},
+	}}
+	cfg := &config.Config{Tags: map[string]config.TagOverride{"reports": {Filename: "Отчёты:2024"}}}
+	want := map[string]string{
+		"POST /things/{id}:cancel": "endpoints/post-things-id-cancel.txt",
+		"GET /товары/{id}":         "endpoints/get-tovary-id.txt",
+		"GET /users/{userId}":      "endpoints/get-users-userId.txt",
+		"GET /reports/a<b>|c?":     "endpoints/get-otchety-2024-a-b-c.txt",
+		"GET /reports/weekly":      "endpoints/get-otchety-2024-weekly.txt",
+	}
+	if got := New(cfg, api).EndpointFiles(); !maps.Equal(got, want) {
+		t.Errorf("EndpointFiles() = %v, want %v", got, want)
+	}
+
+	cfg.Format = config.FormatHTML
+	gen := New(cfg, api)
+	gen.assignFilenames(gen.sortEndpoints())
+	if page := gen.groupPages["reports"]; page != "otchety-2024.html" {
+		t.Errorf("group page override should be sanitized, got %q", page)
+	}
+}
+
+func TestFilenameCollisions(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users/{id}", Summary: "By id", Tags: []string{"Users"}},
+			{Method: "GET", Path: "/users/id", Summary: "Literal", Tags: []string{"users"}},
+		},
+	}
+	cfg := &config.Config{Output: t.TempDir()}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	index, _ := os.ReadFile(filepath.Join(cfg.Output, "llms.txt"))
+	for _, want := range []string{"(./endpoints/get-users-id.txt#get-users-id) — Literal", "(./endpoints/get-users-id-2.txt#get-users-id) — By id"} {
+		if !strings.Contains(string(index), want) {
+			t.Errorf("expected link %q:\n%s", want, index)
+		}
+	}
+	first, _ := os.ReadFile(filepath.Join(cfg.Output, "endpoints", "get-users-id.txt"))
+	second, _ := os.ReadFile(filepath.Join(cfg.Output, "endpoints", "get-users-id-2.txt"))
+	if !strings.Contains(string(first), "Literal") || !strings.Contains(string(second), "By id") {
+		t.Errorf("colliding endpoints overwrote each other")
+	}
+
+	cfg = &config.Config{Output: t.TempDir(), Format: config.FormatHTML}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	for _, name := range []string{"users.html", "users-2.html"} {
+		if _, err := os.Stat(filepath.Join(cfg.Output, "groups", name)); err != nil {
+			t.Errorf("expected groups/%s for tags differing only in case: %v", name, err)
+		}
+	}
+}
+
+func TestEndpointFileNaming(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users", OperationID: "listUsers"},
+			{Method: "GET", Path: "/users/{id}"},
+			{Method: "POST", Path: "/users", OperationID: "users/create"},
+		},
+	}
+
+	// Имена файлов — публичный контракт: ссылки на них живут вне сгенерированной документации
+	tests := []struct {
+		naming string
+		want   map[string]string
+	}{
+		{config.FileNamingPath, map[string]string{
+			"GET /users":      "endpoints/get-users.txt",
+			"GET /users/{id}": "endpoints/get-users-id.txt",
+			"POST /users":     "endpoints/post-users.txt",
+		}},
+		{config.FileNamingOperationID, map[string]string{
+			"GET /users":      "endpoints/listUsers.txt",
+			"GET /users/{id}": "endpoints/get-users-id.txt",
+			"POST /users":     "endpoints/users-create.txt",
+		}},
+	}
+	for _, tt := range tests {
+		got := New(&config.Config{FileNaming: tt.naming}, api).EndpointFiles()
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("fileNaming %s: got %v, want %v", tt.naming, got, tt.want)
+		}
+	}
+}
+
+func TestEndpointMatrix(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Tags:  []parser.Tag{{Name: "users"}, {Name: "legacy", Extensions: map[string]any{"x-llms-optional": true}}},
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users", Tags: []string{"users"}},
+			{Method: "POST", Path: "/users", Tags: []string{"users"}},
+			{Method: "DELETE", Path: "/users/{id}", Tags: []string{"users"}},
+			{Method: "GET", Path: "/users/{id}", Tags: []string{"users"}},
+			{Method: "GET", Path: "/v1/users", Tags: []string{"legacy"}},
+		},
+	}
+	cfg := &config.Config{IndexLayout: config.IndexLayoutMatrix}
+	index := New(cfg, api).generateIndex(api.Endpoints)
+
+	for _, row := range []string{
+		"| Resource | GET | POST | DELETE |",
+		"| `/users` | [✓](./endpoints/get-users.txt#get-users) | [✓](./endpoints/post-users.txt#post-users) |  |",
+		"| `/users/{id}` | [✓](./endpoints/get-users-id.txt#get-users-id) |  | [✓](./endpoints/delete-users-id.txt#delete-users-id) |",
+	} {
+		if !strings.Contains(index, row) {
+			t.Errorf("expected matrix row %q:\n%s", row, index)
+		}
+	}
+	optional := strings.Index(index, "## Optional")
+	if optional < 0 || strings.Contains(index[:optional], "/v1/users") {
+		t.Errorf("expected optional groups outside the matrix:\n%s", index)
+	}
+}
+
+func TestOAuthFlows(t *testing.T) {
+	api := &parser.API{
+		SecuritySchemes: []parser.SecurityScheme{
+			{Name: "oauth", Type: "oauth2", Flows: []parser.OAuthFlow{
+				{Type: "authorizationCode", AuthorizationURL: "https://auth.example.com/authorize", TokenURL: "https://auth.example.com/token",
+					Scopes: map[string]string{"write:pets": "modify pets", "read:pets": "read pets"}},
+				{Type: "clientCredentials", TokenURL: "https://auth.example.com/token",
+					Scopes: map[string]string{"write:pets": "modify pets", "read:pets": "read pets"}},
+			}},
+			{Name: "partner", Type: "oauth2", Flows: []parser.OAuthFlow{
+				{Type: "clientCredentials", TokenURL: "https://partner.example.com/token", Scopes: map[string]string{"partner": ""}},
+				{Type: "implicit", AuthorizationURL: "https://partner.example.com/authorize", Scopes: map[string]string{"partner:ui": "UI access"}},
+			}},
+			{Name: "oidc", Type: "openIdConnect", OpenIDConnectURL: "https://auth.example.com/.well-known/openid-configuration"},
+		},
+	}
+	gen := New(&config.Config{}, api)
+
+	oauth := gen.formatSecurityScheme(api.SecuritySchemes[0])
+	expected := "- **Type**: OAuth 2.0\n" +
+		"- **Flow**: Authorization Code\n" +
+		"  - Authorization URL: `https://auth.example.com/authorize`\n" +
+		"  - Token URL: `https://auth.example.com/token`\n" +
+		"- **Flow**: Client Credentials\n" +
+		"  - Token URL: `https://auth.example.com/token`\n" +
+		"- **Scopes**:\n" +
+		"  - `read:pets` — read pets\n" +
+		"  - `write:pets` — modify pets\n"
+	if !strings.Contains(oauth, expected) {
+		t.Errorf("expected flows with shared scopes listed once, got:\n%s", oauth)
+	}
+
+	partner := gen.formatSecurityScheme(api.SecuritySchemes[1])
+	if !strings.Contains(partner, "  - Scopes:\n    - `partner`\n") || !strings.Contains(partner, "    - `partner:ui` — UI access\n") {
+		t.Errorf("expected per-flow scopes, got:\n%s", partner)
+	}
+
+	oidc := gen.formatSecurityScheme(api.SecuritySchemes[2])
+	if !strings.Contains(oidc, "- **Discovery URL**: `https://auth.example.com/.well-known/openid-configuration`") {
+		t.Errorf("expected discovery URL, got:\n%s", oidc)
+	}
+}
+
+func TestBinaryResponses(t *testing.T) {
+	api := &parser.API{BaseURL: "https://api.example.com"}
+	gen := New(&config.Config{Examples: []string{config.ExampleCurl, config.ExampleHTTPie, config.ExamplePowerShell}}, api)
+
+	ep := parser.Endpoint{
+		Method: "GET",
+		Path:   "/reports/{id}/pdf",
+		Responses: map[string]parser.Response{
+			"200": {Description: "Report", Content: map[string]parser.MediaType{
+				"application/pdf": {Schema: &parser.Schema{Type: "string", Format: "binary"}},
+			}},
+			"404": {Description: "Not found", Content: map[string]parser.MediaType{
+				"application/json": {Schema: &parser.Schema{Type: "object", Properties: map[string]*parser.Schema{"error": {Type: "string"}}}},
+			}},
+		},
+	}
+	section := gen.generateEndpoint(ep)
+	for _, expected := range []string{
+		"Returns binary data (content-type `application/pdf`)",
+		"-o output.pdf",
+		"http --output output.pdf GET",
+		"-OutFile 'output.pdf'",
+		"\"error\": \"string\"",
+	} {
+		if !strings.Contains(section, expected) {
+			t.Errorf("expected %q in:\n%s", expected, section)
+		}
+	}
+
+	ep.Responses = map[string]parser.Response{"200": {Content: map[string]parser.MediaType{"application/octet-stream": {}}}}
+	if result := gen.generateCurlExample(ep); !strings.Contains(result, "-o output.bin") {
+		t.Errorf("expected generic binary output file:\n%s", result)
+	}
+	ep.Responses = map[string]parser.Response{"200": {Content: map[string]parser.MediaType{"application/json": {}}}}
+	if result := gen.generateCurlExample(ep); strings.Contains(result, " -o ") {
+		t.Errorf("JSON response should not be saved to a file:\n%s", result)
+	}
+}
+
+func TestResponseContentNegotiation(t *testing.T) {
+	api := &parser.API{BaseURL: "https://api.example.com"}
+	gen := New(&config.Config{}, api)
+
+	ep := parser.Endpoint{
+		Method: "GET",
+		Path:   "/reports",
+		Responses: map[string]parser.Response{
+			"200": {Description: "Report", Content: map[string]parser.MediaType{
+				"text/csv":         {Schema: &parser.Schema{Type: "string"}},
+				"application/json": {Schema: &parser.Schema{Type: "object", Properties: map[string]*parser.Schema{"total": {Type: "integer"}}}},
+				"application/pdf":  {Schema: &parser.Schema{Type: "string", Format: "binary"}},
+			}},
+		},
+	}
+	section := gen.generateEndpoint(ep)
+	for _, expected := range []string{
+		"Response formats (select with the `Accept` header):\n\n" +
+			"- `Accept: application/json` — used in the example\n" +
+			"- `Accept: application/pdf` — binary data, see the alternate example\n" +
+			"- `Accept: text/csv` — see the alternate example\n",
+		"curl -X GET \"https://api.example.com/reports\" \\\n  -H \"Accept: application/json\"\n",
+		"Accept: `application/pdf`\n\n```bash\ncurl -X GET \"https://api.example.com/reports\" \\\n  -H \"Accept: application/pdf\" \\\n  -o output.pdf\n```",
+		"Accept: `text/csv`\n\n```bash\ncurl -X GET \"https://api.example.com/reports\" \\\n  -H \"Accept: text/csv\"\n```",
+	} {
+		if !strings.Contains(section, expected) {
+			t.Errorf("expected %q in:\n%s", expected, section)
+		}
+	}
+
+	// Предпочтительный тип из конфига становится основным
+	gen = New(&config.Config{ContentTypes: []string{"text/csv"}}, api)
+	if curl := gen.generateCurlExample(ep); !strings.Contains(curl, `-H "Accept: text/csv"`) {
+		t.Errorf("expected text/csv in the main example:\n%s", curl)
+	}
+
+	// Единственный формат ответа не требует Accept
+	ep.Responses = map[string]parser.Response{"200": {Content: map[string]parser.MediaType{"application/json": {}}}}
+	if section := gen.generateEndpoint(ep); strings.Contains(section, "Accept") {
+		t.Errorf("unexpected content negotiation for a single format:\n%s", section)
+	}
+}
+
+func TestRecordResponses(t *testing.T) {
+	gen := New(&config.Config{}, &parser.API{})
+	record := &parser.Schema{Type: "object", Properties: map[string]*parser.Schema{
+		"id":   {Type: "integer"},
+		"name": {Type: "string", Description: "Display name", Example: "Doe, Jane"},
+	}}
+	ep := parser.Endpoint{
+		Method: "GET",
+		Path:   "/export",
+		Responses: map[string]parser.Response{
+			"200": {Description: "Export", Content: map[string]parser.MediaType{
+				"text/csv":             {Schema: &parser.Schema{Type: "array", Items: record}},
+				"application/x-ndjson": {Schema: record},
+			}},
+		},
+	}
+	section := gen.generateEndpoint(ep)
+	for _, expected := range []string{
+		"Content-Type: `text/csv`\n\nCSV with a header row, one record per line. Columns:\n\n| Field | Type | Description |",
+		"| name | string | Display name |\n",
+		"```csv\nid,name\n0,\"Doe, Jane\"\n```",
+		"Content-Type: `application/x-ndjson`\n\nOne JSON record per line (newline-delimited JSON):\n\n```json\n{\"id\":0,\"name\":\"Doe, Jane\"}\n```",
+	} {
+		if !strings.Contains(section, expected) {
+			t.Errorf("expected %q in:\n%s", expected, section)
+		}
+	}
+	if strings.Contains(section, "\"name\": \"Doe, Jane\"") {
+		t.Errorf("record responses should not render a JSON object block:\n%s", section)
+	}
+}
+
+func TestStreamingEndpoints(t *testing.T) {
+	api := &parser.API{BaseURL: "https://api.example.com"}
+	gen := New(&config.Config{Examples: []string{config.ExampleCurl, config.ExampleHTTPie}}, api)
+
+	ep := parser.Endpoint{
+		Method: "POST",
+		Path:   "/completions",
+		Extensions: map[string]any{"x-streaming": map[string]any{
+			"terminator":  "data: [DONE]",
+			"description": "Tokens arrive as `delta` events.",
+		}},
+		Responses: map[string]parser.Response{
+			"200": {Description: "Events", Content: map[string]parser.MediaType{"text/event-stream": {}}},
+		},
+	}
+	section := gen.generateEndpoint(ep)
+	for _, expected := range []string{
+		"### Streaming\n\nThe response is a stream of Server-Sent Events (`text/event-stream`)",
+		"The stream ends with `data: [DONE]`.\n\nTokens arrive as `delta` events.\n\n### Responses",
+		"-H \"Accept: text/event-stream\" \\\n  -N\n",
+		"http --stream POST",
+	} {
+		if !strings.Contains(section, expected) {
+			t.Errorf("expected %q in:\n%s", expected, section)
+		}
+	}
+
+	// x-streaming: true без известного формата — поток частями
+	ep.Extensions = map[string]any{"x-streaming": true}
+	ep.Responses = map[string]parser.Response{"200": {Content: map[string]parser.MediaType{"application/octet-stream": {}}}}
+	if section := gen.generateEndpoint(ep); !strings.Contains(section, "streamed in chunks") || !strings.Contains(section, "server closes the connection") {
+		t.Errorf("expected chunked streaming note:\n%s", section)
+	}
+
+	// x-streaming: false снимает отметку с text/event-stream
+	ep.Extensions = map[string]any{"x-streaming": false}
+	ep.Responses = map[string]parser.Response{"200": {Content: map[string]parser.MediaType{"text/event-stream": {}}}}
+	if section := gen.generateEndpoint(ep); strings.Contains(section, "Streaming") || strings.Contains(section, " -N") {
+		t.Errorf("x-streaming: false should disable the streaming note:\n%s", section)
+	}
+}
+
+func TestWebSocketEndpoints(t *testing.T) {
+	api := &parser.API{
+		BaseURL:         "https://api.example.com/v1",
+		SecuritySchemes: []parser.SecurityScheme{{Name: "bearer", Type: "http", Scheme: "bearer"}},
+	}
+	gen := New(&config.Config{}, api)
+
+	ep := parser.Endpoint{
+		Method: "GET",
+		Path:   "/stream",
+		WebSocket: &parser.WebSocket{
+			Subprotocols: []string{"graphql-ws"},
+			AsyncAPI:     "https://example.com/asyncapi.yaml#/channels/stream",
+			Send: []parser.WebSocketMessage{{Name: "subscribe", Schema: &parser.Schema{
+				Type: "object", Ref: "#/components/schemas/Subscribe",
+				Properties: map[string]*parser.Schema{"channel": {Type: "string"}},
+			}}},
+			Receive: []parser.WebSocketMessage{{Name: "tick", Description: "Price update."}},
+		},
+	}
+	section := gen.generateEndpoint(ep)
+	for _, expected := range []string{
+		"### WebSocket\n\n",
+		"- URL: `wss://api.example.com/v1/stream`\n",
+		"- Subprotocols: `graphql-ws` (`Sec-WebSocket-Protocol` header)\n",
+		"- AsyncAPI: https://example.com/asyncapi.yaml#/channels/stream\n",
+		"#### Client → server: `subscribe`\n\n```json\n{\n  \"channel\": \"string\"\n}\n```",
+		"#### Server → client: `tick`\n\nPrice update.\n\n",
+		"websocat --protocol graphql-ws -H \"Authorization: Bearer YOUR_TOKEN\" \"wss://api.example.com/v1/stream\"",
+	} {
+		if !strings.Contains(section, expected) {
+			t.Errorf("expected %q in:\n%s", expected, section)
+		}
+	}
+
+	ep.WebSocket = &parser.WebSocket{URL: "wss://stream.example.com"}
+	if section := gen.generateEndpoint(ep); !strings.Contains(section, "- URL: `wss://stream.example.com`") {
+		t.Errorf("expected explicit URL:\n%s", section)
+	}
+}
+
+func TestRequestLimits(t *testing.T) {
+	api := &parser.API{Extensions: map[string]any{"x-timeout": 30.0}}
+	gen := New(&config.Config{}, api)
+
+	ep := parser.Endpoint{
+		Method:     "POST",
+		Path:       "/uploads",
+		Extensions: map[string]any{"x-max-request-size": float64(10 << 20)},
+		Responses: map[string]parser.Response{
+			"201": {Description: "Created"},
+			"413": {Description: "Payload exceeds 10 MiB."},
+		},
+	}
+	expected := "### Limits\n\n" +
+		"- Timeout: 30 seconds\n" +
+		"- Max request size: 10485760 bytes (10 MiB)\n" +
+		"- `413`: the request body is too large; send less data per request (Payload exceeds 10 MiB.)\n\n"
+	if section := gen.generateEndpoint(ep); !strings.Contains(section, expected) {
+		t.Errorf("expected %q in:\n%s", expected, section)
+	}
+
+	// Значение операции переопределяет значение корня, строки выводятся как есть
+	ep = parser.Endpoint{Method: "GET", Path: "/reports", Extensions: map[string]any{"x-timeout": "2 minutes"},
+		Responses: map[string]parser.Response{"408": {}}}
+	expected = "- Timeout: 2 minutes\n- `408`: the request took too long; retry, splitting large work into smaller requests\n\n"
+	if section := gen.generateEndpoint(ep); !strings.Contains(section, expected) {
+		t.Errorf("expected %q in:\n%s", expected, section)
+	}
+
+	if section := New(&config.Config{}, &parser.API{}).generateEndpoint(parser.Endpoint{Method: "GET", Path: "/users"}); strings.Contains(section, "### Limits") {
+		t.Errorf("unexpected limits without metadata:\n%s", section)
+	}
+}
+
+func TestRequestContentTypes(t *testing.T) {
+	api := &parser.API{BaseURL: "https://api.example.com"}
+	user := &parser.Schema{
+		Type:       "object",
+		Ref:        "#/components/schemas/User",
+		Properties: map[string]*parser.Schema{"name": {Type: "string", Example: "Joe & Co"}},
+	}
+	ep := parser.Endpoint{
+		Method: "POST",
+		Path:   "/users",
+		RequestBody: &parser.RequestBody{Content: map[string]parser.MediaType{
+			"application/xml":                   {Schema: user},
+			"application/x-www-form-urlencoded": {Schema: user},
+			"application/json":                  {Schema: user},
+		}},
+	}
+
+	// По умолчанию пример в JSON, независимо от порядка обхода map
+	for range 10 {
+		if result := New(&config.Config{}, api).generateCurlExample(ep); !strings.Contains(result, `-H "Content-Type: application/json"`) {
+			t.Fatalf("expected JSON example by default:\n%s", result)
+		}
+	}
+
+	result := New(&config.Config{ContentTypes: []string{"application/xml"}}, api).generateCurlExample(ep)
+	if !strings.Contains(result, "-d '<User>\n  <name>Joe &amp; Co</name>\n</User>'") {
+		t.Errorf("expected preferred XML example:\n%s", result)
+	}
+
+	gen := New(&config.Config{AllContentTypes: true}, api)
+	result = gen.generateExamples(ep)
+	jsonPos := strings.Index(result, "Content-Type: `application/json`")
+	formPos := strings.Index(result, "Content-Type: `application/x-www-form-urlencoded`")
+	xmlPos := strings.Index(result, "Content-Type: `application/xml`")
+	if jsonPos < 0 || formPos < jsonPos || xmlPos < formPos {
+		t.Errorf("expected an example per content type, JSON first:\n%s", result)
+	}
+	if !strings.Contains(result, "--data-urlencode 'name=Joe & Co'") || !strings.Contains(result, "<name>Joe &amp; Co</name>") {
+		t.Errorf("expected form and XML bodies:\n%s", result)
+	}
+
+	// Пример тела в документации сериализуется по типу содержимого, как и блок кода
+	ep.Responses = map[string]parser.Response{"200": {Content: map[string]parser.MediaType{
+		"text/xml": {Schema: &parser.Schema{Type: "object", Properties: map[string]*parser.Schema{
+			"id": {Type: "integer", Example: 7}, "name": {Type: "string", Example: "Joe"},
+		}}},
+	}}}
+	doc := New(&config.Config{MaxPropertiesPerObject: 1}, api).generateEndpoint(ep)
+	for _, expected := range []string{
+		"Content-Type: `application/json`\n\n```json\n{\n  \"name\": ",
+		"Content-Type: `application/xml`\n\n```xml\n<User>\n  <name>Joe &amp; Co</name>\n</User>\n```",
+		"Content-Type: `text/xml`\n\n```xml\n<response>\n  <id>7</id>\n</response>\n```",
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("expected %q in:\n%s", expected, doc)
+		}
+	}
+}
+
+func TestParameterSerialization(t *testing.T) {
+	explodeFalse := false
+	tests := []struct {
+		param    parser.Parameter
+		expected string
+	}{
+		{parser.Parameter{Name: "ids", In: "query", Type: "array", ItemType: "integer"}, "ids=1&ids=2"},
+		{parser.Parameter{Name: "ids", In: "query", Type: "array", ItemType: "integer", Explode: &explodeFalse}, "ids=1,2"},
+		{parser.Parameter{Name: "tags", In: "query", Type: "array", Style: "pipeDelimited", Explode: &explodeFalse, Example: []any{"a", "b"}}, "tags=a|b"},
+		{parser.Parameter{Name: "tags", In: "query", Type: "array", Style: "spaceDelimited", Explode: &explodeFalse, Enum: []string{"x", "y", "z"}}, "tags=x%20y"},
+		{parser.Parameter{Name: "filter", In: "query", Type: "object", Style: "deepObject", Properties: []string{"status", "type"}}, "filter[status]=value&filter[type]=value"},
+		{parser.Parameter{Name: "point", In: "query", Type: "object", Example: map[string]any{"y": 2, "x": 1}}, "x=1&y=2"},
+		{parser.Parameter{Name: "point", In: "query", Type: "object", Explode: &explodeFalse, Example: map[string]any{"y": 2, "x": 1}}, "point=x,1,y,2"},
+	}
+	for _, tt := range tests {
+		if got := serializeQueryParam(tt.param); got != tt.expected {
+			t.Errorf("serializeQueryParam(%+v) = %q, expected %q", tt.param, got, tt.expected)
+		}
+	}
+
+	explodeTrue := true
+	for _, tt := range []struct {
+		param    parser.Parameter
+		expected string
+	}{
+		{parser.Parameter{Name: "ids", In: "path", Type: "array", ItemType: "integer"}, "1,2"},
+		{parser.Parameter{Name: "ids", In: "path", Type: "array", ItemType: "integer", Style: "label"}, ".1,2"},
+		{parser.Parameter{Name: "ids", In: "path", Type: "array", ItemType: "integer", Style: "matrix", Explode: &explodeTrue}, ";ids=1;ids=2"},
+	} {
+		if got := serializePathParam(tt.param); got != tt.expected {
+			t.Errorf("serializePathParam(%+v) = %q, expected %q", tt.param, got, tt.expected)
+		}
+	}
+
+	gen := New(&config.Config{}, &parser.API{BaseURL: "https://api.example.com"})
+	ep := parser.Endpoint{
+		Method: "GET",
+		Path:   "/orders",
+		Parameters: []parser.Parameter{
+			{Name: "ids", In: "query", Type: "array", ItemType: "integer", Explode: &explodeFalse},
+			{Name: "filter", In: "query", Type: "object", Style: "deepObject", Properties: []string{"status"}},
+		},
+	}
+	section := gen.generateEndpoint(ep)
+	for _, expected := range []string{
+		"| ids | query | array[integer] |  | Serialized as `ids=1,2` (style: form, explode: false). |",
+		"Serialized as `filter[status]=value` (style: deepObject, explode: false).",
+		`curl -X GET "https://api.example.com/orders?ids=1,2&filter%5Bstatus%5D=value"`,
+	} {
+		if !strings.Contains(section, expected) {
+			t.Errorf("expected %q in:\n%s", expected, section)
+		}
+	}
+}
+
+func TestNumericEnumExamples(t *testing.T) {
+	gen := New(&config.Config{}, &parser.API{})
+	schema := &parser.Schema{
+		Type: "object",
+		Properties: map[string]*parser.Schema{
+			"code":   {Type: "integer", Enum: []string{"404", "500"}},
+			"status": {Type: "string", Enum: []string{"open"}},
+		},
+	}
+	doc := gen.generateSchemaDoc(schema, 0)
+	for _, expected := range []string{`"code": 404`, `"status": "open"`, "Values: `404`, `500`"} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("expected %q in:\n%s", expected, doc)
+		}
+	}
+}
+
+func TestEnumDescriptionTables(t *testing.T) {
+	gen := New(&config.Config{}, &parser.API{})
+	ep := parser.Endpoint{
+		Method: "GET", Path: "/accounts", Summary: "List accounts",
+		Parameters: []parser.Parameter{
+			{Name: "status", In: "query", Type: "integer", Enum: []string{"1", "2"},
+				EnumDescriptions: map[string]string{"1": "ACTIVE — Account can sign in", "2": "SUSPENDED"}},
+			{Name: "sort", In: "query", Type: "string", Enum: []string{"asc", "desc"}},
+		},
+		RequestBody: &parser.RequestBody{Content: map[string]parser.MediaType{"application/json": {Schema: &parser.Schema{
+			Type: "object",
+			Properties: map[string]*parser.Schema{
+				"plan": {Type: "string", Enum: []string{"free", "pro"}, EnumDescriptions: map[string]string{"pro": "Paid | billed monthly"}},
+			},
+		}}}},
+	}
+	doc := gen.generateEndpoint(ep)
+	for _, expected := range []string{
+		"Values of `status`:\n\n| Value | Meaning |\n|-------|---------|\n| `1` | ACTIVE — Account can sign in |\n| `2` | SUSPENDED |\n",
+		"Values of `plan`:\n\n| Value | Meaning |\n|-------|---------|\n| `free` |  |\n| `pro` | Paid \\| billed monthly |\n",
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("expected %q in:\n%s", expected, doc)
+		}
+	}
+	if strings.Contains(doc, "Values of `sort`") || !strings.Contains(doc, "Enum: `asc`, `desc`") {
+		t.Error("enum without descriptions should stay a plain list")
+	}
+	if strings.Contains(doc, "Enum: `1`") || strings.Contains(doc, "Values: `free`") {
+		t.Errorf("values listed in a table should not repeat inline:\n%s", doc)
+	}
+}
+
+func TestDefaultValues(t *testing.T) {
+	gen := New(&config.Config{}, &parser.API{})
+	ep := parser.Endpoint{
+		Method: "POST", Path: "/pets", Summary: "Create pet",
+		Parameters: []parser.Parameter{
+			{Name: "limit", In: "query", Type: "integer", Default: 20.0, Description: "Page size"},
+			{Name: "id", In: "path", Type: "string", Required: true},
+		},
+		RequestBody: &parser.RequestBody{Content: map[string]parser.MediaType{"application/json": {Schema: &parser.Schema{
+			Type: "object",
+			Properties: map[string]*parser.Schema{
+				"status": {Type: "string", Description: "Pet status", Default: "available"},
+				"tags":   {Type: "array", Items: &parser.Schema{Type: "string"}, Default: []any{}},
+			},
+		}}}},
+	}
+	doc := gen.generateEndpoint(ep)
+	for _, expected := range []string{
+		"| Name | In | Type | Required | Default | Description |\n",
+		"| limit | query | integer |  | `20` | Page size |\n",
+		"| id | path | string | ✓ |  |  |\n",
+		"| status | string | Pet status Default: `available` |\n",
+		"| tags | array[string] |  Default: `[]` |\n",
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("expected %q in:\n%s", expected, doc)
+		}
+	}
+
+	// Без значений по умолчанию колонка не выводится
+	ep.Parameters = ep.Parameters[1:]
+	if doc := gen.generateEndpoint(ep); strings.Contains(doc, "| Default |") {
+		t.Errorf("unexpected Default column:\n%s", doc)
+	}
+}
+
+func TestDeprecationNote(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/v1/users", Deprecated: true, Extensions: map[string]any{
+				"x-deprecated-at": "2024-01-01",
+				"x-sunset":        "2025-06-30",
+				"x-alternative":   "listUsersV2",
+			}},
+			{Method: "GET", Path: "/v2/users", OperationID: "listUsersV2"},
+			{Method: "GET", Path: "/legacy", Extensions: map[string]any{"x-sunset": "2025-01-01"}},
+			{Method: "GET", Path: "/old", Deprecated: true},
+		},
+	}
+	gen := New(&config.Config{}, api)
+
+	section := gen.generateEndpoint(api.Endpoints[0])
+	expected := "> ⚠️ **Deprecated since 2024-01-01.** Stops working after 2025-06-30. Use [`GET /v2/users`](get-v2-users.txt#listusersv2) instead."
+	if !strings.Contains(section, expected) {
+		t.Errorf("expected deprecation note %q in:\n%s", expected, section)
+	}
+
+	// x-sunset без deprecated: true тоже помечает операцию устаревшей
+	section = gen.generateEndpoint(api.Endpoints[2])
+	if !strings.Contains(section, "⚠️ DEPRECATED") || !strings.Contains(section, "Stops working after 2025-01-01. Avoid it in new integrations.") {
+		t.Errorf("expected sunset note:\n%s", section)
+	}
+
+	// Одного deprecated: true достаточно для бейджа, заметка без подробностей не нужна
+	section = gen.generateEndpoint(api.Endpoints[3])
+	if !strings.Contains(section, "⚠️ DEPRECATED") || strings.Contains(section, "> ⚠️ **Deprecated") {
+		t.Errorf("unexpected deprecation output:\n%s", section)
+	}
+}
+
+func TestResponseLinks(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "POST", Path: "/users", Responses: map[string]parser.Response{
+				"201": {Description: "Created", Links: []parser.Link{
+					{Name: "GetUser", OperationID: "getUser", Parameters: map[string]string{"userId": "$response.body#/id"}},
+					{Name: "ListOrders", OperationRef: "#/paths/~1users~1{userId}~1orders/get",
+						Parameters: map[string]string{"userId": "$response.body#/id"}, Description: "Orders of the new user"},
+					{Name: "External", OperationRef: "https://example.com/openapi.yaml#/paths/~1x/get"},
+				}},
+			}},
+			{Method: "GET", Path: "/users/{userId}", OperationID: "getUser"},
+			{Method: "GET", Path: "/users/{userId}/orders"},
+		},
+	}
+	section := New(&config.Config{}, api).generateEndpoint(api.Endpoints[0])
+	expected := "Related operations:\n\n" +
+		"- [`GET /users/{userId}`](get-users-userId.txt#getuser): pass `id` from the response body as `userId`\n" +
+		"- [`GET /users/{userId}/orders`](get-users-userId-orders.txt#get-users-userid-orders): pass `id` from the response body as `userId` — Orders of the new user\n\n"
+	if !strings.Contains(section, expected) {
+		t.Errorf("expected related operations %q in:\n%s", expected, section)
+	}
+
+	for expr, expected := range map[string]string{
+		"$response.header.Location": "the `Location` response header",
+		"$request.path.id":          "the request's `id` path parameter",
+		"$response.body#/user/id":   "`user.id` from the response body",
+		"active":                    "`active`",
+	} {
+		if got := describeLinkExpression(expr); got != expected {
+			t.Errorf("describeLinkExpression(%q) = %q, expected %q", expr, got, expected)
+		}
+	}
+}
+
+func TestWorkflows(t *testing.T) {
+	api := &parser.API{
+		Title: "Test API",
+		Endpoints: []parser.Endpoint{
+			{Method: "POST", Path: "/reports", OperationID: "createReport", Tags: []string{"reports"}},
+			{Method: "GET", Path: "/reports/{id}", OperationID: "getReport", Tags: []string{"reports"}},
+		},
+		Workflows: []parser.Workflow{{
+			ID:      "buildReport",
+			Summary: "Build a report",
+			Inputs:  []string{"period"},
+			Steps: []parser.WorkflowStep{
+				{ID: "create", OperationID: "createReport", RequestBody: `{"period":"$inputs.period"}`,
+					SuccessCriteria: []string{"$statusCode == 202"}, Outputs: map[string]string{"id": "$response.body#/id"}},
+				{ID: "poll", OperationPath: "#/paths/~1reports~1{id}/get", Description: "Wait until the report is ready.",
+					Parameters: map[string]string{"id": "$steps.create.outputs.id"},
+					OnFailure:  []parser.WorkflowAction{{Type: "retry", RetryAfter: 5, RetryLimit: 10}}},
+			},
+			Outputs: map[string]string{"report": "$steps.poll.outputs.report"},
+		}},
+	}
+	dir := t.TempDir()
+	arazzo := filepath.Join(dir, "workflows.yaml")
+	content := "arazzo: 1.0.0\nworkflows:\n  - workflowId: cleanup\n    steps:\n      - stepId: delete\n        operationId: deleteReport\n"
+	if err := os.WriteFile(arazzo, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflows: %v", err)
+	}
+
+	gen := New(&config.Config{Output: filepath.Join(dir, "out"), Workflows: arazzo}, api)
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "out", "llms.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read llms.txt: %v", err)
+	}
+	index := string(data)
+
+	expected := "## Workflows\n\n### Build a report (`buildReport`)\n\nInputs: `period`\n\n" +
+		"1. **create** — [`POST /reports`](./endpoints/post-reports.txt#createreport)\n" +
+		"  - Pass `{\"period\":\"$inputs.period\"}` as the request body\n" +
+		"  - Succeeds when `$statusCode == 202`\n" +
+		"  - Saves `id` = `id` from the response body\n" +
+		"2. **poll** — [`GET /reports/{id}`](./endpoints/get-reports-id.txt#getreport): Wait until the report is ready.\n" +
+		"  - Pass `id` from step `create` as `id`\n" +
+		"  - On failure: retry after 5s, up to 10 times\n\n" +
+		"Result: `report` = `report` from step `poll`\n\n" +
+		"### cleanup (`cleanup`)\n\n1. **delete** — `deleteReport`\n\n"
+	if !strings.Contains(index, expected) {
+		t.Errorf("expected workflows %q in:\n%s", expected, index)
+	}
+	if strings.Index(index, "## Workflows") > strings.Index(index, "## Endpoints") {
+		t.Error("expected workflows before the endpoint list")
+	}
+
+	html := markdownToHTML("1. **create**\n  - Saves `id`\n2. **poll**\n")
+	if !strings.Contains(html, "<ol>\n<li><strong>create</strong></li>\n<ul>\n<li>Saves <code>id</code></li>\n</ul>\n<li>") {
+		t.Errorf("expected an ordered list with nested details, got:\n%s", html)
+	}
+}
+
+func TestAboutSection(t *testing.T) {
+	api := &parser.API{
+		Title:          "Test API",
+		Contact:        &parser.Contact{Name: "API Support", Email: "support@example.com", URL: "https://example.com/support"},
+		License:        &parser.License{Name: "MIT", URL: "https://opensource.org/licenses/MIT"},
+		TermsOfService: "https://example.com/terms",
+		Endpoints:      []parser.Endpoint{{Method: "GET", Path: "/users"}},
+	}
+	index := New(&config.Config{}, api).generateIndex(api.Endpoints)
+	expected := "## About\n\n" +
+		"- Contact: API Support, [support@example.com](mailto:support@example.com), <https://example.com/support>\n" +
+		"- License: [MIT](https://opensource.org/licenses/MIT)\n" +
+		"- Terms of service: <https://example.com/terms>\n\n"
+	if !strings.HasSuffix(index, expected) {
+		t.Errorf("expected about block %q at the end of:\n%s", expected, index)
+	}
+
+	ru := New(&config.Config{Language: config.LanguageRU}, &parser.API{Title: "API", License: &parser.License{Name: "MIT"}}).generateIndex(nil)
+	if !strings.Contains(ru, "## Об API\n\n- Лицензия: MIT\n") {
+		t.Errorf("expected localized about block in:\n%s", ru)
+	}
+
+	if about := New(&config.Config{}, &parser.API{Title: "API"}).generateAbout(); about != "" {
+		t.Errorf("expected no about block without contact info, got %q", about)
+	}
+}
+
+func TestNormalizeMarkdown(t *testing.T) {
+	tests := []struct {
+		name, input, base, expected string
+	}{
+		{"html inline", "Returns <b>all</b> users.<br>See <a href=\"https://example.com\">docs</a> &amp; <code>id</code>.",
+			"", "Returns **all** users.\nSee [docs](https://example.com) & `id`."},
+		{"paragraphs and lists", "<p>Intro</p><ul><li>one</li><li>two</li></ul>", "", "Intro\n\n- one\n- two"},
+		{"html table", "<table><tr><th>Code</th><th>Meaning</th></tr><tr><td>1</td><td>a | b</td></tr></table>",
+			"", "| Code | Meaning |\n|---|---|\n| 1 | a \\| b |"},
+		{"images", "Flow: ![diagram](flow.png) <img src=\"x.png\" alt=\"chart\"> <img src=\"y.png\">", "", "Flow: diagram chart"},
+		{"relative links", "See [guide](/docs/guide) and [faq](faq.md), [top](#top), [ext](https://x.io).",
+			"https://api.example.com/", "See [guide](https://api.example.com/docs/guide) and [faq](https://api.example.com/faq.md), [top](#top), [ext](https://x.io)."},
+		{"blank lines", "First  \n\n\n\n\nSecond\n", "", "First\n\nSecond"},
+		{"angle brackets kept", "Use GET /users/<id> or <https://example.com>", "", "Use GET /users/<id> or <https://example.com>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeMarkdown(tt.input, tt.base); got != tt.expected {
+				t.Errorf("normalizeMarkdown(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	api := &parser.API{
+		Title:       "Test API",
+		Description: "Short summary.\n\nLonger <em>details</em>.",
+		Endpoints: []parser.Endpoint{{Method: "GET", Path: "/users", Parameters: []parser.Parameter{
+			{Name: "q", In: "query", Description: "Search query.\n\nSupports <code>*</code> wildcards."},
+		}}},
+	}
+	gen := New(&config.Config{}, api)
+	if index := gen.generateIndex(api.Endpoints); !strings.Contains(index, "> Short summary.\n\nLonger _details_.\n\n") {
+		t.Errorf("expected quoted summary followed by details in:\n%s", index)
+	}
+	if section := gen.generateEndpoint(api.Endpoints[0]); !strings.Contains(section, "| Search query. Supports `*` wildcards. |") {
+		t.Errorf("expected a single-line parameter description in:\n%s", section)
+	}
+}
+
+func TestDescriptionTruncation(t *testing.T) {
+	long := "Creates a report for the given period. " + strings.Repeat("Lorem ipsum dolor sit amet. ", 20)
+	api := &parser.API{Title: "Test API", Endpoints: []parser.Endpoint{
+		{Method: "POST", Path: "/reports", Summary: "Create a report", Description: long,
+			ExternalDocs: &parser.ExternalDocs{URL: "https://docs.example.com/reports"}},
+		{Method: "GET", Path: "/reports", Summary: "List reports", Description: long},
+	}}
+	gen := New(&config.Config{MaxDescriptionLength: 50}, api)
+
+	section := gen.generateEndpoint(api.Endpoints[0])
+	if !strings.Contains(section, "## POST /reports - Create a report\n\nCreates a report for the given period. Lorem ipsum…(truncated, see external docs)\n\n") {
+		t.Errorf("expected truncated description with summary intact in:\n%s", section)
+	}
+	if section := gen.generateEndpoint(api.Endpoints[1]); !strings.Contains(section, "Lorem ipsum…(truncated)\n\n") {
+		t.Errorf("expected truncation marker without external docs in:\n%s", section)
+	}
+
+	if got := truncateDescription("Intro\n```\ncode code code", 15, false); got != "Intro\n```\ncode\n```\n…(truncated)" {
+		t.Errorf("expected the code block to be closed, got %q", got)
+	}
+	if got := truncateDescription(long, 0, false); got != long {
+		t.Error("expected no truncation without a limit")
+	}
+}
+
+func TestSearchIndex(t *testing.T) {
+	api := &parser.API{Title: "Test API", Endpoints: []parser.Endpoint{
+		{Method: "GET", Path: "/users/{userId}/orders", OperationID: "listUserOrders", Summary: "List orders of the user",
+			Tags: []string{"Orders"}, Parameters: []parser.Parameter{{Name: "userId", In: "path"}, {Name: "status", In: "query"}}},
+		{Method: "DELETE", Path: "/users/{userId}", OperationID: "delete_user", Tags: []string{"Users"}},
+	}}
+	dir := t.TempDir()
+	if err := New(&config.Config{Output: dir, SearchIndex: true}, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, SearchIndexFile))
+	if err != nil {
+		t.Fatalf("Failed to read search index: %v", err)
+	}
+	var index searchIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("Invalid search index: %v", err)
+	}
+
+	if len(index.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", index.Entries)
+	}
+	orders := index.Entries[slices.IndexFunc(index.Entries, func(e searchEntry) bool { return e.OperationID == "listUserOrders" })]
+	if orders.File != "endpoints/get-users-userId-orders.txt" || orders.Anchor != "listuserorders" {
+		t.Errorf("unexpected location %q#%q", orders.File, orders.Anchor)
+	}
+	expected := []string{"get", "list", "listuserorders", "orders", "status", "user", "userid", "users"}
+	if !slices.Equal(orders.Keywords, expected) {
+		t.Errorf("expected keywords %v, got %v", expected, orders.Keywords)
+	}
+	if !slices.Equal(orders.Safety, []string{"safe", "idempotent"}) {
+		t.Errorf("expected safe, idempotent labels, got %v", orders.Safety)
+	}
+	if users := index.Keywords["users"]; len(users) != 2 {
+		t.Errorf("expected both operations under \"users\", got %v", users)
+	}
+	if deletes := index.Keywords["delete"]; len(deletes) != 1 || index.Entries[deletes[0]].ID != "DELETE /users/{userId}" {
+		t.Errorf("expected the delete operation under \"delete\", got %v", deletes)
+	}
+
+	// В HTML запись указывает на страницу группы и якорь секции
+	htmlDir := t.TempDir()
+	if err := New(&config.Config{Output: htmlDir, Format: config.FormatHTML, SearchIndex: true}, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, _ = os.ReadFile(filepath.Join(htmlDir, SearchIndexFile))
+	if !strings.Contains(string(data), `"file": "groups/orders.html",
+      "anchor": "listuserorders"`) {
+		t.Errorf("expected HTML anchors in:\n%s", data)
+	}
+}
+
+func TestChunksFormat(t *testing.T) {
+	user := &parser.Schema{Type: "object", Ref: "#/components/schemas/User", Description: "A registered user.",
+		Properties: map[string]*parser.Schema{"id": {Type: "string"}, "name": {Type: "string"}}}
+	errSchema := &parser.Schema{Type: "object", Ref: "#/components/schemas/Error", Properties: map[string]*parser.Schema{"message": {Type: "string"}}}
+	api := &parser.API{Title: "Test API", Endpoints: []parser.Endpoint{
+		{Method: "GET", Path: "/users", OperationID: "listUsers", Tags: []string{"users"}, Responses: map[string]parser.Response{
+			"200": {Description: "OK", Content: map[string]parser.MediaType{"application/json": {Schema: &parser.Schema{Type: "array", Items: user}}}},
+			"404": {Description: "Not found", Content: map[string]parser.MediaType{"application/json": {Schema: errSchema}}},
+		}},
+		{Method: "GET", Path: "/users/{id}", Tags: []string{"users"}, Responses: map[string]parser.Response{
+			"200": {Description: "OK", Content: map[string]parser.MediaType{"application/json": {Schema: user}}},
+			"404": {Description: "Not found", Content: map[string]parser.MediaType{"application/json": {Schema: errSchema}}},
+		}},
+	}}
+	dir := t.TempDir()
+	if err := New(&config.Config{Output: dir, Format: config.FormatChunks}, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "llms.txt")); err == nil {
+		t.Error("expected no llms.txt in chunks format")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, ChunksFile))
+	if err != nil {
+		t.Fatalf("Failed to read chunks: %v", err)
+	}
+
+	var records []chunk
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var c chunk
+		if err := json.Unmarshal([]byte(line), &c); err != nil {
+			t.Fatalf("invalid JSONL line %q: %v", line, err)
+		}
+		records = append(records, c)
+	}
+	var ids []string
+	for _, c := range records {
+		ids = append(ids, c.ID)
+	}
+	expected := []string{"operation:listUsers", "operation:GET /users/{id}", "schema:Error", "schema:User"}
+	if !slices.Equal(ids, expected) {
+		t.Fatalf("expected chunks %v, got %v", expected, ids)
+	}
+
+	op := records[0]
+	if op.Kind != "operation" || op.Tag != "users" || op.Method != "GET" || op.Path != "/users" || op.Tokens == 0 {
+		t.Errorf("unexpected operation metadata %+v", op)
+	}
+	// Общая схема ошибки описывается в самом фрагменте, а не ссылкой на llms.txt
+	if strings.Contains(op.Text, "llms.txt") || !strings.Contains(op.Text, "message") {
+		t.Errorf("expected a self-contained chunk, got:\n%s", op.Text)
+	}
+	if schema := records[3]; schema.Kind != "schema" || !strings.HasPrefix(schema.Text, "## User\n\nA registered user.\n\n| Field |") {
+		t.Errorf("unexpected schema chunk %+v", schema)
+	}
+}
+
+func TestEndpointAnchors(t *testing.T) {
+	api := &parser.API{Title: "Test API", Endpoints: []parser.Endpoint{
+		{Method: "POST", Path: "/users", Deprecated: true, Extensions: map[string]any{"x-alternative": "GET /users/{id}"}},
+		{Method: "GET", Path: "/users/{id}"},
+	}}
+
+	// В одном файле llms.txt ссылки ведут на якоря заголовков
+	index := New(&config.Config{GroupBy: config.GroupByNone}, api).generateIndex(api.Endpoints)
+	for _, want := range []string{
+		"<a id=\"post-users\"></a>\n\n## POST /users",
+		"Use [`GET /users/{id}`](#get-users-id) instead.",
+		"<a id=\"get-users-id\"></a>\n\n## GET /users/{id}",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("expected %q in:\n%s", want, index)
+		}
+	}
+
+	// Фрагментам для RAG и HTML-страницам явные якоря не нужны
+	for _, format := range []string{config.FormatChunks, config.FormatHTML} {
+		if section := New(&config.Config{Format: format}, api).generateEndpoint(api.Endpoints[1]); strings.Contains(section, "<a id=") {
+			t.Errorf("expected no anchor line in %s output, got:\n%s", format, section)
+		}
+	}
+}
+
+func TestOutputLayout(t *testing.T) {
+	api := &parser.API{Title: "Test API", Endpoints: []parser.Endpoint{
+		{Method: "GET", Path: "/users", Responses: map[string]parser.Response{"401": {Description: "Unauthorized"}}},
+		{Method: "POST", Path: "/users", OperationID: "llms"},
+	}}
+	for _, tt := range []struct {
+		name, ext, dir, file, link, indexLink string
+	}{
+		{"markdown", "md", "", "endpoints/get-users.md", "./endpoints/get-users.md#get-users", "../llms.txt"},
+		{"custom dir", "", "docs/api", "docs/api/get-users.txt", "./docs/api/get-users.txt#get-users", "../../llms.txt"},
+		{"next to index", "", ".", "get-users.txt", "./get-users.txt#get-users", "llms.txt"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			gen := New(&config.Config{Output: dir, FileExtension: tt.ext, EndpointsDir: tt.dir}, api)
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(tt.file))); err != nil {
+				t.Errorf("expected %s: %v", tt.file, err)
+			}
+			index, _ := os.ReadFile(filepath.Join(dir, "llms.txt"))
+			if !strings.Contains(string(index), "("+tt.link+")") {
+				t.Errorf("expected link %s in:\n%s", tt.link, index)
+			}
+			if got := gen.indexLink(); got != tt.indexLink {
+				t.Errorf("indexLink() = %q, expected %q", got, tt.indexLink)
+			}
+			// Файл эндпоинта не должен перезаписать индекс
+			if !strings.HasPrefix(string(index), "# Test API") {
+				t.Errorf("llms.txt was overwritten:\n%s", index)
+			}
+		})
+	}
+
+	for _, cfg := range []config.Config{
+		{Source: "api.yaml", FileExtension: "html"},
+		{Source: "api.yaml", EndpointsDir: "../outside"},
+	} {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("expected validation error for %+v", cfg)
+		}
+	}
+}
+
+func TestPublishManifest(t *testing.T) {
+	api := &parser.API{Title: "Test API", Endpoints: []parser.Endpoint{{Method: "GET", Path: "/users"}}}
+	dir := t.TempDir()
+	if err := New(&config.Config{Output: dir, PublishManifest: true}, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, PublishManifestFile))
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	var m publishManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("Invalid manifest: %v", err)
+	}
+	var paths []string
+	for _, f := range m.Files {
+		paths = append(paths, f.Path)
+	}
+	if !slices.Equal(paths, []string{"endpoints/get-users.txt", "llms.txt"}) {
+		t.Errorf("unexpected manifest files %v", paths)
+	}
+	index, _ := os.ReadFile(filepath.Join(dir, "llms.txt"))
+	if f := m.Files[1]; f.Size != int64(len(index)) || len(f.SHA256) != 64 {
+		t.Errorf("unexpected llms.txt entry %+v", f)
+	}
+
+	if problems, err := Verify(dir); err != nil || len(problems) != 0 {
+		t.Fatalf("expected a clean verification, got %v, %v", problems, err)
+	}
+
+	// Подмена, удаление и лишний файл обнаруживаются
+	if err := os.WriteFile(filepath.Join(dir, "llms.txt"), []byte("# Tampered\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "endpoints", "get-users.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "extra.txt"), []byte("injected"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	problems, err := Verify(dir)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	expected := []VerifyProblem{
+		{"endpoints/get-users.txt", VerifyMissing},
+		{"extra.txt", VerifyUnexpected},
+		{"llms.txt", VerifyModified},
+	}
+	if !slices.Equal(problems, expected) {
+		t.Errorf("expected problems %v, got %v", expected, problems)
+	}
+
+	// Render включает манифест в набор файлов, чтобы check видел его изменения
+	files, err := New(&config.Config{Output: dir, PublishManifest: true}, api).Render()
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if _, ok := files[PublishManifestFile]; !ok {
+		t.Error("expected manifest.json among rendered files")
+	}
+}
+
+func TestAutoSummaries(t *testing.T) {
+	arrayResponse := map[string]parser.Response{"200": {Content: map[string]parser.MediaType{
+		"application/json": {Schema: &parser.Schema{Type: "array", Items: &parser.Schema{Type: "object"}}},
+	}}}
+	api := &parser.API{Title: "Test API", Endpoints: []parser.Endpoint{
+		{Method: "GET", Path: "/v1/users"},
+		{Method: "GET", Path: "/v1/users/{id}"},
+		{Method: "POST", Path: "/v1/categories"},
+		{Method: "PATCH", Path: "/v1/addresses/{id}"},
+		{Method: "POST", Path: "/v1/users/{id}/activate"},
+		{Method: "POST", Path: "/v1/things/{id}:cancel"},
+		{Method: "POST", Path: "/v1/things:batchGet"},
+		{Method: "GET", Path: "/v1/me"},
+		{Method: "GET", Path: "/v1/feed", Responses: arrayResponse},
+		{Method: "DELETE", Path: "/v1/order-items/{id}", Summary: "Remove an item"},
+	}}
+
+	gen := New(&config.Config{}, api)
+	endpoints := gen.sortEndpoints()
+	gen.fillSummaries(endpoints)
+	expected := map[string]string{
+		"GET /v1/users":                "Retrieve a list of users",
+		"GET /v1/users/{id}":           "Retrieve a user",
+		"POST /v1/categories":          "Create a category",
+		"PATCH /v1/addresses/{id}":     "Update an address",
+		"POST /v1/users/{id}/activate": "Activate a user",
+		"POST /v1/things/{id}:cancel":  "Cancel a thing",
+		"POST /v1/things:batchGet":     "Batch get things",
+		"GET /v1/me":                   "Retrieve me",
+		"GET /v1/feed":                 "Retrieve a list of feed",
+		"DELETE /v1/order-items/{id}":  "Remove an item",
+	}
+	for _, ep := range endpoints {
+		if want := expected[ep.Method+" "+ep.Path]; ep.Summary != want {
+			t.Errorf("%s %s: expected summary %q, got %q", ep.Method, ep.Path, want, ep.Summary)
+		}
+	}
+
+	// Синтезированные summary попадают в индекс, с флагом отключения — нет
+	dir := t.TempDir()
+	if err := New(&config.Config{Output: dir}, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	index, _ := os.ReadFile(filepath.Join(dir, "llms.txt"))
+	if !strings.Contains(string(index), " — Retrieve a list of users") {
+		t.Errorf("expected synthesized summary in index:\n%s", index)
+	}
+	dir = t.TempDir()
+	if err := New(&config.Config{Output: dir, NoAutoSummaries: true}, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	index, _ = os.ReadFile(filepath.Join(dir, "llms.txt"))
+	if strings.Contains(string(index), "Retrieve a list of users") {
+		t.Errorf("expected no synthesized summaries with NoAutoSummaries:\n%s", index)
+	}
+	if api.Endpoints[0].Summary != "" {
+		t.Error("synthesized summaries must not modify the parsed API")
+	}
+}
+
+func TestOverrides(t *testing.T) {
+	api := &parser.API{Title: "Test API", Endpoints: []parser.Endpoint{
+		{
+			Method: "POST", Path: "/users", OperationID: "createUser", Summary: "Create user", Description: "Creates a user.",
+			RequestBody: &parser.RequestBody{Content: map[string]parser.MediaType{
+				"application/json": {Schema: &parser.Schema{Type: "object", Properties: map[string]*parser.Schema{"email": {Type: "string"}}}},
+			}},
+			Responses: map[string]parser.Response{"201": {Description: "Created", Content: map[string]parser.MediaType{
+				"application/json": {Schema: &parser.Schema{Type: "object", Properties: map[string]*parser.Schema{"id": {Type: "integer"}}}},
+			}}},
+		},
+		{Method: "GET", Path: "/users", Summary: "List"},
+	}}
+	dir := t.TempDir()
+	overrides := filepath.Join(dir, "overrides.yaml")
+	os.WriteFile(overrides, []byte(`operations:
+  createUser:
+    appendDescription: Emails must be unique.
+    notes:
+      - Rate limited to 10 requests per minute.
+    examples:
+      request: {email: jane@example.com}
+      responses:
+        "201": {id: 42}
+  get /users:
+    summary: List users
+`), 0644)
+
+	out := filepath.Join(dir, "out")
+	if err := New(&config.Config{Output: out, Overrides: overrides}, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(out, "endpoints", "post-users.txt"))
+	content := string(data)
+	for _, want := range []string{
+		"Creates a user.\n\nEmails must be unique.",
+		"### Notes\n\n- Rate limited to 10 requests per minute.\n",
+		"\"email\": \"jane@example.com\"",
+		"```json\n{\n  \"id\": 42\n}\n```",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in:\n%s", want, content)
+		}
+	}
+	index, _ := os.ReadFile(filepath.Join(out, "llms.txt"))
+	if !strings.Contains(string(index), "— List users") {
+		t.Errorf("expected overridden summary in index:\n%s", index)
+	}
+	if api.Endpoints[0].Description != "Creates a user." {
+		t.Error("overrides must not modify the parsed API")
+	}
+
+	// Ключ без операции — ошибка, а не тихо проигнорированная правка
+	os.WriteFile(overrides, []byte("operations:\n  deleteUser:\n    description: x\n"), 0644)
+	if err := New(&config.Config{Output: out, Overrides: overrides}, api).Generate(); err == nil || !strings.Contains(err.Error(), "deleteUser") {
+		t.Errorf("expected error for unmatched override key, got %v", err)
+	}
+}
+
+func TestVersionedOutput(t *testing.T) {
+	if dir, err := VersionDir(" v1.2.0 (beta) "); err != nil || dir != "v1.2.0-beta" {
+		t.Errorf("unexpected version dir %q, %v", dir, err)
+	}
+	if _, err := VersionDir(""); err == nil {
+		t.Error("expected error for empty version")
+	}
+
+	ordered := []string{"2.0.0", "1.10.0", "1.9.2", "1.9.2-beta", "1.9"}
+	for i := 1; i < len(ordered); i++ {
+		if compareVersions(ordered[i-1], ordered[i]) <= 0 {
+			t.Errorf("expected %s > %s", ordered[i-1], ordered[i])
+		}
+	}
+
+	root := t.TempDir()
+	api := &parser.API{Title: "Test API", Endpoints: []parser.Endpoint{{Method: "GET", Path: "/users", Summary: "List users"}}}
+	for _, version := range []string{"1.10.0", "1.9.2"} {
+		if err := New(&config.Config{Output: filepath.Join(root, version)}, api).Generate(); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if err := UpdateVersionIndex(root, "Test API"); err != nil {
+			t.Fatalf("UpdateVersionIndex failed: %v", err)
+		}
+	}
+
+	// latest указывает на старшую версию, даже если последней перегенерирована младшая
+	if target, err := os.Readlink(filepath.Join(root, LatestLink)); err != nil || target != "1.10.0" {
+		t.Errorf("expected latest -> 1.10.0, got %q, %v", target, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, LatestLink, "llms.txt")); err != nil {
+		t.Errorf("expected llms.txt through latest link: %v", err)
+	}
+	index, _ := os.ReadFile(filepath.Join(root, VersionsFile))
+	expected := "# Test API versions\n\n- [1.10.0](./1.10.0/llms.txt) — latest, also at [./latest/llms.txt](./latest/llms.txt)\n- [1.9.2](./1.9.2/llms.txt)\n"
+	if string(index) != expected {
+		t.Errorf("unexpected versions index:\n%s", index)
+	}
+}
+
+func TestSchemaGlossary(t *testing.T) {
+	api := &parser.API{
+		Title:     "Test API",
+		Endpoints: []parser.Endpoint{{Method: "GET", Path: "/users", Summary: "List users"}},
+		Schemas: map[string]*parser.Schema{
+			"User": {
+				Type:        "object",
+				Description: "A registered user.\n\nUsers are created on sign-up.",
+				Required:    []string{"id"},
+				Properties: map[string]*parser.Schema{
+					"id":      {Type: "string"},
+					"role":    {Type: "string", Enum: []string{"admin", "member"}},
+					"address": {Ref: "#/components/schemas/Address", Type: "object"},
+					"tags":    {Type: "array", Items: &parser.Schema{Type: "string"}},
+				},
+			},
+			"Address": {Type: "object", Properties: map[string]*parser.Schema{"city": {Type: "string"}}},
+		},
+	}
+
+	dir := t.TempDir()
+	if err := New(&config.Config{Output: dir, SchemaGlossary: true}, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	glossary, err := os.ReadFile(filepath.Join(dir, "schemas.txt"))
+	if err != nil {
+		t.Fatalf("expected schemas.txt: %v", err)
+	}
+	for _, want := range []string{
+		"# Test API — Schemas\n\n",
+		"## Address\n\nFields: `city` string\n",
+		"## User\n\nA registered user.\n\nFields: `address` Address, `id`* string, `role` string (admin | member), `tags` array[string]\n",
+	} {
+		if !strings.Contains(string(glossary), want) {
+			t.Errorf("schemas.txt missing %q, got:\n%s", want, glossary)
+		}
+	}
+	if strings.Contains(string(glossary), "created on sign-up") {
+		t.Error("glossary should keep only the first description paragraph")
+	}
+	index, _ := os.ReadFile(filepath.Join(dir, "llms.txt"))
+	if !strings.Contains(string(index), "- [Schemas](./schemas.txt): 2 named schemas with their fields") {
+		t.Errorf("llms.txt should link the glossary, got:\n%s", index)
+	}
+
+	for _, cfg := range []*config.Config{{Output: t.TempDir()}, {Output: t.TempDir(), SchemaGlossary: true, Format: "html"}} {
+		if err := New(cfg, api).Generate(); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(cfg.Output, "schemas.txt")); err == nil {
+			t.Errorf("unexpected schemas.txt for config %+v", cfg)
+		}
+	}
+}
+
+func TestChangelogLink(t *testing.T) {
+	api := &parser.API{Title: "Test API", Endpoints: []parser.Endpoint{{Method: "GET", Path: "/users", Summary: "List users"}}}
+	dir := t.TempDir()
+	cfg := &config.Config{Output: dir, Incremental: true}
+	if err := New(cfg, api).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	index, _ := os.ReadFile(filepath.Join(dir, "llms.txt"))
+	if strings.Contains(string(index), "Changelog") {
+		t.Errorf("llms.txt should not link a missing changelog, got:\n%s", index)
+	}
+
+	changelog := "## Changelog\n\nChanges since v1:\n"
+	if err := os.WriteFile(filepath.Join(dir, ChangelogFile), []byte(changelog), 0644); err != nil {
+		t.Fatalf("Failed to write changelog: %v", err)
+	}
+	gen := New(cfg, api)
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	index, _ = os.ReadFile(filepath.Join(dir, "llms.txt"))
+	if !strings.Contains(string(index), "## Changelog\n\n- [Changelog](./changelog.txt): API changes since an earlier spec revision\n") {
+		t.Errorf("llms.txt should link the changelog, got:\n%s", index)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, ChangelogFile)); err != nil || string(data) != changelog {
+		t.Errorf("generation should keep changelog.txt, got %q (%v)", data, err)
+	}
+
+	// Журнал не принадлежит генератору: сверка с выводом не видит расхождений
+	files, err := gen.Render()
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	changes, err := Changes(dir, files)
+	if err != nil || len(changes) != 0 {
+		t.Errorf("expected no changes with a changelog, got %+v (%v)", changes, err)
+	}
+}
+
+func TestGenerateContextCanceled(t *testing.T) {
+	api := &parser.API{Title: "Test API", Endpoints: []parser.Endpoint{
+		{Method: "GET", Path: "/users", Summary: "List users"},
+		{Method: "POST", Path: "/users", Summary: "Create user"},
+	}}
+	output := t.TempDir()
+
+	// Отмена после первого файла: остальные не записываются
+	ctx, cancel := context.WithCancel(context.Background())
+	gen := New(&config.Config{Output: output}, api)
+	gen.SetProgress(func(p Progress) {
+		if p.Files == 1 {
+			cancel()
+		}
+	})
+	err := gen.GenerateContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Join(output, "endpoints"))
+	if len(entries) != 1 {
+		t.Errorf("expected 1 endpoint file before cancellation, got %d", len(entries))
+	}
+	if _, err := os.Stat(filepath.Join(output, "llms.txt")); !os.IsNotExist(err) {
+		t.Errorf("llms.txt must not be written after cancellation: %v", err)
+	}
+
+	// Отменённый контекст не влияет на следующий запуск
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate after cancellation failed: %v", err)
+	}
+}
+
+// largeAPI строит синтетический API из n операций с параметрами, телом запроса и ответами
+func largeAPI(n int) *parser.API {
+	item := &parser.Schema{Type: "object", Required: []string{"id"}, Properties: map[string]*parser.Schema{
+		"id":         {Type: "integer", Description: "Identifier"},
+		"name":       {Type: "string", Description: "Display name"},
+		"status":     {Type: "string", Enum: []string{"active", "archived"}},
+		"created_at": {Type: "string", Format: "date-time"},
+	}}
+	api := &parser.API{Title: "Large API", Version: "1.0.0", BaseURL: "https://api.example.com"}
+	for i := range n {
+		resource := fmt.Sprintf("resource%d", i/4)
+		path := "/" + resource + "/{id}"
+		ep := parser.Endpoint{
+			Method:      []string{"GET", "PUT", "PATCH", "DELETE"}[i%4],
+			Path:        path,
+			OperationID: fmt.Sprintf("operation%d", i),
+			Summary:     fmt.Sprintf("Operation %d on %s", i, resource),
+			Description: strings.Repeat("Describes the operation in enough detail for an agent to call it. ", 3),
+			Tags:        []string{fmt.Sprintf("tag%d", i/100)},
+			Parameters: []parser.Parameter{
+				{Name: "id", In: "path", Type: "integer", Required: true, Description: "Identifier"},
+				{Name: "expand", In: "query", Type: "string", Description: "Related objects to include"},
+			},
+			Responses: map[string]parser.Response{
+				"200": {Description: "OK", Content: map[string]parser.MediaType{"application/json": {Schema: item}}},
+				"404": {Description: "Not found"},
+			},
+		}
+		if ep.Method == "PUT" || ep.Method == "PATCH" {
+			ep.RequestBody = &parser.RequestBody{Required: true, Content: map[string]parser.MediaType{"application/json": {Schema: item}}}
+		}
+		api.Endpoints = append(api.Endpoints, ep)
+	}
+	return api
+}
+
+// measurePeakHeap выполняет fn и возвращает максимальный объём живых объектов кучи за время выполнения
+func measurePeakHeap(fn func()) uint64 {
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	var peak atomic.Uint64
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(time.Millisecond)
+		defer ticker.Stop()
+		for {
+			metrics.Read(sample)
+			if v := sample[0].Value.Uint64(); v > peak.Load() {
+				peak.Store(v)
+			}
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	fn()
+	close(done)
+	<-stopped
+	return peak.Load()
+}
+
+// BenchmarkGenerateLarge сравнивает пиковый объём кучи при генерации 10k операций в память (Render)
+// и потоковой записи на диск (Generate); в плоском режиме все операции попадают в один llms.txt
+func BenchmarkGenerateLarge(b *testing.B) {
+	api := largeAPI(10000)
+	for _, groupBy := range []string{config.GroupByTag, config.GroupByNone} {
+		b.Run("render-in-memory/"+groupBy, func(b *testing.B) {
+			b.ReportAllocs()
+			var peak uint64
+			for b.Loop() {
+				runtime.GC()
+				peak = max(peak, measurePeakHeap(func() {
+					if _, err := New(&config.Config{Output: b.TempDir(), GroupBy: groupBy}, api).Render(); err != nil {
+						b.Fatal(err)
+					}
+				}))
+			}
+			b.ReportMetric(float64(peak)/(1<<20), "peak-heap-MB")
+		})
+		b.Run("stream-to-disk/"+groupBy, func(b *testing.B) {
+			b.ReportAllocs()
+			var peak uint64
+			for b.Loop() {
+				runtime.GC()
+				peak = max(peak, measurePeakHeap(func() {
+					if err := New(&config.Config{Output: b.TempDir(), GroupBy: groupBy}, api).Generate(); err != nil {
+						b.Fatal(err)
+					}
+				}))
+			}
+			b.ReportMetric(float64(peak)/(1<<20), "peak-heap-MB")
+		})
+	}
+}
+
+// BenchmarkRenderFormats измеряет рендеринг 8000 операций в каждом встроенном формате без записи на диск
+func BenchmarkRenderFormats(b *testing.B) {
+	api := largeAPI(8000)
+	for _, format := range []string{config.FormatText, config.FormatHTML, config.FormatJSON, config.FormatChunks} {
+		b.Run(format, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := New(&config.Config{Output: "llms", Format: format}, api).Render(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// updateGolden перезаписывает ожидаемый вывод: go test ./internal/generator -run TestGolden -update
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata/golden")
+
+// TestGolden прогоняет полный конвейер (конфиг, разбор, генерация) на спецификациях из
+// testdata/golden/<случай>/openapi.yaml и сравнивает вывод с want/. Необязательный config.json
+// рядом со спецификацией задаёт настройки случая
+func TestGolden(t *testing.T) {
+	cases, err := filepath.Glob(filepath.Join("testdata", "golden", "*"))
+	if err != nil || len(cases) == 0 {
+		t.Fatalf("no golden cases found: %v", err)
+	}
+	for _, dir := range cases {
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			if _, err := os.Stat(filepath.Join(dir, "config.json")); err == nil {
+				if cfg, err = config.LoadFromFile(filepath.Join(dir, "config.json")); err != nil {
+					t.Fatal(err)
+				}
+			}
+			cfg.Source = filepath.Join(dir, "openapi.yaml")
+			cfg.Output = "llms"
+			if err := cfg.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			api, err := parser.Parse(cfg.Source, &parser.ParseOptions{SkipValidation: cfg.SkipValidation, BestEffort: cfg.BestEffort, Offline: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			files, err := New(cfg, api).Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := filepath.Join(dir, "want")
+			if *updateGolden {
+				if err := os.RemoveAll(want); err != nil {
+					t.Fatal(err)
+				}
+				for rel, data := range files {
+					path := filepath.Join(want, filepath.FromSlash(rel))
+					if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(path, data, 0644); err != nil {
+						t.Fatal(err)
+					}
+				}
+				return
+			}
+
+			expected := map[string]string{}
+			err = filepath.WalkDir(want, func(path string, d fs.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				rel, _ := filepath.Rel(want, path)
+				expected[filepath.ToSlash(rel)] = string(data)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("failed to read golden files (run with -update to create them): %v", err)
+			}
+
+			for rel, data := range files {
+				if golden, ok := expected[rel]; !ok {
+					t.Errorf("unexpected file %s (run with -update to accept)", rel)
+				} else if golden != string(data) {
+					t.Errorf("%s differs from golden file (run with -update to accept):\n%s", rel, textdiff.Unified("want/"+rel, "got/"+rel, golden, string(data)))
+				}
+			}
+			for rel := range expected {
+				if _, ok := files[rel]; !ok {
+					t.Errorf("missing file %s (run with -update to accept)", rel)
+				}
+			}
+		})
+	}
+}
+
+// fuzzSchema строит дерево схем модели по байтам: младшие три бита выбирают операцию над текущим
+// узлом, старшие — тип, формат или пример нового узла. Модель после разбора ациклическая,
+// поэтому циклов в дереве нет, а глубина вложенности ограничена только длиной входа
+func fuzzSchema(data []byte) *parser.Schema {
+	types := []string{"object", "array", "string", "integer", "number", "boolean", "null", "", "weird"}
+	formats := []string{"", "date-time", "date", "email", "uri", "int64", "binary"}
+	examples := []any{nil, "quote\"d", 1.5, false, map[string]any{"k": []any{nil}}, []any{}, " "}
+	root := &parser.Schema{Type: "object"}
+	stack := []*parser.Schema{root}
+	for i, b := range data {
+		current := stack[len(stack)-1]
+		child := &parser.Schema{Type: types[int(b>>3)%len(types)], Format: formats[int(b>>4)%len(formats)]}
+		switch b % 8 {
+		case 0, 1:
+			if current.Properties == nil {
+				current.Properties = map[string]*parser.Schema{}
+			}
+			current.Properties[fmt.Sprintf("p%d", i)] = child
+			stack = append(stack, child)
+		case 2:
+			current.Items = child
+			stack = append(stack, child)
+		case 3:
+			current.Example = examples[int(b>>3)%len(examples)]
+		case 4:
+			current.Enum = append(current.Enum, fmt.Sprintf("v%d", b>>3), "\"")
+		case 5:
+			current.Required = append(current.Required, fmt.Sprintf("p%d", i-1))
+		case 6, 7:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	return root
+}
+
+func FuzzRenderJSONSchema(f *testing.F) {
+	f.Add([]byte{0, 2, 0, 6, 3}, uint8(4), uint8(0))
+	f.Add([]byte{2, 2, 2, 2, 0x13, 0x23}, uint8(1), uint8(2))
+	f.Add([]byte{0, 0, 0, 0, 0, 0, 0x0c, 0x1b}, uint8(2), uint8(1))
+	f.Fuzz(func(t *testing.T, data []byte, maxDepth, maxProps uint8) {
+		gen := New(&config.Config{}, &parser.API{})
+		schema := fuzzSchema(data)
+		example := gen.renderJSONSchema(schema, 0, int(maxDepth%8)+1, int(maxProps%4))
+		// Без ограничения числа полей пример — валидный JSON; с ограничением в нём есть комментарий
+		if maxProps%4 == 0 && !json.Valid([]byte(example)) {
+			t.Fatalf("invalid JSON example:\n%s", example)
+		}
+		gen.generateSchemaDoc(schema, 0)
+	})
+}
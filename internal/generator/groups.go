@@ -0,0 +1,180 @@
+package generator
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/config"
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// endpointGroup — группа эндпоинтов в индексе
+type endpointGroup struct {
+	Name         string
+	Title        string // отображаемое имя: переопределение из конфига или Name
+	Description  string
+	ExternalDocs *parser.ExternalDocs
+	Endpoints    []parser.Endpoint
+}
+
+// versionSegment совпадает с сегментами версии пути (v1, v1.4)
+var versionSegment = regexp.MustCompile(`^v\d+(\.\d+)*$`)
+
+// groupName возвращает имя группы эндпоинта согласно groupBy.
+// Пустая строка означает, что эндпоинт не относится ни к одной группе
+func (g *Generator) groupName(ep parser.Endpoint) string {
+	switch g.cfg.GroupBy {
+	case config.GroupByPath:
+		for _, segment := range strings.Split(ep.Path, "/") {
+			if segment == "" || strings.HasPrefix(segment, "{") || versionSegment.MatchString(segment) {
+				continue
+			}
+			return segment
+		}
+		return ""
+	case config.GroupByXGroup:
+		if group, ok := ep.Extensions["x-group"].(string); ok && group != "" {
+			return group
+		}
+	}
+
+	if len(ep.Tags) > 0 {
+		return ep.Tags[0]
+	}
+	return ""
+}
+
+// groupEndpoints раскладывает отсортированные эндпоинты по группам.
+// Группы-теги идут в порядке объявления в спецификации, остальные — в порядке появления
+func (g *Generator) groupEndpoints(endpoints []parser.Endpoint) []endpointGroup {
+	index := make(map[string]int)
+	var groups []endpointGroup
+
+	add := func(name string) int {
+		if i, ok := index[name]; ok {
+			return i
+		}
+		group := endpointGroup{Name: name, Title: g.groupTitle(name)}
+		for _, tag := range g.api.Tags {
+			if tag.Name == name {
+				group.Description = tag.Description
+				group.ExternalDocs = tag.ExternalDocs
+				break
+			}
+		}
+		if override := g.cfg.Tags[name]; override.Description != "" {
+			group.Description = override.Description
+		}
+		groups = append(groups, group)
+		index[name] = len(groups) - 1
+		return index[name]
+	}
+
+	if g.cfg.GroupBy == "" || g.cfg.GroupBy == config.GroupByTag {
+		for _, ep := range endpoints {
+			if len(ep.Tags) > 0 {
+				// Регистрируем объявленные теги заранее, чтобы сохранить их порядок
+				for _, tag := range g.api.Tags {
+					add(tag.Name)
+				}
+				break
+			}
+		}
+	}
+
+	for _, ep := range endpoints {
+		i := add(g.groupName(ep))
+		groups[i].Endpoints = append(groups[i].Endpoints, ep)
+	}
+
+	// Убираем объявленные, но пустые группы
+	result := groups[:0]
+	for _, group := range groups {
+		if len(group.Endpoints) > 0 {
+			result = append(result, group)
+		}
+	}
+
+	// Группы с order из конфига идут первыми по возрастанию, остальные сохраняют порядок
+	sort.SliceStable(result, func(i, j int) bool {
+		oi, oj := g.cfg.Tags[result[i].Name].Order, g.cfg.Tags[result[j].Name].Order
+		switch {
+		case oi != 0 && oj != 0:
+			return oi < oj
+		default:
+			return oi != 0 && oj == 0
+		}
+	})
+	return result
+}
+
+// groupTitle возвращает отображаемое имя группы с учётом переопределения tags.<имя>.title
+func (g *Generator) groupTitle(name string) string {
+	if title := g.cfg.Tags[name].Title; title != "" {
+		return title
+	}
+	return name
+}
+
+// groupPathPrefix возвращает общий префикс путей (по сегментам) всех операций группы
+func (g *Generator) groupPathPrefix(group string) string {
+	if prefix, ok := g.pathPrefixes[group]; ok {
+		return prefix
+	}
+
+	var prefix []string
+	first := true
+	for _, ep := range g.api.Endpoints {
+		if g.groupName(ep) != group {
+			continue
+		}
+		segments := strings.Split(strings.TrimPrefix(ep.Path, "/"), "/")
+		if first {
+			prefix, first = segments, false
+			continue
+		}
+		n := 0
+		for n < len(prefix) && n < len(segments) && prefix[n] == segments[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+
+	if g.pathPrefixes == nil {
+		g.pathPrefixes = make(map[string]string)
+	}
+	g.pathPrefixes[group] = "/" + strings.Join(prefix, "/")
+	return g.pathPrefixes[group]
+}
+
+// isOptionalGroup сообщает, что группа помечена как необязательная: в optionalTags
+// конфига или расширением x-llms-optional: true у тега
+func (g *Generator) isOptionalGroup(name string) bool {
+	if name == "" {
+		return false
+	}
+	if slices.Contains(g.cfg.OptionalTags, name) {
+		return true
+	}
+	for _, tag := range g.api.Tags {
+		if tag.Name == name {
+			optional, _ := tag.Extensions["x-llms-optional"].(bool)
+			return optional
+		}
+	}
+	return false
+}
+
+// splitOptionalGroups разделяет группы на обязательные и необязательные, сохраняя порядок
+func (g *Generator) splitOptionalGroups(groups []endpointGroup) (primary, optional []endpointGroup) {
+	for _, group := range groups {
+		if g.isOptionalGroup(group.Name) {
+			optional = append(optional, group)
+		} else {
+			primary = append(primary, group)
+		}
+	}
+	return primary, optional
+}
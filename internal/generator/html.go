@@ -0,0 +1,350 @@
+package generator
+
+import (
+	"fmt"
+	"html"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/config"
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// htmlStyle — минимальные стили статического сайта и подсветки кода
+const htmlStyle = `body{font-family:-apple-system,Segoe UI,Helvetica,Arial,sans-serif;max-width:960px;margin:2rem auto;padding:0 1rem;line-height:1.5;color:#1f2328}
+a{color:#0969da}
+code{font-family:SFMono-Regular,Consolas,monospace;background:#f6f8fa;padding:.1em .3em;border-radius:4px}
+pre{background:#f6f8fa;padding:1rem;overflow:auto;border-radius:6px}
+pre code{padding:0;background:none}
+table{border-collapse:collapse;margin:1rem 0}
+th,td{border:1px solid #d0d7de;padding:.3rem .6rem;text-align:left;vertical-align:top}
+blockquote{margin:0;padding:0 1rem;color:#59636e;border-left:.25em solid #d0d7de}
+section{border-top:1px solid #d0d7de;margin-top:2rem}
+.k{color:#0550ae}.s{color:#0a3069}.n{color:#953800}.l{color:#cf222e}.c{color:#6e7781;font-style:italic}.f{color:#8250df}`
+
+// isHTML сообщает, что вместо llms.txt генерируется статический HTML-сайт для ревью
+func (g *Generator) isHTML() bool {
+	return g.cfg.Format == config.FormatHTML
+}
+
+// groupPageName возвращает имя HTML-страницы группы
+func (g *Generator) groupPageName(group string) string {
+	if name, ok := g.groupPages[group]; ok {
+		return name
+	}
+	return g.baseGroupPageName(group)
+}
+
+// baseGroupPageName возвращает имя страницы группы без учёта коллизий
+func (g *Generator) baseGroupPageName(group string) string {
+	if group == "" {
+		return "other.html"
+	}
+	if filename := cleanFilename(g.cfg.Tags[group].Filename); filename != "" {
+		return filename + ".html"
+	}
+	if name := sanitizeFilename(group); name != "" {
+		return name + ".html"
+	}
+	return "other.html"
+}
+
+// endpointAnchor возвращает стабильный якорь эндпоинта: id секции на странице группы
+// и якорь заголовка в текстовом выводе. Зависит только от operationId или метода и пути
+func endpointAnchor(ep parser.Endpoint) string {
+	words := strings.Fields(strings.NewReplacer("/", " ", "{", "", "}", "").Replace(endpointID(ep)))
+	return slugify(strings.Join(words, " "))
+}
+
+// headingAnchor ставит перед заголовком операции явный якорь, чтобы на операцию можно было
+// сослаться из индекса и других файлов. В HTML якорем служит id секции, фрагментам для RAG он не нужен
+func (g *Generator) headingAnchor(ep parser.Endpoint) string {
+	if g.isHTML() || g.cfg.Format == config.FormatChunks {
+		return ""
+	}
+	return `<a id="` + endpointAnchor(ep) + `"></a>` + "\n\n"
+}
+
+// generateHTML генерирует index.html и по странице на каждую группу эндпоинтов
+func (g *Generator) generateHTML(endpoints []parser.Endpoint) error {
+	title := g.cfg.Title
+	if title == "" {
+		title = g.api.Title
+	}
+
+	if !g.isFlat() {
+		groupsDir := filepath.Join(g.cfg.Output, "groups")
+		if err := g.mkdirAll(groupsDir); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		groups := g.groupEndpoints(endpoints)
+		progress := &progressTracker{fn: g.progress, state: Progress{TotalFiles: len(groups), TotalGroups: len(groups)}}
+		for _, group := range groups {
+			name := group.Title
+			if name == "" {
+				name = g.heading("Other")
+			}
+
+			var body strings.Builder
+			body.WriteString(`<p><a href="../index.html">← ` + html.EscapeString(title) + "</a></p>\n")
+			body.WriteString(markdownToHTML("# " + name + "\n\n" + g.description(group.Description)))
+			for _, ep := range group.Endpoints {
+				body.WriteString(`<section id="` + endpointAnchor(ep) + `">` + "\n")
+				body.WriteString(markdownToHTML(g.wrapSection("operation", endpointID(ep), g.generateEndpoint(ep))))
+				body.WriteString("</section>\n")
+			}
+
+			path := filepath.Join(groupsDir, g.groupPageName(group.Name))
+			if err := g.writeFile(path, []byte(htmlPage(name+" — "+title, body.String()))); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			progress.groupDone()
+		}
+	} else if err := g.mkdirAll(g.cfg.Output); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	indexPath := filepath.Join(g.cfg.Output, "index.html")
+	index := htmlPage(title, markdownToHTML(g.generateIndex(endpoints)))
+	if err := g.writeFile(indexPath, []byte(index)); err != nil {
+		return fmt.Errorf("failed to write index.html: %w", err)
+	}
+	return nil
+}
+
+// htmlPage оборачивает тело страницы в HTML-документ
+func htmlPage(title, body string) string {
+	return "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>" + html.EscapeString(title) +
+		"</title>\n<style>\n" + htmlStyle + "\n</style>\n</head>\n<body>\n" + body + "</body>\n</html>\n"
+}
+
+var (
+	headingLine  = regexp.MustCompile(`^(#{1,6}) (.*)$`)
+	listLine     = regexp.MustCompile(`^( *)(-|\d+\.) (.*)$`)
+	tableDivider = regexp.MustCompile(`^\|[-:| ]+\|$`)
+)
+
+// markdownToHTML переводит в HTML подмножество Markdown, которое порождает генератор:
+// заголовки, абзацы, цитаты, списки, таблицы, блоки кода и HTML-комментарии маркеров
+func markdownToHTML(md string) string {
+	var sb strings.Builder
+	lines := strings.Split(md, "\n")
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+
+		case strings.HasPrefix(line, "```"):
+			lang := strings.TrimPrefix(line, "```")
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(lines[i], "```"); i++ {
+				code = append(code, lines[i])
+			}
+			i++ // закрывающий ```
+			class := ""
+			if lang != "" {
+				class = ` class="language-` + html.EscapeString(lang) + `"`
+			}
+			sb.WriteString("<pre><code" + class + ">" + highlightCode(lang, strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case strings.HasPrefix(line, "<!--"):
+			sb.WriteString(line + "\n")
+			i++
+
+		case headingLine.MatchString(line):
+			m := headingLine.FindStringSubmatch(line)
+			level := len(m[1])
+			sb.WriteString(fmt.Sprintf("<h%d id=\"%s\">%s</h%d>\n", level, slugify(m[2]), inlineHTML(m[2]), level))
+			i++
+
+		case strings.HasPrefix(line, "> "):
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(lines[i], "> "); i++ {
+				quote = append(quote, inlineHTML(strings.TrimPrefix(lines[i], "> ")))
+			}
+			sb.WriteString("<blockquote><p>" + strings.Join(quote, "<br>\n") + "</p></blockquote>\n")
+
+		case strings.HasPrefix(line, "|"):
+			var rows []string
+			for ; i < len(lines) && strings.HasPrefix(lines[i], "|"); i++ {
+				rows = append(rows, lines[i])
+			}
+			sb.WriteString(tableToHTML(rows))
+
+		case listLine.MatchString(line):
+			var items []string
+			for ; i < len(lines) && listLine.MatchString(lines[i]); i++ {
+				items = append(items, lines[i])
+			}
+			sb.WriteString(listToHTML(items))
+
+		default:
+			var para []string
+			for ; i < len(lines) && isParagraphLine(lines[i]); i++ {
+				para = append(para, inlineHTML(lines[i]))
+			}
+			sb.WriteString("<p>" + strings.Join(para, "<br>\n") + "</p>\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// isParagraphLine сообщает, что строка продолжает обычный абзац
+func isParagraphLine(line string) bool {
+	return strings.TrimSpace(line) != "" &&
+		!strings.HasPrefix(line, "```") &&
+		!strings.HasPrefix(line, "<!--") &&
+		!strings.HasPrefix(line, "> ") &&
+		!strings.HasPrefix(line, "|") &&
+		!headingLine.MatchString(line) &&
+		!listLine.MatchString(line)
+}
+
+// tableToHTML переводит строки Markdown-таблицы в HTML; первая строка — заголовок
+func tableToHTML(rows []string) string {
+	cells := func(row string) []string {
+		row = strings.TrimSpace(row)
+		row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+		parts := strings.Split(row, "|")
+		for i := range parts {
+			parts[i] = inlineHTML(strings.TrimSpace(parts[i]))
+		}
+		return parts
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<table>\n")
+	for i, row := range rows {
+		if tableDivider.MatchString(strings.TrimSpace(row)) {
+			continue
+		}
+		tag := "td"
+		if i == 0 {
+			tag = "th"
+		}
+		sb.WriteString("<tr>")
+		for _, cell := range cells(row) {
+			sb.WriteString("<" + tag + ">" + cell + "</" + tag + ">")
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</table>\n")
+	return sb.String()
+}
+
+// listToHTML переводит элементы списка в HTML; каждые два пробела отступа — уровень вложенности.
+// Уровень с элементами "1." становится нумерованным списком
+func listToHTML(items []string) string {
+	var sb strings.Builder
+	var open []string // теги открытых уровней
+	for _, item := range items {
+		m := listLine.FindStringSubmatch(item)
+		level := len(m[1])/2 + 1
+		for len(open) < level {
+			tag := "ul"
+			if m[2] != "-" {
+				tag = "ol"
+			}
+			open = append(open, tag)
+			sb.WriteString("<" + tag + ">\n")
+		}
+		for len(open) > level {
+			sb.WriteString("</" + open[len(open)-1] + ">\n")
+			open = open[:len(open)-1]
+		}
+		sb.WriteString("<li>" + inlineHTML(m[3]) + "</li>\n")
+	}
+	for i := len(open) - 1; i >= 0; i-- {
+		sb.WriteString("</" + open[i] + ">\n")
+	}
+	return sb.String()
+}
+
+var (
+	inlineLink     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]*)\)`)
+	inlineAutoLink = regexp.MustCompile(`&lt;(https?://[^\s&]+)&gt;`)
+	inlineBold     = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	inlineItalic   = regexp.MustCompile(`(^|\s)_([^_]+)_($|\s)`)
+)
+
+// inlineHTML экранирует текст и переводит в HTML inline-разметку: `код`, ссылки, **жирный**, _курсив_
+func inlineHTML(text string) string {
+	// Код не размечается, поэтому обрабатываем текст между обратными кавычками отдельно
+	parts := strings.Split(text, "`")
+	for i, part := range parts {
+		if i%2 == 1 && i < len(parts)-1 {
+			parts[i] = "<code>" + html.EscapeString(part) + "</code>"
+			continue
+		}
+		part = html.EscapeString(part)
+		part = inlineAutoLink.ReplaceAllString(part, `<a href="$1">$1</a>`)
+		part = inlineBold.ReplaceAllString(part, "<strong>$1</strong>")
+		part = inlineItalic.ReplaceAllString(part, "$1<em>$2</em>$3")
+		if i%2 == 1 {
+			// Непарная обратная кавычка
+			part = "`" + part
+		}
+		parts[i] = part
+	}
+	result := strings.Join(parts, "")
+
+	// Ссылки могут содержать код в тексте, поэтому заменяем их после сборки
+	return inlineLink.ReplaceAllString(result, `<a href="$2">$1</a>`)
+}
+
+// Токены подсветки: ключи и строки JSON, числа, литералы, комментарии, флаги командной строки
+var (
+	jsonToken  = regexp.MustCompile(`"(?:\\.|[^"\\])*"(\s*:)?|-?\d+(?:\.\d+)?(?:[eE][+-]?\d+)?|\b(?:true|false|null)\b|//[^\n]*`)
+	shellToken = regexp.MustCompile(`'[^']*'|"(?:\\.|[^"\\])*"|(?:^|\s)--?[A-Za-z][\w-]*|#[^\n]*|\$\w+`)
+)
+
+// highlightCode экранирует код и размечает токены классами для подсветки синтаксиса
+func highlightCode(lang, code string) string {
+	var token *regexp.Regexp
+	switch lang {
+	case "json":
+		token = jsonToken
+	case "bash", "sh", "shell", "powershell":
+		token = shellToken
+	default:
+		return html.EscapeString(code)
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, loc := range token.FindAllStringIndex(code, -1) {
+		sb.WriteString(html.EscapeString(code[last:loc[0]]))
+		tok := code[loc[0]:loc[1]]
+		last = loc[1]
+
+		// Ведущий пробел перед флагом оставляем вне подсветки
+		if trimmed := strings.TrimLeft(tok, " \t\n"); trimmed != tok {
+			sb.WriteString(tok[:len(tok)-len(trimmed)])
+			tok = trimmed
+		}
+
+		class := "s"
+		switch {
+		case strings.HasPrefix(tok, "//") || strings.HasPrefix(tok, "#"):
+			class = "c"
+		case strings.HasPrefix(tok, "-") && lang != "json":
+			class = "f"
+		case strings.HasPrefix(tok, "$"):
+			class = "k"
+		case tok == "true" || tok == "false" || tok == "null":
+			class = "l"
+		case strings.HasSuffix(tok, ":"):
+			class = "k"
+		case tok[0] != '"' && tok[0] != '\'':
+			class = "n"
+		}
+		sb.WriteString(`<span class="` + class + `">` + html.EscapeString(tok) + "</span>")
+	}
+	sb.WriteString(html.EscapeString(code[last:]))
+	return sb.String()
+}
@@ -0,0 +1,229 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mdwit/spec2llms/internal/config"
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// headingTranslations — заголовки секций на поддерживаемых языках, кроме английского
+var headingTranslations = map[string]map[string]string{
+	config.LanguageRU: {
+		"About":                  "Об API",
+		"Authentication":         "Аутентификация",
+		"Changelog":              "Журнал изменений",
+		"Contact":                "Контакты",
+		"Destructive Operations": "Опасные операции",
+		"Endpoints":              "Эндпоинты",
+		"Error body":             "Тело ошибки",
+		"Errors":                 "Ошибки",
+		"Example":                "Пример",
+		"Fields":                 "Поля",
+		"License":                "Лицензия",
+		"Limits":                 "Ограничения",
+		"Notes":                  "Примечания",
+		"Other":                  "Прочее",
+		"Parameters":             "Параметры",
+		"Rate Limits":            "Ограничения частоты запросов",
+		"Request Body":           "Тело запроса",
+		"Required":               "Обязательные поля",
+		"Related operations":     "Связанные операции",
+		"Resource":               "Ресурс",
+		"Safety":                 "Безопасность",
+		"any other status":       "любой другой статус",
+		"any status":             "любой статус",
+		"see":                    "см.",
+		"Returns binary data":    "Возвращает двоичные данные",
+		"Responses":              "Ответы",
+		"Response formats":       "Форматы ответа",
+		"Schemas":                "Схемы",
+		"Servers":                "Серверы",
+		"Shared Notes":           "Общие примечания",
+		"Stability":              "Стабильность",
+		"Streaming":              "Потоковый ответ",
+		"Terms of service":       "Условия использования",
+		"Try it":                 "Попробовать",
+		"Type":                   "Тип",
+		"Values of":              "Значения",
+		"Workflows":              "Сценарии",
+	},
+}
+
+// statusTexts — названия HTTP статусов на поддерживаемых языках, кроме английского.
+// Английские названия берутся из net/http
+var statusTexts = map[string]map[string]string{
+	config.LanguageRU: {
+		"200":     "Успешно",
+		"201":     "Создано",
+		"202":     "Принято",
+		"204":     "Нет содержимого",
+		"206":     "Частичное содержимое",
+		"301":     "Перемещено навсегда",
+		"302":     "Найдено",
+		"304":     "Не изменено",
+		"307":     "Временное перенаправление",
+		"308":     "Постоянное перенаправление",
+		"400":     "Некорректный запрос",
+		"401":     "Не авторизован",
+		"403":     "Доступ запрещён",
+		"404":     "Не найдено",
+		"405":     "Метод не поддерживается",
+		"406":     "Неприемлемо",
+		"408":     "Истекло время ожидания запроса",
+		"409":     "Конфликт",
+		"410":     "Удалено",
+		"412":     "Условие не выполнено",
+		"413":     "Слишком большой запрос",
+		"415":     "Неподдерживаемый тип данных",
+		"422":     "Необрабатываемый запрос",
+		"429":     "Слишком много запросов",
+		"500":     "Внутренняя ошибка сервера",
+		"501":     "Не реализовано",
+		"502":     "Ошибка шлюза",
+		"503":     "Сервис недоступен",
+		"504":     "Шлюз не отвечает",
+		"1XX":     "Информационный ответ",
+		"2XX":     "Успешно",
+		"3XX":     "Перенаправление",
+		"4XX":     "Ошибка клиента",
+		"5XX":     "Ошибка сервера",
+		"default": "Ответ по умолчанию",
+	},
+}
+
+// statusClassTexts — английские названия диапазонов кодов (2XX) и default
+var statusClassTexts = map[string]string{
+	"1XX":     "Informational",
+	"2XX":     "Success",
+	"3XX":     "Redirection",
+	"4XX":     "Client error",
+	"5XX":     "Server error",
+	"default": "Default response",
+}
+
+// statusText возвращает название кода ответа на языке вывода; для кода без
+// перевода — английское название, для неизвестного кода — пустую строку
+func (g *Generator) statusText(code string) string {
+	// Диапазоны в спецификациях пишут и как 2XX, и как 2xx
+	if strings.HasSuffix(strings.ToUpper(code), "XX") {
+		code = strings.ToUpper(code)
+	}
+	if text, ok := statusTexts[g.language()][code]; ok {
+		return text
+	}
+	if text, ok := statusClassTexts[code]; ok {
+		return text
+	}
+	if n, err := strconv.Atoi(code); err == nil {
+		return http.StatusText(n)
+	}
+	return ""
+}
+
+// responseHeading оформляет код ответа со стандартным названием статуса (**404 Not Found**)
+// и описанием из спецификации. Диапазоны (4XX) и default поясняются, какие статусы они покрывают
+func (g *Generator) responseHeading(code, description string) string {
+	reason := g.statusText(code)
+	upper := strings.ToUpper(code)
+
+	heading := "**" + code
+	if reason != "" && code != "default" {
+		heading += " " + reason
+	}
+	heading += "**"
+	switch {
+	case code == "default":
+		heading += " (" + g.heading("any other status") + ")"
+	case len(upper) == 3 && strings.HasSuffix(upper, "XX"):
+		heading += fmt.Sprintf(" (%s %c00–%c99)", g.heading("any status"), upper[0], upper[0])
+	}
+
+	// Описание, повторяющее название статуса ("OK" у 200), не дублируем
+	if description != "" && !strings.EqualFold(description, reason) {
+		heading += " - " + description
+	}
+	return heading
+}
+
+// sortResponseCodes сортирует коды ответов по числовому значению: диапазон 4XX идёт
+// после конкретных кодов 4xx, default — последним
+func sortResponseCodes(codes []string) {
+	order := func(code string) int {
+		upper := strings.ToUpper(code)
+		if n, err := strconv.Atoi(code); err == nil {
+			return n * 10
+		}
+		if len(upper) == 3 && strings.HasSuffix(upper, "XX") && upper[0] >= '1' && upper[0] <= '5' {
+			return int(upper[0]-'0')*1000 + 999
+		}
+		if code == "default" {
+			return math.MaxInt
+		}
+		return math.MaxInt - 1
+	}
+	sort.SliceStable(codes, func(i, j int) bool {
+		if oi, oj := order(codes[i]), order(codes[j]); oi != oj {
+			return oi < oj
+		}
+		return codes[i] < codes[j]
+	})
+}
+
+// heading возвращает заголовок секции на языке вывода
+func (g *Generator) heading(text string) string {
+	if translated, ok := headingTranslations[g.language()][text]; ok {
+		return translated
+	}
+	return text
+}
+
+// language возвращает язык вывода; для lang: auto он определяется по описаниям спецификации
+func (g *Generator) language() string {
+	if g.cfg.Language != config.LanguageAuto {
+		return g.cfg.Language
+	}
+	if g.detectedLanguage == "" {
+		g.detectedLanguage = detectLanguage(g.api)
+	}
+	return g.detectedLanguage
+}
+
+// detectLanguage определяет преобладающий язык описаний по письменности:
+// если кириллических букв больше, чем латинских, — русский, иначе английский
+func detectLanguage(api *parser.API) string {
+	var cyrillic, latin int
+	count := func(text string) {
+		for _, r := range text {
+			switch {
+			case unicode.Is(unicode.Cyrillic, r):
+				cyrillic++
+			case unicode.Is(unicode.Latin, r):
+				latin++
+			}
+		}
+	}
+
+	count(api.Description)
+	for _, tag := range api.Tags {
+		count(tag.Description)
+	}
+	for _, ep := range api.Endpoints {
+		count(ep.Summary)
+		count(ep.Description)
+		for _, p := range ep.Parameters {
+			count(p.Description)
+		}
+	}
+
+	if cyrillic > latin {
+		return config.LanguageRU
+	}
+	return config.LanguageEN
+}
@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+)
+
+// generateJSON сохраняет нормализованную модель API в api.json для сторонних инструментов
+// (поисковых индексаторов, своих рендереров, генераторов тестов).
+// Ключи объектов сериализуются в отсортированном порядке, поэтому вывод стабилен между запусками
+func (g *Generator) generateJSON() error {
+	if err := g.mkdirAll(g.cfg.Output); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// В модель попадают и сценарии из документа Arazzo
+	api := *g.api
+	api.Workflows = g.workflows
+	data, err := json.MarshalIndent(&api, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode API model: %w", err)
+	}
+
+	path := filepath.Join(g.cfg.Output, "api.json")
+	if err := g.writeFile(path, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write api.json: %w", err)
+	}
+	return nil
+}
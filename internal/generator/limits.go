@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// Расширения с ограничениями запроса: значение операции переопределяет значение корня спецификации
+const (
+	extTimeout        = "x-timeout"          // секунды числом или строка ("30s", "2 minutes")
+	extMaxRequestSize = "x-max-request-size" // байты числом или строка ("10MB")
+)
+
+// limitExtension возвращает значение расширения операции, иначе корня спецификации
+func (g *Generator) limitExtension(ep parser.Endpoint, name string) any {
+	if v, ok := ep.Extensions[name]; ok && v != nil {
+		return v
+	}
+	return g.api.Extensions[name]
+}
+
+// formatTimeout форматирует x-timeout: число — секунды
+func formatTimeout(v any) string {
+	switch v := v.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64) + " seconds"
+	case int, int64:
+		return fmt.Sprint(v) + " seconds"
+	case string:
+		return strings.TrimSpace(v)
+	}
+	return ""
+}
+
+// formatByteSize форматирует x-max-request-size: число — байты, крупные значения дополняются KiB/MiB/GiB
+func formatByteSize(v any) string {
+	var size float64
+	switch v := v.(type) {
+	case float64:
+		size = v
+	case int:
+		size = float64(v)
+	case int64:
+		size = float64(v)
+	case string:
+		return strings.TrimSpace(v)
+	default:
+		return ""
+	}
+	text := strconv.FormatFloat(size, 'f', -1, 64) + " bytes"
+	for _, unit := range []struct {
+		name string
+		size float64
+	}{{"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10}} {
+		if size >= unit.size {
+			return text + " (" + strconv.FormatFloat(size/unit.size, 'f', -1, 64) + " " + unit.name + ")"
+		}
+	}
+	return text
+}
+
+// generateLimits описывает границы запроса: таймаут и максимальный размер тела из расширений,
+// ответы 408 и 413. Агенту, собирающему большой запрос, они нужны до отправки
+func (g *Generator) generateLimits(ep parser.Endpoint) string {
+	var lines []string
+	if timeout := formatTimeout(g.limitExtension(ep, extTimeout)); timeout != "" {
+		lines = append(lines, "- Timeout: "+timeout)
+	}
+	if size := formatByteSize(g.limitExtension(ep, extMaxRequestSize)); size != "" {
+		lines = append(lines, "- Max request size: "+size)
+	}
+	for _, limit := range []struct{ code, note string }{
+		{"408", "the request took too long; retry, splitting large work into smaller requests"},
+		{"413", "the request body is too large; send less data per request"},
+	} {
+		resp, ok := ep.Responses[limit.code]
+		if !ok {
+			continue
+		}
+		line := "- `" + limit.code + "`: " + limit.note
+		if desc := g.inlineDescription(resp.Description); desc != "" {
+			line += " (" + desc + ")"
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "### " + g.heading("Limits") + "\n\n" + strings.Join(lines, "\n") + "\n\n"
+}
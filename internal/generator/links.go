@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// generateResponseLinks описывает links ответа: какие операции вызвать следом и какие
+// данные ответа передать в их параметры. Это явные подсказки агенту, как связаны эндпоинты
+func (g *Generator) generateResponseLinks(links []parser.Link) string {
+	var lines []string
+	for _, link := range links {
+		op := link.OperationID
+		if op == "" {
+			op = operationRefTarget(link.OperationRef)
+		}
+		if op == "" {
+			continue
+		}
+
+		line := "- " + g.operationReference(op)
+		var inputs []string
+		for _, name := range slices.Sorted(maps.Keys(link.Parameters)) {
+			inputs = append(inputs, describeLinkExpression(link.Parameters[name])+" as `"+name+"`")
+		}
+		if link.RequestBody != "" {
+			inputs = append(inputs, describeLinkExpression(link.RequestBody)+" as the request body")
+		}
+		if len(inputs) > 0 {
+			line += ": pass " + strings.Join(inputs, ", ")
+		}
+		if link.Description != "" {
+			line += " — " + g.inlineDescription(link.Description)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return g.heading("Related operations") + ":\n\n" + strings.Join(lines, "\n") + "\n\n"
+}
+
+// operationRefTarget переводит operationRef вида #/paths/~1users~1{id}/get в "GET /users/{id}".
+// Ссылки на другие документы не разрешаются
+func operationRefTarget(ref string) string {
+	pointer, ok := strings.CutPrefix(ref, "#/paths/")
+	if !ok {
+		return ""
+	}
+	path, method, ok := strings.Cut(pointer, "/")
+	if !ok || strings.Contains(method, "/") {
+		return ""
+	}
+	path = strings.NewReplacer("~1", "/", "~0", "~").Replace(path)
+	return strings.ToUpper(method) + " " + path
+}
+
+// describeLinkExpression описывает runtime-выражение link или сценария словами:
+// $response.body#/user/id → `user.id` from the response body. Прочие значения выводятся как есть
+func describeLinkExpression(expr string) string {
+	source, rest, _ := strings.Cut(expr, "#")
+	field := strings.ReplaceAll(strings.Trim(rest, "/"), "/", ".")
+	switch {
+	case source == "$response.body" && field != "":
+		return "`" + field + "` from the response body"
+	case source == "$response.body":
+		return "the response body"
+	case strings.HasPrefix(source, "$response.header."):
+		return "the `" + strings.TrimPrefix(source, "$response.header.") + "` response header"
+	case source == "$request.body" && field != "":
+		return "`" + field + "` from the request body"
+	case strings.HasPrefix(source, "$request.path."):
+		return "the request's `" + strings.TrimPrefix(source, "$request.path.") + "` path parameter"
+	case strings.HasPrefix(source, "$request.query."):
+		return "the request's `" + strings.TrimPrefix(source, "$request.query.") + "` query parameter"
+	case strings.HasPrefix(source, "$request.header."):
+		return "the request's `" + strings.TrimPrefix(source, "$request.header.") + "` header"
+	case strings.HasPrefix(source, "$inputs."):
+		return "the `" + strings.TrimPrefix(source, "$inputs.") + "` input"
+	case strings.HasPrefix(source, "$steps.") && strings.Contains(source, ".outputs."):
+		step, output, _ := strings.Cut(strings.TrimPrefix(source, "$steps."), ".outputs.")
+		return "`" + output + "` from step `" + step + "`"
+	}
+	return "`" + expr + "`"
+}
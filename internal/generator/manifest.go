@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ManifestFile — манифест инкрементальной генерации в директории вывода
+const ManifestFile = ".spec2llms-manifest.json"
+
+// RedirectsFile — карта переименований старых имён файлов в новые (migrate-names).
+// Генерация её не создаёт и не удаляет
+const RedirectsFile = "redirects.json"
+
+// manifest хранит хеши содержимого сгенерированных файлов: путь относительно вывода → sha256
+type manifest struct {
+	Files map[string]string `json:"files"`
+}
+
+// loadManifest читает манифест предыдущего запуска и файлы, записанные прошлыми запусками.
+// Отсутствующий или повреждённый манифест означает, что все файлы будут записаны заново
+func (g *Generator) loadManifest() {
+	g.previous = map[string]string{}
+	g.written = map[string]string{}
+	g.owned = ownedFiles(g.cfg.Output)
+
+	data, err := os.ReadFile(filepath.Join(g.cfg.Output, ManifestFile))
+	if err != nil {
+		return
+	}
+	var m manifest
+	if json.Unmarshal(data, &m) != nil {
+		return
+	}
+	// Манифест мог быть отредактирован: пути вне директории вывода не удаляем и не сравниваем
+	for rel, hash := range m.Files {
+		if filepath.IsLocal(filepath.FromSlash(rel)) {
+			g.previous[rel] = hash
+		}
+	}
+}
+
+// writeFile записывает сгенерированный файл целиком. В инкрементальном режиме файл, хеш которого
+// совпадает с манифестом, не перезаписывается, чтобы не менять mtime для rsync/S3 sync/git.
+// После отмены контекста генерации файл не записывается
+func (g *Generator) writeFile(path string, data []byte) error {
+	fw, err := g.createFile(path)
+	if err != nil {
+		return err
+	}
+	fw.Write(data)
+	return fw.Close()
+}
+
+// mkdirAll создаёт директорию вывода; при генерации в память ничего не делает
+func (g *Generator) mkdirAll(dir string) error {
+	if g.memory != nil {
+		return nil
+	}
+	return os.MkdirAll(dir, 0755)
+}
+
+// saveManifest удаляет файлы прошлых запусков, которые больше не генерируются, и в инкрементальном
+// режиме сохраняет манифест. Без инкрементального режима манифест прошлого запуска удаляется:
+// его хеши уже не описывают файлы, и следующий инкрементальный запуск не должен им доверять
+func (g *Generator) saveManifest() error {
+	for _, rel := range staleFiles(g.owned, g.published) {
+		path := filepath.Join(g.cfg.Output, filepath.FromSlash(rel))
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove stale %s: %w", path, err)
+		}
+	}
+	if !g.cfg.Incremental {
+		path := filepath.Join(g.cfg.Output, ManifestFile)
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove outdated %s: %w", path, err)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(manifest{Files: g.written}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(g.cfg.Output, ManifestFile), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// ownedFiles возвращает файлы, которые записали прошлые запуски, по манифестам .spec2llms-manifest.json
+// и manifest.json. Генерация удаляет только их: остальные файлы директории вывода принадлежат
+// пользователю. Пути вне директории вывода (отредактированный манифест) отбрасываются
+func ownedFiles(output string) map[string]bool {
+	owned := map[string]bool{}
+	add := func(rel string) {
+		switch rel {
+		case ManifestFile, PublishManifestFile, RedirectsFile:
+			return
+		}
+		if filepath.IsLocal(filepath.FromSlash(rel)) {
+			owned[rel] = true
+		}
+	}
+
+	var m manifest
+	if data, err := os.ReadFile(filepath.Join(output, ManifestFile)); err == nil && json.Unmarshal(data, &m) == nil {
+		for rel := range m.Files {
+			add(rel)
+		}
+	}
+	var pm publishManifest
+	if data, err := os.ReadFile(filepath.Join(output, PublishManifestFile)); err == nil && json.Unmarshal(data, &pm) == nil {
+		for _, f := range pm.Files {
+			add(f.Path)
+		}
+	}
+	return owned
+}
+
+// staleFiles возвращает по алфавиту файлы прошлых запусков, которых нет среди сгенерированных сейчас
+func staleFiles[V any](owned map[string]bool, generated map[string]V) []string {
+	var stale []string
+	for rel := range owned {
+		if _, ok := generated[rel]; !ok {
+			stale = append(stale, rel)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
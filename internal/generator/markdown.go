@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// htmlTagNames — HTML-теги, которые встречаются в описаниях спецификаций. Прочие конструкции
+// в угловых скобках (<id>, <https://...>) — это текст или автоссылки Markdown, их не трогаем
+const htmlTagNames = `p|br|hr|div|span|b|strong|i|em|u|s|code|pre|a|img|ul|ol|li|dl|dt|dd|table|thead|tbody|tfoot|tr|th|td|h[1-6]|blockquote|sup|sub|small|font|center|details|summary`
+
+var (
+	htmlTag       = regexp.MustCompile(`(?i)</?(?:` + htmlTagNames + `)\b[^>]*>`)
+	htmlPre       = regexp.MustCompile(`(?is)<pre\b[^>]*>(.*?)</pre>`)
+	htmlTable     = regexp.MustCompile(`(?is)<table\b[^>]*>(.*?)</table>`)
+	htmlTableRow  = regexp.MustCompile(`(?is)<tr\b[^>]*>(.*?)</tr>`)
+	htmlTableCell = regexp.MustCompile(`(?is)<t[hd]\b[^>]*>(.*?)</t[hd]>`)
+	htmlHeading   = regexp.MustCompile(`(?is)<h[1-6]\b[^>]*>(.*?)</h[1-6]>`)
+	htmlListItem  = regexp.MustCompile(`(?is)<li\b[^>]*>(.*?)</li>`)
+	htmlAnchor    = regexp.MustCompile(`(?is)<a\b[^>]*?\bhref=["']([^"']*)["'][^>]*>(.*?)</a>`)
+	htmlImage     = regexp.MustCompile(`(?is)<img\b[^>]*>`)
+	htmlAlt       = regexp.MustCompile(`(?i)\balt=["']([^"']*)["']`)
+	htmlBold      = regexp.MustCompile(`(?is)<(b|strong)\b[^>]*>(.*?)</(?:b|strong)>`)
+	htmlItalic    = regexp.MustCompile(`(?is)<(i|em)\b[^>]*>(.*?)</(?:i|em)>`)
+	htmlCode      = regexp.MustCompile(`(?is)<code\b[^>]*>(.*?)</code>`)
+	htmlBreak     = regexp.MustCompile(`(?i)<br\s*/?>`)
+	htmlBlock     = regexp.MustCompile(`(?i)</?(?:p|div|ul|ol|dl|blockquote|details)\b[^>]*>|<hr\s*/?>`)
+	markdownImage = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownLink  = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	blankLines    = regexp.MustCompile(`\n{3,}`)
+)
+
+// normalizeMarkdown приводит описание из спецификации к Markdown, пригодному для текстового вывода:
+// HTML переводится в Markdown (или вырезается), картинки заменяются подписью, относительные ссылки
+// разрешаются относительно base (docsBaseUrl), лишние пустые строки схлопываются
+func normalizeMarkdown(text, base string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if htmlTag.MatchString(text) {
+		text = htmlToMarkdown(text)
+	}
+
+	// Картинки в текстовом выводе бесполезны — остаётся подпись
+	text = markdownImage.ReplaceAllString(text, "$1")
+
+	if base != "" {
+		text = resolveRelativeLinks(text, base)
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	text = blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text)
+}
+
+// htmlToMarkdown переводит распространённые HTML-конструкции в Markdown и вырезает остальные теги
+func htmlToMarkdown(text string) string {
+	text = htmlPre.ReplaceAllStringFunc(text, func(m string) string {
+		code := htmlPre.FindStringSubmatch(m)[1]
+		code = html.UnescapeString(htmlTag.ReplaceAllString(code, ""))
+		return "\n\n```\n" + strings.Trim(code, "\n") + "\n```\n\n"
+	})
+	text = htmlTable.ReplaceAllStringFunc(text, func(m string) string {
+		var rows []string
+		for i, row := range htmlTableRow.FindAllStringSubmatch(m, -1) {
+			var cells []string
+			for _, cell := range htmlTableCell.FindAllStringSubmatch(row[1], -1) {
+				cells = append(cells, inlineText(htmlTag.ReplaceAllString(cell[1], "")))
+			}
+			rows = append(rows, "| "+strings.Join(cells, " | ")+" |")
+			if i == 0 {
+				rows = append(rows, "|"+strings.Repeat("---|", len(cells)))
+			}
+		}
+		return "\n\n" + strings.Join(rows, "\n") + "\n\n"
+	})
+	text = htmlHeading.ReplaceAllString(text, "\n\n**$1**\n\n")
+	text = htmlListItem.ReplaceAllString(text, "\n- $1")
+	text = htmlAnchor.ReplaceAllString(text, "[$2]($1)")
+	text = htmlImage.ReplaceAllStringFunc(text, func(m string) string {
+		if alt := htmlAlt.FindStringSubmatch(m); alt != nil {
+			return alt[1]
+		}
+		return ""
+	})
+	text = htmlBold.ReplaceAllString(text, "**$2**")
+	text = htmlItalic.ReplaceAllString(text, "_${2}_")
+	text = htmlCode.ReplaceAllString(text, "`$1`")
+	text = htmlBreak.ReplaceAllString(text, "\n")
+	text = htmlBlock.ReplaceAllString(text, "\n\n")
+	text = htmlTag.ReplaceAllString(text, "")
+	return html.UnescapeString(text)
+}
+
+// resolveRelativeLinks делает относительные ссылки Markdown абсолютными относительно base.
+// Якоря (#section), абсолютные URL и mailto: не меняются
+func resolveRelativeLinks(text, base string) string {
+	baseURL, err := url.Parse(strings.TrimSuffix(base, "/") + "/")
+	if err != nil {
+		return text
+	}
+	return markdownLink.ReplaceAllStringFunc(text, func(m string) string {
+		parts := markdownLink.FindStringSubmatch(m)
+		target, err := url.Parse(parts[2])
+		if err != nil || target.IsAbs() || strings.HasPrefix(parts[2], "#") || strings.HasPrefix(parts[2], "//") {
+			return m
+		}
+		return "[" + parts[1] + "](" + baseURL.ResolveReference(target).String() + ")"
+	})
+}
+
+// inlineText сводит текст в одну строку для ячейки таблицы или строки списка
+func inlineText(text string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(text), " "), "|", `\|`)
+}
+
+// description нормализует многострочное описание из спецификации
+func (g *Generator) description(text string) string {
+	return normalizeMarkdown(text, g.cfg.DocsBaseURL)
+}
+
+// inlineDescription нормализует описание и сводит его в одну строку
+func (g *Generator) inlineDescription(text string) string {
+	return inlineText(g.description(text))
+}
+
+// truncateDescription обрезает описание до limit символов (0 — без ограничения) по границе слова.
+// Незакрытый блок кода закрывается; если есть внешняя документация, отметка отсылает к ней
+func truncateDescription(desc string, limit int, hasExternalDocs bool) string {
+	runes := []rune(desc)
+	if limit <= 0 || len(runes) <= limit {
+		return desc
+	}
+
+	cut := limit
+	for i := limit; i > limit/2; i-- {
+		if unicode.IsSpace(runes[i]) {
+			cut = i
+			break
+		}
+	}
+	truncated := strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace)
+	if strings.Count(truncated, "```")%2 == 1 {
+		truncated += "\n```\n"
+	}
+
+	note := "…(truncated)"
+	if hasExternalDocs {
+		note = "…(truncated, see external docs)"
+	}
+	return truncated + note
+}
@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// endpointID возвращает стабильный идентификатор операции: operationId или "METHOD /path"
+func endpointID(ep parser.Endpoint) string {
+	if ep.OperationID != "" {
+		return ep.OperationID
+	}
+	return ep.Method + " " + ep.Path
+}
+
+// matchesOperation проверяет, что операция задана как operationId или "METHOD /path"
+func matchesOperation(ep parser.Endpoint, op string) bool {
+	return ep.OperationID == op || strings.EqualFold(ep.Method+" "+ep.Path, op)
+}
+
+// wrapSection оборачивает секцию невидимыми маркерами (HTML-комментариями),
+// по которым инструменты ревью и патчинга находят секцию независимо от соседнего текста
+func (g *Generator) wrapSection(kind, id, content string) string {
+	if !g.cfg.SectionMarkers {
+		return content
+	}
+
+	attr := kind + "=" + id
+	var sb strings.Builder
+	sb.WriteString("<!-- spec2llms:begin " + attr + " -->\n")
+	sb.WriteString(content)
+	if !strings.HasSuffix(content, "\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("<!-- spec2llms:end " + attr + " -->\n\n")
+	return sb.String()
+}
@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// generateEndpointMatrix генерирует список эндпоинтов в виде таблицы ресурс × метод:
+// строка на каждый путь, столбец на каждый используемый в API метод, в ячейке — ссылка ✓.
+// Агент видит форму API целиком, не читая файлы групп. Группы из секции Optional в таблицу не входят
+func (g *Generator) generateEndpointMatrix(endpoints []parser.Endpoint) string {
+	primary, _ := g.splitOptionalGroups(g.groupEndpoints(endpoints))
+
+	var paths, methods []string
+	links := make(map[string]map[string]string)
+	for _, group := range primary {
+		for _, ep := range group.Endpoints {
+			if links[ep.Path] == nil {
+				links[ep.Path] = make(map[string]string)
+				paths = append(paths, ep.Path)
+			}
+			links[ep.Path][ep.Method] = g.endpointLink(ep, group.Name)
+			if !slices.Contains(methods, ep.Method) {
+				methods = append(methods, ep.Method)
+			}
+		}
+	}
+	slices.SortFunc(methods, func(a, b string) int {
+		if oa, ob := methodOrder(a), methodOrder(b); oa != ob {
+			return oa - ob
+		}
+		return strings.Compare(a, b)
+	})
+
+	var sb strings.Builder
+	sb.WriteString("## " + g.heading("Endpoints") + "\n\n")
+	sb.WriteString("| " + g.heading("Resource") + " | " + strings.Join(methods, " | ") + " |\n")
+	sb.WriteString("|---" + strings.Repeat("|:---:", len(methods)) + "|\n")
+	for _, path := range paths {
+		sb.WriteString("| `" + path + "` |")
+		for _, method := range methods {
+			if link, ok := links[path][method]; ok {
+				sb.WriteString(" [✓](" + link + ") |")
+			} else {
+				sb.WriteString("  |")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
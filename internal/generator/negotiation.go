@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// successResponse возвращает успешный ответ операции с наименьшим кодом 2xx
+func successResponse(ep parser.Endpoint) (parser.Response, bool) {
+	codes := make([]string, 0, len(ep.Responses))
+	for code := range ep.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return parser.Response{}, false
+	}
+	sort.Strings(codes)
+	return ep.Responses[codes[0]], true
+}
+
+// responseContentTypes возвращает типы содержимого успешного ответа в порядке предпочтения
+// (см. orderContentTypes). Первый тип показывает основной пример запроса
+func (g *Generator) responseContentTypes(ep parser.Endpoint) []string {
+	resp, ok := successResponse(ep)
+	if !ok {
+		return nil
+	}
+	return orderContentTypes(resp.Content, g.cfg.ContentTypes)
+}
+
+// negotiatedContentTypes возвращает типы содержимого успешного ответа, если их несколько
+// и формат выбирается заголовком Accept; иначе nil
+func (g *Generator) negotiatedContentTypes(ep parser.Endpoint) []string {
+	if types := g.responseContentTypes(ep); len(types) > 1 {
+		return types
+	}
+	return nil
+}
+
+// generateResponseFormats перечисляет форматы успешного ответа и заголовок Accept для каждого:
+// без него агент не узнает, как получить CSV или XML вместо JSON
+func (g *Generator) generateResponseFormats(ep parser.Endpoint) string {
+	types := g.negotiatedContentTypes(ep)
+	if types == nil {
+		return ""
+	}
+	resp, _ := successResponse(ep)
+
+	var sb strings.Builder
+	sb.WriteString(g.heading("Response formats") + " (select with the `Accept` header):\n\n")
+	for i, contentType := range types {
+		line := "- `Accept: " + contentType + "`"
+		switch {
+		case i == 0:
+			line += " — used in the example"
+		case isBinaryContent(contentType, resp.Content[contentType].Schema):
+			line += " — binary data, see the alternate example"
+		default:
+			line += " — see the alternate example"
+		}
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// generateAlternateFormatExamples добавляет пример curl для каждого формата ответа, кроме основного:
+// тот же запрос с другим заголовком Accept, двоичный ответ сохраняется в файл
+func (g *Generator) generateAlternateFormatExamples(ep parser.Endpoint) string {
+	types := g.negotiatedContentTypes(ep)
+	if types == nil {
+		return ""
+	}
+	resp, _ := successResponse(ep)
+	req := g.buildExampleRequest(ep)
+
+	var sb strings.Builder
+	for _, contentType := range types[1:] {
+		variant := req
+		variant.Headers = withAccept(req.Headers, contentType)
+		variant.Output = outputFile(contentType, resp.Content[contentType].Schema)
+		sb.WriteString("Accept: `" + contentType + "`\n\n")
+		sb.WriteString(formatCurl(variant))
+	}
+	return sb.String()
+}
+
+// withAccept возвращает копию заголовков с Accept: contentType на месте прежнего Accept или первым
+func withAccept(headers []exampleHeader, contentType string) []exampleHeader {
+	result := []exampleHeader{{"Accept", contentType}}
+	for _, h := range headers {
+		if !strings.EqualFold(h.Name, "Accept") {
+			result = append(result, h)
+		}
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// applyOverrides применяет файл правок overrides к модели API и запоминает готовые примеры.
+// Ключ, не совпавший ни с одной операцией, — ошибка: правка устарела или в ключе опечатка
+func (g *Generator) applyOverrides() error {
+	if g.cfg.Overrides == "" {
+		return nil
+	}
+	overrides, err := parser.LoadOverrides(g.cfg.Overrides)
+	if err != nil {
+		return err
+	}
+	api, unused := overrides.Apply(g.api)
+	if len(unused) > 0 {
+		return fmt.Errorf("overrides %s: no operation matches %s", g.cfg.Overrides, strings.Join(unused, ", "))
+	}
+	g.api = api
+
+	g.exampleOverrides = make(map[string]parser.ExampleOverrides)
+	for _, ep := range g.api.Endpoints {
+		if examples := overrides.Examples(ep); examples != nil {
+			g.exampleOverrides[ep.Method+" "+ep.Path] = *examples
+		}
+	}
+	return nil
+}
+
+// overrideRequestBody возвращает тело примера запроса из overrides
+func (g *Generator) overrideRequestBody(ep parser.Endpoint) (string, bool) {
+	examples, ok := g.exampleOverrides[ep.Method+" "+ep.Path]
+	if !ok || examples.Request == nil {
+		return "", false
+	}
+	body, _ := formatOverrideExample(examples.Request)
+	return body, true
+}
+
+// overrideResponseExample возвращает пример ответа из overrides для кода статуса
+func (g *Generator) overrideResponseExample(ep parser.Endpoint, code string) (responseExample, bool) {
+	example, ok := g.exampleOverrides[ep.Method+" "+ep.Path].Responses[code]
+	if !ok {
+		return responseExample{}, false
+	}
+	content, lang := formatOverrideExample(example)
+	return responseExample{Lang: lang, Content: content}, true
+}
+
+// formatOverrideExample оформляет пример: строка выводится как есть, остальное — как JSON
+func formatOverrideExample(example any) (content, lang string) {
+	if s, ok := example.(string); ok {
+		return strings.TrimRight(s, "\n"), ""
+	}
+	data, err := json.MarshalIndent(example, "", "  ")
+	if err != nil {
+		return fmt.Sprint(example), ""
+	}
+	return string(data), "json"
+}
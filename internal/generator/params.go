@@ -0,0 +1,166 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// paramStyle возвращает style параметра с учётом значения по умолчанию:
+// form для query и cookie, simple для path и header
+func paramStyle(p parser.Parameter) string {
+	if p.Style != "" {
+		return p.Style
+	}
+	if p.In == "query" || p.In == "cookie" {
+		return "form"
+	}
+	return "simple"
+}
+
+// paramExplode возвращает explode параметра; по умолчанию true только для style: form
+func paramExplode(p parser.Parameter) bool {
+	if p.Explode != nil {
+		return *p.Explode
+	}
+	return paramStyle(p) == "form"
+}
+
+// isStructuredParam сообщает, что значение параметра сериализуется по style/explode
+func isStructuredParam(p parser.Parameter) bool {
+	return p.Type == "array" || p.Type == "object"
+}
+
+// paramExampleValues возвращает пример значения массива (элементы) или объекта (пары поле-значение)
+func paramExampleValues(p parser.Parameter) (items []string, fields [][2]string) {
+	switch example := p.Example.(type) {
+	case []any:
+		for _, v := range example {
+			items = append(items, fmt.Sprint(v))
+		}
+		return items, nil
+	case map[string]any:
+		for _, name := range slices.Sorted(maps.Keys(example)) {
+			fields = append(fields, [2]string{name, fmt.Sprint(example[name])})
+		}
+		return nil, fields
+	}
+
+	if p.Type == "object" {
+		names := p.Properties
+		if len(names) == 0 {
+			names = []string{"key"}
+		}
+		for _, name := range names {
+			fields = append(fields, [2]string{name, "value"})
+		}
+		return nil, fields
+	}
+
+	switch {
+	case len(p.Enum) >= 2:
+		return p.Enum[:2], nil
+	case p.ItemType == "integer" || p.ItemType == "number":
+		return []string{"1", "2"}, nil
+	case p.ItemType == "boolean":
+		return []string{"true", "false"}, nil
+	}
+	return []string{"value1", "value2"}, nil
+}
+
+// serializeQueryParam сериализует массив или объект в query-строку по style/explode:
+// ids=1&ids=2, ids=1,2, ids=1%202, ids=1|2, filter[status]=value
+func serializeQueryParam(p parser.Parameter) string {
+	items, fields := paramExampleValues(p)
+	style, explode := paramStyle(p), paramExplode(p)
+
+	if fields != nil {
+		var pairs []string
+		for _, f := range fields {
+			switch {
+			case style == "deepObject":
+				pairs = append(pairs, p.Name+"["+f[0]+"]="+f[1])
+			case explode:
+				pairs = append(pairs, f[0]+"="+f[1])
+			default:
+				pairs = append(pairs, f[0]+","+f[1])
+			}
+		}
+		if style == "deepObject" || explode {
+			return strings.Join(pairs, "&")
+		}
+		return p.Name + "=" + strings.Join(pairs, ",")
+	}
+
+	switch {
+	case style == "spaceDelimited":
+		return p.Name + "=" + strings.Join(items, "%20")
+	case style == "pipeDelimited":
+		return p.Name + "=" + strings.Join(items, "|")
+	case explode:
+		pairs := make([]string, 0, len(items))
+		for _, item := range items {
+			pairs = append(pairs, p.Name+"="+item)
+		}
+		return strings.Join(pairs, "&")
+	}
+	return p.Name + "=" + strings.Join(items, ",")
+}
+
+// serializePathParam сериализует массив или объект в сегмент пути по style/explode:
+// simple (1,2), label (.1.2) или matrix (;ids=1;ids=2)
+func serializePathParam(p parser.Parameter) string {
+	items, fields := paramExampleValues(p)
+	explode := paramExplode(p)
+
+	// Пары объекта: role=admin при explode, иначе role,admin
+	values := items
+	for _, f := range fields {
+		if explode {
+			values = append(values, f[0]+"="+f[1])
+		} else {
+			values = append(values, f[0], f[1])
+		}
+	}
+
+	switch paramStyle(p) {
+	case "label":
+		if explode {
+			return "." + strings.Join(values, ".")
+		}
+		return "." + strings.Join(values, ",")
+	case "matrix":
+		if explode && fields == nil {
+			return ";" + p.Name + "=" + strings.Join(values, ";"+p.Name+"=")
+		}
+		if explode {
+			return ";" + strings.Join(values, ";")
+		}
+		return ";" + p.Name + "=" + strings.Join(values, ",")
+	}
+	return strings.Join(values, ",")
+}
+
+// serializationNote описывает, как передаётся массив или объект, на примере значения
+func serializationNote(p parser.Parameter) string {
+	switch {
+	case !isStructuredParam(p):
+		return ""
+	case p.In == "query":
+		return fmt.Sprintf(" Serialized as `%s` (style: %s, explode: %t).", serializeQueryParam(p), paramStyle(p), paramExplode(p))
+	case p.In == "path":
+		return fmt.Sprintf(" Serialized as `%s` (style: %s, explode: %t).", serializePathParam(p), paramStyle(p), paramExplode(p))
+	}
+	return ""
+}
+
+// paramType возвращает тип параметра для таблицы параметров: array[integer] для массивов
+func paramType(p parser.Parameter) string {
+	if p.Type == "array" && p.ItemType != "" {
+		return "array[" + p.ItemType + "]"
+	}
+	return p.Type
+}
@@ -0,0 +1,57 @@
+package generator
+
+import "github.com/mdwit/spec2llms/internal/parser"
+
+// Progress — состояние генерации для индикатора прогресса
+type Progress struct {
+	Files       int // записано файлов
+	TotalFiles  int
+	Groups      int // групп, все файлы которых записаны
+	TotalGroups int
+}
+
+// SetProgress задаёт функцию, вызываемую после записи каждого файла документации
+func (g *Generator) SetProgress(fn func(Progress)) {
+	g.progress = fn
+}
+
+// progressTracker считает записанные файлы и завершённые группы
+type progressTracker struct {
+	fn        func(Progress)
+	state     Progress
+	remaining map[string]int // незаписанных файлов в группе
+}
+
+// newProgressTracker готовит учёт прогресса: по файлу на эндпоинт плюс extra файлов (индекс)
+func (g *Generator) newProgressTracker(endpoints []parser.Endpoint, extra int) *progressTracker {
+	t := &progressTracker{fn: g.progress, remaining: make(map[string]int)}
+	for _, ep := range endpoints {
+		t.remaining[g.groupName(ep)]++
+	}
+	t.state.TotalFiles = len(endpoints) + extra
+	t.state.TotalGroups = len(t.remaining)
+	return t
+}
+
+// endpointDone отмечает записанный файл эндпоинта группы
+func (t *progressTracker) endpointDone(group string) {
+	t.remaining[group]--
+	if t.remaining[group] == 0 {
+		t.state.Groups++
+	}
+	t.fileDone()
+}
+
+// groupDone отмечает группу, записанную одним файлом (страница группы в HTML)
+func (t *progressTracker) groupDone() {
+	t.state.Groups++
+	t.fileDone()
+}
+
+// fileDone отмечает записанный файл
+func (t *progressTracker) fileDone() {
+	t.state.Files++
+	if t.fn != nil {
+		t.fn(t.state)
+	}
+}
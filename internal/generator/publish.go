@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// PublishManifestFile — манифест публикации: каждый сгенерированный файл с хешем и размером.
+// По нему spec2llms verify проверяет, что развёрнутая копия не изменена
+const PublishManifestFile = "manifest.json"
+
+// GeneratedFile — файл, записанный генерацией: запись манифеста публикации и отчёта о запуске
+type GeneratedFile struct {
+	Path   string `json:"path"` // относительно директории вывода, через /
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// publishManifest — содержимое manifest.json
+type publishManifest struct {
+	Files []GeneratedFile `json:"files"`
+}
+
+// Результаты проверки файла по манифесту публикации
+const (
+	VerifyMissing    = "missing"    // файл из манифеста отсутствует
+	VerifyModified   = "modified"   // хеш или размер не совпадают
+	VerifyUnexpected = "unexpected" // файла нет в манифесте
+)
+
+// VerifyProblem — расхождение директории с манифестом публикации
+type VerifyProblem struct {
+	Path string
+	Kind string
+}
+
+// recordPublished запоминает сгенерированный файл для манифеста публикации и Files
+func (g *Generator) recordPublished(rel, hash string, size int64) {
+	if g.published == nil || rel == PublishManifestFile {
+		return
+	}
+	g.published[rel] = GeneratedFile{Path: rel, SHA256: hash, Size: size}
+}
+
+// Files возвращает файлы, записанные последним Generate или Render, по пути. manifest.json
+// в список не входит
+func (g *Generator) Files() []GeneratedFile {
+	files := make([]GeneratedFile, 0, len(g.published))
+	for _, f := range g.published {
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// writePublishManifest записывает manifest.json со всеми файлами этого запуска
+func (g *Generator) writePublishManifest() error {
+	m := publishManifest{Files: g.Files()}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", PublishManifestFile, err)
+	}
+	if err := g.writeFile(filepath.Join(g.cfg.Output, PublishManifestFile), append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write %s: %w", PublishManifestFile, err)
+	}
+	return nil
+}
+
+// Verify сверяет директорию с её manifest.json: каждый файл должен быть на месте с тем же
+// хешем и размером, лишних файлов быть не должно. Результат отсортирован по пути
+func Verify(dir string) ([]VerifyProblem, error) {
+	data, err := os.ReadFile(filepath.Join(dir, PublishManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", PublishManifestFile, err)
+	}
+	var m publishManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", PublishManifestFile, err)
+	}
+
+	var problems []VerifyProblem
+	listed := make(map[string]bool, len(m.Files))
+	for _, f := range m.Files {
+		listed[f.Path] = true
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(f.Path)))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			problems = append(problems, VerifyProblem{f.Path, VerifyMissing})
+			continue
+		case err != nil:
+			return nil, err
+		}
+		sum := sha256.Sum256(content)
+		if int64(len(content)) != f.Size || hex.EncodeToString(sum[:]) != f.SHA256 {
+			problems = append(problems, VerifyProblem{f.Path, VerifyModified})
+		}
+	}
+
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		switch rel {
+		case PublishManifestFile, ManifestFile, RedirectsFile:
+			return nil
+		}
+		if !listed[rel] {
+			problems = append(problems, VerifyProblem{rel, VerifyUnexpected})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Path < problems[j].Path })
+	return problems, nil
+}
@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// quickstartEndpoint выбирает эндпоинт для примера в секции "Try it": заданный в sandbox.operation,
+// иначе первый GET, все обязательные параметры которого известны, иначе первый GET
+func (g *Generator) quickstartEndpoint(endpoints []parser.Endpoint) (*parser.Endpoint, error) {
+	sandbox := g.cfg.Sandbox
+	if sandbox.Operation != "" {
+		for i := range endpoints {
+			ep := &endpoints[i]
+			if matchesOperation(*ep, sandbox.Operation) {
+				return ep, nil
+			}
+		}
+		return nil, fmt.Errorf("sandbox operation %q not found in spec", sandbox.Operation)
+	}
+
+	var firstGet *parser.Endpoint
+	for i := range endpoints {
+		ep := &endpoints[i]
+		if ep.Method != "GET" || ep.Deprecated {
+			continue
+		}
+		if firstGet == nil {
+			firstGet = ep
+		}
+		if g.hasQuickstartValues(*ep) {
+			return ep, nil
+		}
+	}
+	if firstGet == nil && len(endpoints) > 0 {
+		return &endpoints[0], nil
+	}
+	return firstGet, nil
+}
+
+// hasQuickstartValues сообщает, что для всех обязательных параметров эндпоинта есть реальные значения
+func (g *Generator) hasQuickstartValues(ep parser.Endpoint) bool {
+	for _, p := range ep.Parameters {
+		if !p.Required || (p.In != "path" && p.In != "query") {
+			continue
+		}
+		if _, ok := g.cfg.Sandbox.SeedData[p.Name]; ok {
+			continue
+		}
+		if _, ok := g.paramPlaceholder(ep, p.Name); ok {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// generateQuickstart генерирует секцию "Try it" с полным рабочим запросом к sandbox
+func (g *Generator) generateQuickstart(ep *parser.Endpoint) string {
+	sandbox := g.cfg.Sandbox
+
+	var sb strings.Builder
+	sb.WriteString("## " + g.heading("Try it") + "\n\n")
+	sb.WriteString("Sandbox: `" + sandbox.BaseURL + "`\n\n")
+	if sandbox.Credentials != "" {
+		sb.WriteString(sandbox.Credentials + "\n\n")
+	}
+
+	if len(sandbox.SeedData) > 0 {
+		names := make([]string, 0, len(sandbox.SeedData))
+		for name := range sandbox.SeedData {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		sb.WriteString("Seed data:\n\n")
+		for _, name := range names {
+			sb.WriteString(fmt.Sprintf("- `%s`: `%s`\n", name, sandbox.SeedData[name]))
+		}
+		sb.WriteString("\n")
+	}
+
+	if ep != nil {
+		line := fmt.Sprintf("First request — `%s %s`", ep.Method, ep.Path)
+		if ep.Summary != "" {
+			line += " (" + ep.Summary + ")"
+		}
+		sb.WriteString(line + ":\n\n")
+		sb.WriteString(g.formatExamples(g.buildExampleRequestAt(*ep, sandbox.BaseURL, sandbox.SeedData)))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// isRateLimitExtension сообщает, описывает ли расширение ограничения частоты запросов
+func isRateLimitExtension(key string) bool {
+	key = strings.ToLower(key)
+	return strings.HasPrefix(key, "x-ratelimit") || strings.HasPrefix(key, "x-rate-limit")
+}
+
+// isRateLimitHeader сообщает, относится ли заголовок ответа к rate limiting
+func isRateLimitHeader(name string) bool {
+	name = strings.ToLower(name)
+	return strings.HasPrefix(name, "ratelimit") ||
+		strings.HasPrefix(name, "x-ratelimit") ||
+		strings.HasPrefix(name, "x-rate-limit") ||
+		name == "retry-after"
+}
+
+// generateRateLimits генерирует секцию Rate Limits для llms.txt из x-ratelimit расширений,
+// ответов 429 и заголовков RateLimit-*/Retry-After. Пустая строка — если ничего не найдено
+func (g *Generator) generateRateLimits(endpoints []parser.Endpoint) string {
+	global := rateLimitExtensions(g.api.Extensions)
+
+	var perEndpoint []string
+	var throttled []string
+	headers := make(map[string]string) // имя → описание
+	var headerNames []string
+
+	for _, ep := range endpoints {
+		if ext := rateLimitExtensions(ep.Extensions); len(ext) > 0 {
+			perEndpoint = append(perEndpoint, fmt.Sprintf("- `%s %s`: %s", ep.Method, ep.Path, strings.Join(ext, "; ")))
+		}
+		if _, ok := ep.Responses["429"]; ok {
+			throttled = append(throttled, fmt.Sprintf("`%s %s`", ep.Method, ep.Path))
+		}
+		for _, resp := range ep.Responses {
+			for name, header := range resp.Headers {
+				if !isRateLimitHeader(name) {
+					continue
+				}
+				if _, seen := headers[name]; !seen {
+					headerNames = append(headerNames, name)
+				}
+				if headers[name] == "" {
+					headers[name] = header.Description
+				}
+			}
+		}
+	}
+
+	if len(global) == 0 && len(perEndpoint) == 0 && len(throttled) == 0 && len(headerNames) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## " + g.heading("Rate Limits") + "\n\n")
+
+	for _, line := range global {
+		sb.WriteString("- " + line + "\n")
+	}
+	if len(global) > 0 {
+		sb.WriteString("\n")
+	}
+
+	if len(perEndpoint) > 0 {
+		sb.WriteString("Per-endpoint limits:\n\n")
+		sb.WriteString(strings.Join(perEndpoint, "\n") + "\n\n")
+	}
+
+	if len(throttled) > 0 {
+		sb.WriteString("Endpoints that may respond with `429 Too Many Requests`: " + strings.Join(throttled, ", ") + ".\n\n")
+	}
+
+	if len(headerNames) > 0 {
+		sort.Strings(headerNames)
+		sb.WriteString("Rate limit headers:\n\n")
+		for _, name := range headerNames {
+			line := "- `" + name + "`"
+			if headers[name] != "" {
+				line += " — " + headers[name]
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	// Как отступать при превышении лимита
+	if retryAfter := findHeader(headerNames, "retry-after"); retryAfter != "" {
+		sb.WriteString("On `429`, wait for the duration given in the `" + retryAfter + "` header (seconds or an HTTP date) before retrying.\n\n")
+	} else if len(throttled) > 0 {
+		sb.WriteString("On `429`, back off (e.g. exponentially) before retrying.\n\n")
+	}
+
+	return sb.String()
+}
+
+// rateLimitExtensions форматирует x-ratelimit* расширения в строки вида "key: value"
+func rateLimitExtensions(extensions map[string]any) []string {
+	var keys []string
+	for key := range extensions {
+		if isRateLimitExtension(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("`%s`: %s", key, formatExtensionValue(extensions[key])))
+	}
+	return lines
+}
+
+// formatExtensionValue форматирует значение расширения в одну строку
+func formatExtensionValue(value any) string {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			parts = append(parts, k+"="+formatExtensionValue(v[k]))
+		}
+		return strings.Join(parts, ", ")
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, formatExtensionValue(item))
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// findHeader ищет заголовок без учёта регистра
+func findHeader(names []string, target string) string {
+	for _, name := range names {
+		if strings.EqualFold(name, target) {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// ndjsonContentTypes — типы содержимого "JSON-объект на строку"
+var ndjsonContentTypes = map[string]bool{
+	"application/x-ndjson":     true,
+	"application/ndjson":       true,
+	"application/jsonl":        true,
+	"application/x-jsonlines":  true,
+	"application/jsonlines":    true,
+	"application/json-seq":     true,
+	"application/stream+json":  true,
+	"application/x-json-lines": true,
+}
+
+// isCSVContent сообщает, что ответ — таблица CSV
+func isCSVContent(contentType string) bool {
+	return mediaTypeBase(contentType) == "text/csv"
+}
+
+// isNDJSONContent сообщает, что ответ — поток JSON-записей по одной на строку
+func isNDJSONContent(contentType string) bool {
+	return ndjsonContentTypes[mediaTypeBase(contentType)]
+}
+
+// recordSchema возвращает схему одной записи построчного ответа: спецификации описывают CSV
+// и NDJSON то массивом записей, то самой записью. nil — полей у записи нет
+func recordSchema(schema *parser.Schema) *parser.Schema {
+	if schema != nil && schema.Type == "array" {
+		schema = schema.Items
+	}
+	if schema == nil || len(schema.Properties) == 0 {
+		return nil
+	}
+	return schema
+}
+
+// generateCSVDoc описывает CSV-ответ колонками записи и примером с заголовком и одной строкой
+// вместо JSON-объекта, которого в ответе не будет
+func (g *Generator) generateCSVDoc(schema *parser.Schema) string {
+	record := recordSchema(schema)
+	if record == nil {
+		return ""
+	}
+	columns := slices.Sorted(maps.Keys(record.Properties))
+	row := make([]string, len(columns))
+	for i, name := range columns {
+		row[i] = csvValue(g.getTypeExample(record.Properties[name]))
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(columns)
+	w.Write(row)
+	w.Flush()
+
+	var sb strings.Builder
+	sb.WriteString("CSV with a header row, one record per line. Columns:\n\n")
+	sb.WriteString(g.generateFieldsTable(record, ""))
+	sb.WriteString("```csv\n" + buf.String() + "```\n\n")
+	return sb.String()
+}
+
+// csvValue переводит JSON-пример значения в ячейку CSV: строки без кавычек JSON
+func csvValue(example string) string {
+	if unquoted, err := strconv.Unquote(example); err == nil && strings.HasPrefix(example, `"`) {
+		return unquoted
+	}
+	return example
+}
+
+// generateNDJSONDoc описывает ответ NDJSON: одна компактная JSON-запись на строку и её поля
+func (g *Generator) generateNDJSONDoc(schema *parser.Schema) string {
+	record := recordSchema(schema)
+	if record == nil {
+		return ""
+	}
+	line := g.renderJSONSchema(record, 0, g.schemaDepth(), 0)
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(line)); err == nil {
+		line = compact.String()
+	}
+
+	var sb strings.Builder
+	sb.WriteString("One JSON record per line (newline-delimited JSON):\n\n")
+	sb.WriteString("```json\n" + line + "\n```\n\n")
+	sb.WriteString(g.generateFieldsTable(record, ""))
+	return sb.String()
+}
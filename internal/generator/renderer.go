@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"plugin"
+	"strings"
+	"sync"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// Renderer оформляет документацию в собственном формате. Каждый метод возвращает
+// файлы, которые записываются в директорию вывода; пути задаются относительно неё
+type Renderer interface {
+	RenderIndex(api *parser.API, groups []Group) ([]File, error)
+	RenderGroup(api *parser.API, group Group) ([]File, error)
+	RenderEndpoint(api *parser.API, ep parser.Endpoint) ([]File, error)
+}
+
+// Group — группа эндпоинтов (тег, сегмент пути или x-group), передаваемая рендереру
+type Group struct {
+	Name        string            `json:"name"`
+	Title       string            `json:"title,omitempty"` // отображаемое имя (tags.<имя>.title из конфига)
+	Description string            `json:"description,omitempty"`
+	Endpoints   []parser.Endpoint `json:"endpoints"`
+}
+
+// File — файл, созданный рендерером
+type File struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = map[string]Renderer{}
+)
+
+// RegisterRenderer регистрирует рендерер под именем, которое указывается в конфиге (renderer)
+func RegisterRenderer(name string, r Renderer) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	renderers[name] = r
+}
+
+// lookupRenderer находит рендерер по значению renderer из конфига:
+// имя зарегистрированного, exec:<команда> для внешней программы или plugin:<файл.so> для Go-плагина
+func lookupRenderer(name string) (Renderer, error) {
+	switch {
+	case strings.HasPrefix(name, "exec:"):
+		return &execRenderer{command: strings.TrimPrefix(name, "exec:")}, nil
+	case strings.HasPrefix(name, "plugin:"):
+		return loadPluginRenderer(strings.TrimPrefix(name, "plugin:"))
+	}
+
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	if r, ok := renderers[name]; ok {
+		return r, nil
+	}
+	return nil, fmt.Errorf("unknown renderer %q", name)
+}
+
+// loadPluginRenderer загружает Go-плагин, экспортирующий переменную Renderer.
+// Плагин должен быть собран той же версией Go и тех же зависимостей, что и spec2llms
+func loadPluginRenderer(path string) (Renderer, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load renderer plugin %s: %w", path, err)
+	}
+	sym, err := p.Lookup("Renderer")
+	if err != nil {
+		return nil, fmt.Errorf("renderer plugin %s: %w", path, err)
+	}
+	switch r := sym.(type) {
+	case *Renderer:
+		return *r, nil
+	case Renderer:
+		return r, nil
+	}
+	return nil, fmt.Errorf("renderer plugin %s: symbol Renderer does not implement generator.Renderer", path)
+}
+
+// generateWithRenderer генерирует документацию внешним рендерером
+func (g *Generator) generateWithRenderer(endpoints []parser.Endpoint) error {
+	r, err := lookupRenderer(g.cfg.Renderer)
+	if err != nil {
+		return err
+	}
+
+	var groups []Group
+	for _, group := range g.groupEndpoints(endpoints) {
+		groups = append(groups, Group{Name: group.Name, Title: group.Title, Description: group.Description, Endpoints: group.Endpoints})
+	}
+
+	var files []File
+	for _, ep := range endpoints {
+		out, err := r.RenderEndpoint(g.api, ep)
+		if err != nil {
+			return fmt.Errorf("renderer failed on %s %s: %w", ep.Method, ep.Path, err)
+		}
+		files = append(files, out...)
+	}
+	for _, group := range groups {
+		out, err := r.RenderGroup(g.api, group)
+		if err != nil {
+			return fmt.Errorf("renderer failed on group %q: %w", group.Name, err)
+		}
+		files = append(files, out...)
+	}
+	out, err := r.RenderIndex(g.api, groups)
+	if err != nil {
+		return fmt.Errorf("renderer failed on index: %w", err)
+	}
+	files = append(files, out...)
+
+	return g.writeRenderedFiles(files)
+}
+
+// writeRenderedFiles записывает файлы рендерера, не позволяя выйти за пределы директории вывода
+func (g *Generator) writeRenderedFiles(files []File) error {
+	for _, f := range files {
+		rel := filepath.FromSlash(f.Path)
+		if !filepath.IsLocal(rel) {
+			return fmt.Errorf("renderer produced path outside output directory: %q", f.Path)
+		}
+
+		path := filepath.Join(g.cfg.Output, rel)
+		if err := g.mkdirAll(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if err := g.writeFile(path, []byte(f.Content)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// execRenderer — рендерер во внешней программе. Программа запускается один раз,
+// получает на stdin JSON {"api": ..., "groups": [...]} и печатает на stdout {"files": [{"path", "content"}]}
+type execRenderer struct {
+	command string
+}
+
+func (r *execRenderer) RenderIndex(api *parser.API, groups []Group) ([]File, error) {
+	input, err := json.Marshal(struct {
+		API    *parser.API `json:"api"`
+		Groups []Group     `json:"groups"`
+	}{api, groups})
+	if err != nil {
+		return nil, err
+	}
+
+	args := strings.Fields(r.command)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty renderer command")
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	var output struct {
+		Files []File `json:"files"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("%s: invalid output: %w", args[0], err)
+	}
+	return output.Files, nil
+}
+
+// Внешняя программа получает всю модель за один запуск в RenderIndex
+func (r *execRenderer) RenderGroup(*parser.API, Group) ([]File, error)              { return nil, nil }
+func (r *execRenderer) RenderEndpoint(*parser.API, parser.Endpoint) ([]File, error) { return nil, nil }
@@ -0,0 +1,148 @@
+package generator
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// isDestructive сообщает, что операция удаляет или необратимо меняет данные:
+// DELETE или x-destructive: true. x-destructive: false снимает отметку с DELETE
+func isDestructive(ep parser.Endpoint) bool {
+	switch v := ep.Extensions["x-destructive"].(type) {
+	case bool:
+		return v
+	case string:
+		// Строка описывает последствия вызова
+		return v != ""
+	}
+	return ep.Method == "DELETE"
+}
+
+// destructiveEffect возвращает описание последствий из x-destructive, если оно задано строкой
+func destructiveEffect(ep parser.Endpoint) string {
+	effect, _ := ep.Extensions["x-destructive"].(string)
+	return strings.TrimSpace(effect)
+}
+
+// idempotencyKeyHeaders — заголовки, с которыми повтор неидемпотентного запроса безопасен
+var idempotencyKeyHeaders = []string{"idempotency-key", "x-idempotency-key"}
+
+// operationSafety — свойства операции, по которым агент решает, можно ли вызвать её без
+// подтверждения и повторить после сбоя
+type operationSafety struct {
+	Safe           bool   // не меняет состояние: GET, HEAD, OPTIONS, TRACE или x-safe: true
+	Idempotent     bool   // повтор даёт тот же результат: безопасные методы, PUT, DELETE или x-idempotent: true
+	Destructive    bool   // см. isDestructive
+	IdempotencyKey string // заголовок, делающий повтор безопасным (Idempotency-Key)
+}
+
+// safetyInfo определяет свойства операции по семантике метода HTTP; расширения x-safe
+// и x-idempotent их переопределяют, а заголовок Idempotency-Key отмечает операции,
+// которые можно безопасно повторить с тем же ключом
+func safetyInfo(ep parser.Endpoint) operationSafety {
+	s := operationSafety{Destructive: isDestructive(ep)}
+	switch ep.Method {
+	case "GET", "HEAD", "OPTIONS", "TRACE":
+		s.Safe, s.Idempotent = true, true
+	case "PUT", "DELETE":
+		s.Idempotent = true
+	}
+	if v, ok := ep.Extensions["x-safe"].(bool); ok {
+		s.Safe = v
+	}
+	if v, ok := ep.Extensions["x-idempotent"].(bool); ok {
+		s.Idempotent = v
+	}
+	if s.Destructive {
+		s.Safe = false
+	}
+	if s.Safe {
+		s.Idempotent = true
+	}
+	for _, p := range ep.Parameters {
+		if p.In == "header" && slices.Contains(idempotencyKeyHeaders, strings.ToLower(p.Name)) {
+			s.IdempotencyKey = p.Name
+			break
+		}
+	}
+	return s
+}
+
+// Labels возвращает свойства операции метками для машиночитаемых индексов:
+// safe, idempotent, idempotency-key, destructive
+func (s operationSafety) Labels() []string {
+	var labels []string
+	if s.Safe {
+		labels = append(labels, "safe")
+	}
+	if s.Idempotent {
+		labels = append(labels, "idempotent")
+	} else if s.IdempotencyKey != "" {
+		labels = append(labels, "idempotency-key")
+	}
+	if s.Destructive {
+		labels = append(labels, "destructive")
+	}
+	return labels
+}
+
+// generateSafetyLine описывает одной строкой, меняет ли операция состояние и можно ли её повторить
+func (g *Generator) generateSafetyLine(ep parser.Endpoint) string {
+	s := safetyInfo(ep)
+	var parts []string
+	switch {
+	case s.Safe:
+		parts = append(parts, "safe (read-only)", "idempotent")
+	case s.Idempotent:
+		parts = append(parts, "modifies data", "idempotent")
+	case s.IdempotencyKey != "":
+		parts = append(parts, "modifies data", "retry safely with the same `"+s.IdempotencyKey+"` header")
+	default:
+		parts = append(parts, "modifies data", "not idempotent, do not retry blindly")
+	}
+	if s.Destructive {
+		parts = append(parts, "destructive")
+	}
+	return g.heading("Safety") + ": " + strings.Join(parts, ", ") + "\n\n"
+}
+
+// generateDestructiveWarning генерирует заметное предупреждение для опасной операции
+func (g *Generator) generateDestructiveWarning(ep parser.Endpoint) string {
+	if !isDestructive(ep) {
+		return ""
+	}
+	line := "> ⚠️ **Destructive operation.** Ask for human confirmation before calling it."
+	if effect := destructiveEffect(ep); effect != "" {
+		line += " Effect: " + effect
+	}
+	return line + "\n\n"
+}
+
+// generateDestructiveOperations генерирует секцию индекса со списком опасных операций,
+// по которой guardrail-системы агентов настраивают подтверждение человеком. Каждая операция
+// ссылается на свою секцию, где повторено предупреждение
+func (g *Generator) generateDestructiveOperations(endpoints []parser.Endpoint) string {
+	var sb strings.Builder
+	for _, ep := range endpoints {
+		if !isDestructive(ep) {
+			continue
+		}
+		line := "- " + g.indexOperationReference(ep)
+		if ep.Summary != "" {
+			line += " — " + ep.Summary
+		}
+		if effect := destructiveEffect(ep); effect != "" {
+			line += ". Effect: " + effect
+		}
+		sb.WriteString(line + "\n")
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+
+	return "## " + g.heading("Destructive Operations") + "\n\n" +
+		"These operations delete or irreversibly modify data and should require human confirmation.\n\n" +
+		sb.String() + "\n"
+}
@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// schemaGlossaryName — имя файла глоссария именованных схем без расширения
+const schemaGlossaryName = "schemas"
+
+// hasSchemaGlossary сообщает, что рядом с llms.txt пишется глоссарий схем
+func (g *Generator) hasSchemaGlossary() bool {
+	return g.cfg.SchemaGlossary && len(g.api.Schemas) > 0 && !g.isHTML()
+}
+
+// schemaGlossaryFile возвращает имя файла глоссария относительно директории вывода
+func (g *Generator) schemaGlossaryFile() string {
+	return schemaGlossaryName + g.fileExtension()
+}
+
+// generateSchemaGlossary перечисляет схемы components.schemas: по схеме на заголовок с первым
+// абзацем описания и полями в одну строку, чтобы агент находил "объект User" без чтения файлов эндпоинтов
+func (g *Generator) generateSchemaGlossary() string {
+	var sb strings.Builder
+	title := g.cfg.Title
+	if title == "" {
+		title = g.api.Title
+	}
+	sb.WriteString("# " + title + " — " + g.heading("Schemas") + "\n\n")
+	sb.WriteString("> Named schemas used in requests and responses. Required fields are marked with *.\n\n")
+
+	for _, name := range slices.Sorted(maps.Keys(g.api.Schemas)) {
+		schema := g.api.Schemas[name]
+		sb.WriteString("## " + name + "\n\n")
+		if desc := g.description(schema.Description); desc != "" {
+			first, _, _ := strings.Cut(desc, "\n\n")
+			sb.WriteString(inlineText(first) + "\n\n")
+		}
+		if fields := schemaFieldSummary(schema); fields != "" {
+			sb.WriteString(g.heading("Fields") + ": " + fields + "\n\n")
+		} else if schema.Type != "" {
+			sb.WriteString(g.heading("Type") + ": " + glossaryType(schema) + "\n\n")
+		}
+	}
+	return sb.String()
+}
+
+// schemaFieldSummary перечисляет поля схемы через запятую: `имя`* тип
+func schemaFieldSummary(schema *parser.Schema) string {
+	fields := make([]string, 0, len(schema.Properties))
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		field := "`" + name + "`"
+		if slices.Contains(schema.Required, name) {
+			field += "*"
+		}
+		if typ := glossaryType(schema.Properties[name]); typ != "" {
+			field += " " + typ
+		}
+		fields = append(fields, field)
+	}
+	return strings.Join(fields, ", ")
+}
+
+// glossaryType описывает тип поля; ссылка на именованную схему выводится её именем,
+// чтобы по нему найти схему в глоссарии
+func glossaryType(schema *parser.Schema) string {
+	switch {
+	case schema == nil:
+		return ""
+	case schema.RefName() != "":
+		return schema.RefName()
+	case schema.Type == "array" && schema.Items != nil:
+		return "array[" + glossaryType(schema.Items) + "]"
+	case len(schema.Enum) > 0:
+		return fmt.Sprintf("%s (%s)", schema.Type, strings.Join(schema.Enum, " | "))
+	}
+	return fieldType(schema)
+}
+
+// generateSchemaGlossaryLink — секция llms.txt со ссылкой на глоссарий схем
+func (g *Generator) generateSchemaGlossaryLink() string {
+	heading := g.heading("Schemas")
+	return fmt.Sprintf("## %s\n\n- [%s](./%s): %d named schemas with their fields\n\n", heading, heading, g.schemaGlossaryFile(), len(g.api.Schemas))
+}
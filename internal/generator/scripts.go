@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// unsafeFilenameChars — символы, недопустимые в именах файлов из operationId
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// scriptName возвращает имя скрипта примера: <operationId>.sh, без operationId — по методу и пути
+func (g *Generator) scriptName(ep parser.Endpoint) string {
+	if ep.OperationID != "" {
+		return unsafeFilenameChars.ReplaceAllString(ep.OperationID, "-") + ".sh"
+	}
+	return strings.TrimSuffix(g.getEndpointFilename(ep), g.fileExtension()) + ".sh"
+}
+
+// generateCurlScripts записывает пример curl каждой операции в examples/<operationId>.sh
+// и скрипт smoke.sh, который по очереди выполняет безопасные GET-примеры
+func (g *Generator) generateCurlScripts(endpoints []parser.Endpoint) error {
+	dir := filepath.Join(g.cfg.Output, "examples")
+	if err := g.mkdirAll(dir); err != nil {
+		return fmt.Errorf("failed to create examples directory: %w", err)
+	}
+
+	var smoke []string
+	for _, ep := range endpoints {
+		name := g.scriptName(ep)
+
+		var sb strings.Builder
+		sb.WriteString("#!/bin/sh\n")
+		sb.WriteString(fmt.Sprintf("# %s %s", ep.Method, ep.Path))
+		if ep.Summary != "" {
+			sb.WriteString(" - " + ep.Summary)
+		}
+		sb.WriteString("\n# Extra arguments are passed to curl, e.g. -fsS\n")
+		sb.WriteString(curlCommand(g.buildExampleRequest(ep), `curl "$@"`) + "\n")
+
+		path := filepath.Join(dir, name)
+		if err := g.writeFile(path, []byte(sb.String())); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+
+		if ep.Method == "GET" && !isDestructive(ep) {
+			smoke = append(smoke, name)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n")
+	sb.WriteString("# Smoke test: runs every safe GET example and reports failures.\n")
+	sb.WriteString("# Extra arguments are passed to curl, e.g. ./smoke.sh -H \"Authorization: Bearer $TOKEN\"\n")
+	sb.WriteString("dir=$(dirname \"$0\")\n")
+	sb.WriteString("failed=0\n")
+	sb.WriteString("for script in " + strings.Join(smoke, " ") + "; do\n")
+	sb.WriteString("\tif sh \"$dir/$script\" -fsS -o /dev/null \"$@\"; then\n")
+	sb.WriteString("\t\techo \"ok   $script\"\n")
+	sb.WriteString("\telse\n")
+	sb.WriteString("\t\techo \"FAIL $script\"\n")
+	sb.WriteString("\t\tfailed=1\n")
+	sb.WriteString("\tfi\n")
+	sb.WriteString("done\n")
+	sb.WriteString("exit $failed\n")
+
+	path := filepath.Join(dir, "smoke.sh")
+	if err := g.writeFile(path, []byte(sb.String())); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
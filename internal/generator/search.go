@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// SearchIndexFile — индекс ключевых слов для точечного поиска по документации
+const SearchIndexFile = "search.json"
+
+// searchEntry — операция в search.json: где лежит её документация и по каким словам её искать
+type searchEntry struct {
+	ID          string   `json:"id"` // METHOD /path
+	OperationID string   `json:"operationId,omitempty"`
+	Summary     string   `json:"summary,omitempty"`
+	File        string   `json:"file"`             // путь относительно директории вывода
+	Anchor      string   `json:"anchor"`           // якорь заголовка операции в файле
+	Safety      []string `json:"safety,omitempty"` // safe, idempotent, idempotency-key, destructive
+	Keywords    []string `json:"keywords"`
+}
+
+// searchIndex — содержимое search.json: ключевое слово → номера записей в entries
+type searchIndex struct {
+	Entries  []searchEntry    `json:"entries"`
+	Keywords map[string][]int `json:"keywords"`
+}
+
+// searchStopWords — служебные слова summary, не несущие смысла для поиска
+var searchStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "into": true,
+	"all": true, "its": true, "this": true, "that": true, "are": true, "was": true,
+}
+
+// generateSearchIndex записывает search.json, чтобы RAG-агенты и сайты документации
+// находили нужный файл по пути, operationId, summary и именам параметров, не загружая всё подряд
+func (g *Generator) generateSearchIndex(endpoints []parser.Endpoint) error {
+	index := searchIndex{Entries: []searchEntry{}, Keywords: map[string][]int{}}
+	for i, ep := range endpoints {
+		entry := searchEntry{
+			ID:          ep.Method + " " + ep.Path,
+			OperationID: ep.OperationID,
+			Summary:     ep.Summary,
+			Keywords:    searchKeywords(ep),
+			Anchor:      endpointAnchor(ep),
+			Safety:      safetyInfo(ep).Labels(),
+		}
+		switch {
+		case g.isHTML():
+			entry.File = "groups/" + g.groupPageName(g.groupName(ep))
+		case g.isFlat():
+			entry.File = "llms.txt"
+		default:
+			entry.File = g.endpointPath(ep)
+		}
+		index.Entries = append(index.Entries, entry)
+		for _, keyword := range entry.Keywords {
+			index.Keywords[keyword] = append(index.Keywords[keyword], i)
+		}
+	}
+
+	if err := g.mkdirAll(g.cfg.Output); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode search index: %w", err)
+	}
+	if err := g.writeFile(filepath.Join(g.cfg.Output, SearchIndexFile), append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write %s: %w", SearchIndexFile, err)
+	}
+	return nil
+}
+
+// searchKeywords собирает ключевые слова операции в нижнем регистре: метод, сегменты пути,
+// operationId целиком и по словам, теги, слова summary и имена параметров
+func searchKeywords(ep parser.Endpoint) []string {
+	var keywords []string
+	add := func(words ...string) {
+		for _, w := range words {
+			if w = strings.ToLower(w); w != "" && !slices.Contains(keywords, w) {
+				keywords = append(keywords, w)
+			}
+		}
+	}
+
+	add(ep.Method)
+	for _, segment := range strings.Split(ep.Path, "/") {
+		add(strings.Trim(segment, "{}"))
+	}
+	if ep.OperationID != "" {
+		add(ep.OperationID)
+		add(identifierWords(ep.OperationID)...)
+	}
+	add(ep.Tags...)
+	for _, word := range strings.FieldsFunc(ep.Summary, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(word)) >= 3 && !searchStopWords[strings.ToLower(word)] {
+			add(word)
+		}
+	}
+	for _, p := range ep.Parameters {
+		add(p.Name)
+	}
+
+	slices.Sort(keywords)
+	return keywords
+}
+
+// identifierWords разбивает идентификатор на слова: listUserOrders, list_user-orders → list, user, orders
+func identifierWords(id string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+	for i, r := range []rune(id) {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0 && len(current) > 0 && !unicode.IsUpper(current[len(current)-1]):
+			flush()
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
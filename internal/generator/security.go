@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// generateEndpointSecurity генерирует секцию с требуемыми схемами аутентификации.
+// Для каждой схемы показывается её тип и scopes, а имя ведёт в секцию Authentication llms.txt,
+// чтобы агент, читающий только файл эндпоинта, понимал, что значит ключ схемы
+func (g *Generator) generateEndpointSecurity(ep parser.Endpoint) string {
+	if len(ep.Security) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("### " + g.heading("Authentication") + "\n\n")
+	if len(ep.Security) > 1 {
+		sb.WriteString("Any of:\n\n")
+	}
+
+	for _, req := range ep.Security {
+		names := make([]string, 0, len(req))
+		for name := range req {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		parts := make([]string, 0, len(names))
+		for _, name := range names {
+			parts = append(parts, g.describeSecurityRequirement(name, req[name]))
+		}
+		sb.WriteString("- " + strings.Join(parts, " + ") + "\n")
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// describeSecurityRequirement описывает одну схему: ссылка, человекочитаемый тип и scopes
+func (g *Generator) describeSecurityRequirement(name string, scopes []string) string {
+	text := fmt.Sprintf("[%s](%s#%s)", name, g.indexLink(), slugify(name))
+
+	var details []string
+	if scheme, ok := g.findSecurityScheme(name); ok {
+		details = append(details, securitySchemeLabel(scheme))
+	}
+	if len(scopes) > 0 {
+		details = append(details, "scopes: `"+strings.Join(scopes, "`, `")+"`")
+	}
+	if len(details) > 0 {
+		text += " (" + strings.Join(details, ", ") + ")"
+	}
+	return text
+}
+
+// findSecurityScheme ищет схему аутентификации по имени
+func (g *Generator) findSecurityScheme(name string) (parser.SecurityScheme, bool) {
+	for _, scheme := range g.api.SecuritySchemes {
+		if scheme.Name == name {
+			return scheme, true
+		}
+	}
+	return parser.SecurityScheme{}, false
+}
+
+// securitySchemeLabel возвращает краткое описание типа схемы
+func securitySchemeLabel(scheme parser.SecurityScheme) string {
+	switch scheme.Type {
+	case "apiKey":
+		return fmt.Sprintf("API key in %s `%s`", scheme.In, scheme.ParamName)
+	case "http":
+		switch strings.ToLower(scheme.Scheme) {
+		case "bearer":
+			return "HTTP Bearer token"
+		case "basic":
+			return "HTTP Basic"
+		}
+		return "HTTP " + scheme.Scheme
+	case "oauth2":
+		return "OAuth 2.0"
+	case "openIdConnect":
+		return "OpenID Connect"
+	}
+	return scheme.Type
+}
+
+// oauthFlowNames — названия потоков OAuth 2.0 в документации
+var oauthFlowNames = map[string]string{
+	"authorizationCode": "Authorization Code",
+	"clientCredentials": "Client Credentials",
+	"password":          "Password",
+	"implicit":          "Implicit",
+}
+
+// formatOAuthFlows описывает потоки OAuth 2.0: адреса получения токена и scopes.
+// Одинаковые у всех потоков scopes (типичный случай) выводятся один раз после потоков
+func formatOAuthFlows(flows []parser.OAuthFlow) string {
+	sharedScopes := len(flows) > 0
+	for _, flow := range flows {
+		if !maps.Equal(flow.Scopes, flows[0].Scopes) {
+			sharedScopes = false
+		}
+	}
+
+	var sb strings.Builder
+	for _, flow := range flows {
+		sb.WriteString("- **Flow**: " + oauthFlowNames[flow.Type] + "\n")
+		if flow.AuthorizationURL != "" {
+			sb.WriteString("  - Authorization URL: `" + flow.AuthorizationURL + "`\n")
+		}
+		if flow.TokenURL != "" {
+			sb.WriteString("  - Token URL: `" + flow.TokenURL + "`\n")
+		}
+		if flow.RefreshURL != "" {
+			sb.WriteString("  - Refresh URL: `" + flow.RefreshURL + "`\n")
+		}
+		if !sharedScopes && len(flow.Scopes) > 0 {
+			sb.WriteString("  - Scopes:\n")
+			sb.WriteString(formatScopes(flow.Scopes, "    "))
+		}
+	}
+	if sharedScopes && len(flows[0].Scopes) > 0 {
+		sb.WriteString("- **Scopes**:\n")
+		sb.WriteString(formatScopes(flows[0].Scopes, "  "))
+	}
+	return sb.String()
+}
+
+// formatScopes выводит scopes с описаниями, отсортированные по имени
+func formatScopes(scopes map[string]string, indent string) string {
+	var sb strings.Builder
+	for _, scope := range slices.Sorted(maps.Keys(scopes)) {
+		sb.WriteString(indent + "- `" + scope + "`")
+		if description := scopes[scope]; description != "" {
+			sb.WriteString(" — " + description)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+var slugInvalid = regexp.MustCompile(`[^\p{L}\p{N}_\- ]+`)
+
+// slugify строит якорь заголовка по правилам GitHub Markdown
+func slugify(heading string) string {
+	slug := strings.ToLower(strings.TrimSpace(heading))
+	slug = slugInvalid.ReplaceAllString(slug, "")
+	return strings.ReplaceAll(slug, " ", "-")
+}
@@ -0,0 +1,497 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/config"
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// exampleRequest — готовый к оформлению пример запроса, общий для всех форматов примеров
+type exampleRequest struct {
+	Method      string
+	URL         string
+	ContentType string          // тип содержимого тела; пусто — запрос без тела
+	Headers     []exampleHeader // заголовки аутентификации и прочие, кроме Content-Type
+	Cookies     []exampleHeader // cookie аутентификации (apiKey in: cookie)
+	BasicAuth   string          // user:password для HTTP Basic
+	Body        string          // отформатированное тело запроса (JSON)
+	Form        []formField     // поля формы вместо Body (urlencoded и multipart)
+	Output      string          // файл для сохранения двоичного ответа
+	Stream      bool            // ответ приходит потоком и читается без буферизации
+}
+
+// exampleHeader — заголовок примера запроса
+type exampleHeader struct {
+	Name  string
+	Value string
+}
+
+// buildExampleRequest собирает пример запроса: URL с примерами параметров, заголовки и тело
+func (g *Generator) buildExampleRequest(ep parser.Endpoint) exampleRequest {
+	return g.buildExampleRequestAt(ep, g.endpointBaseURL(ep), nil)
+}
+
+// buildExampleRequestAt собирает пример запроса к заданному базовому URL.
+// values задаёт значения параметров по имени поверх placeholders (например, seed-данные sandbox)
+func (g *Generator) buildExampleRequestAt(ep parser.Endpoint, baseURL string, values map[string]string) exampleRequest {
+	if baseURL == "" || strings.HasPrefix(baseURL, "/") {
+		baseURL = "https://api.example.com" + baseURL
+	}
+
+	// Убираем trailing slash
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	// Формируем путь с примерами параметров
+	path := ep.Path
+	for _, p := range ep.Parameters {
+		if p.In == "path" {
+			example, ok := values[p.Name]
+			if !ok {
+				example, ok = g.paramPlaceholder(ep, p.Name)
+			}
+			switch {
+			case ok:
+				// Значение из конфига (например, id из sandbox) приоритетнее примера из спецификации
+			case isStructuredParam(p):
+				example = serializePathParam(p)
+			case p.Example != nil:
+				example = fmt.Sprintf("%v", p.Example)
+			case p.Type == "integer":
+				example = "1"
+			default:
+				example = "example"
+			}
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", example)
+		}
+	}
+
+	// Query параметры
+	var queryParams []string
+	for _, p := range ep.Parameters {
+		if p.In == "query" {
+			example, ok := values[p.Name]
+			if !ok {
+				example, ok = g.paramPlaceholder(ep, p.Name)
+			}
+			if !ok && isStructuredParam(p) {
+				// Квадратные скобки deepObject кодируются: curl иначе считает их шаблоном URL
+				query := serializeQueryParam(p)
+				query = strings.NewReplacer("[", "%5B", "]", "%5D").Replace(query)
+				queryParams = append(queryParams, query)
+				continue
+			}
+			switch {
+			case ok:
+			case p.Example != nil:
+				example = fmt.Sprintf("%v", p.Example)
+			case len(p.Enum) > 0:
+				example = p.Enum[0]
+			case p.Type == "integer" || p.Type == "number":
+				example = "1"
+			case p.Type == "boolean":
+				example = "true"
+			default:
+				example = "value"
+			}
+			queryParams = append(queryParams, p.Name+"="+example)
+		}
+	}
+
+	req := exampleRequest{
+		Method: ep.Method,
+		Output: g.binaryOutputFile(ep),
+	}
+	// Если ответ бывает в нескольких форматах, основной пример запрашивает предпочтительный явно
+	types := g.negotiatedContentTypes(ep)
+	if types != nil {
+		req.Headers = append(req.Headers, exampleHeader{"Accept", types[0]})
+	}
+	// Потоковый ответ читается без буферизации; события SSE запрашиваются явно
+	if s, ok := streamingInfo(ep); ok {
+		req.Stream = true
+		if s.Format == streamSSE && types == nil {
+			req.Headers = append(req.Headers, exampleHeader{"Accept", "text/event-stream"})
+		}
+	}
+
+	// Аутентификация в том месте запроса, которое объявляет схема
+	apiKey := placeholderOr(g.cfg.Placeholders.APIKey, "YOUR_API_KEY")
+	for _, scheme := range g.exampleAuthSchemes(ep) {
+		switch {
+		case scheme.Type == "apiKey" && scheme.In == "query":
+			queryParams = append(queryParams, scheme.ParamName+"="+apiKey)
+		case scheme.Type == "apiKey" && scheme.In == "cookie":
+			req.Cookies = append(req.Cookies, exampleHeader{scheme.ParamName, apiKey})
+		case scheme.Type == "apiKey":
+			req.Headers = append(req.Headers, exampleHeader{scheme.ParamName, apiKey})
+		case scheme.Type == "oauth2", scheme.Type == "openIdConnect", strings.EqualFold(scheme.Scheme, "bearer"):
+			req.Headers = append(req.Headers, exampleHeader{"Authorization", "Bearer " + placeholderOr(g.cfg.Placeholders.Token, "YOUR_TOKEN")})
+		case strings.EqualFold(scheme.Scheme, "basic"):
+			req.BasicAuth = placeholderOr(g.cfg.Placeholders.Basic, "YOUR_USERNAME:YOUR_PASSWORD")
+		}
+	}
+
+	req.URL = baseURL + path
+	if len(queryParams) > 0 {
+		req.URL += "?" + strings.Join(queryParams, "&")
+	}
+
+	// Request body: пример показывает предпочтительный тип содержимого
+	if contentTypes := exampleBodyContentTypes(ep, g.cfg.ContentTypes); len(contentTypes) > 0 {
+		g.setExampleBody(&req, contentTypes[0], ep.RequestBody.Content[contentTypes[0]])
+		// Готовое тело из overrides заменяет синтезированное по схеме (кроме полей формы)
+		if body, ok := g.overrideRequestBody(ep); ok && len(req.Form) == 0 {
+			req.Body = body
+			req.ContentType = contentTypes[0]
+		}
+	}
+
+	return req
+}
+
+// exampleBodyContentTypes возвращает типы содержимого тела запроса в порядке предпочтения
+// или nil, если в примере тело не передаётся
+func exampleBodyContentTypes(ep parser.Endpoint, preferred []string) []string {
+	if ep.RequestBody == nil || (ep.Method != "POST" && ep.Method != "PUT" && ep.Method != "PATCH") {
+		return nil
+	}
+	return orderContentTypes(ep.RequestBody.Content, preferred)
+}
+
+// setExampleBody заполняет тело примера запроса в формате типа содержимого
+func (g *Generator) setExampleBody(req *exampleRequest, contentType string, media parser.MediaType) {
+	if media.Schema == nil {
+		return
+	}
+	if isFormContentType(contentType) {
+		req.Form = g.formFields(media.Schema)
+	} else if body := g.renderJSONSchema(media.Schema, 0, g.schemaDepth(), 0); body != "" {
+		// Тело запроса рендерится целиком без ограничения полей, чтобы оставаться валидным JSON
+		req.Body = prettyJSON(body)
+		if isXMLContentType(contentType) {
+			req.Body = jsonToXML(body, xmlRootName(media.Schema, "request"))
+		}
+	}
+	if req.Body != "" || len(req.Form) > 0 {
+		req.ContentType = contentType
+	}
+}
+
+// exampleAuthSchemes возвращает схемы аутентификации для примера запроса: все схемы первой
+// альтернативы из security операции, а если операция его не объявляет — первую схему API,
+// которую можно показать в примере
+func (g *Generator) exampleAuthSchemes(ep parser.Endpoint) []parser.SecurityScheme {
+	if len(ep.Security) > 0 {
+		names := make([]string, 0, len(ep.Security[0]))
+		for name := range ep.Security[0] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var schemes []parser.SecurityScheme
+		for _, name := range names {
+			if scheme, ok := g.findSecurityScheme(name); ok && exampleAuthSupported(scheme) {
+				schemes = append(schemes, scheme)
+			}
+		}
+		return schemes
+	}
+
+	for _, scheme := range g.api.SecuritySchemes {
+		if exampleAuthSupported(scheme) {
+			return []parser.SecurityScheme{scheme}
+		}
+	}
+	return nil
+}
+
+// exampleAuthSupported сообщает, что учётные данные схемы можно подставить в пример запроса:
+// API-ключ в заголовке, query или cookie, HTTP Bearer и Basic, а также OAuth 2.0 и OpenID Connect,
+// токен которых передаётся как Bearer
+func exampleAuthSupported(scheme parser.SecurityScheme) bool {
+	switch scheme.Type {
+	case "oauth2", "openIdConnect":
+		return true
+	case "apiKey":
+		return scheme.In == "header" || scheme.In == "query" || scheme.In == "cookie"
+	case "http":
+		return strings.EqualFold(scheme.Scheme, "bearer") || strings.EqualFold(scheme.Scheme, "basic")
+	}
+	return false
+}
+
+// paramPlaceholder возвращает значение параметра из placeholders.params:
+// сначала для операции (<operationId>.<name>), затем по имени параметра
+func (g *Generator) paramPlaceholder(ep parser.Endpoint, name string) (string, bool) {
+	params := g.cfg.Placeholders.Params
+	if ep.OperationID != "" {
+		if value, ok := params[ep.OperationID+"."+name]; ok {
+			return value, true
+		}
+	}
+	value, ok := params[name]
+	return value, ok
+}
+
+// placeholderOr возвращает значение из конфига или заглушку по умолчанию
+func placeholderOr(value, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return fallback
+}
+
+// generateExamples генерирует примеры запроса во всех форматах из конфига (по умолчанию curl).
+// С allContentTypes тело запроса показывается отдельным примером для каждого типа содержимого
+func (g *Generator) generateExamples(ep parser.Endpoint) string {
+	req := g.buildExampleRequest(ep)
+	contentTypes := exampleBodyContentTypes(ep, g.cfg.ContentTypes)
+	if !g.cfg.AllContentTypes || len(contentTypes) < 2 {
+		return g.formatExamples(req)
+	}
+
+	var sb strings.Builder
+	for _, contentType := range contentTypes {
+		variant := req
+		variant.ContentType, variant.Body, variant.Form = "", "", nil
+		g.setExampleBody(&variant, contentType, ep.RequestBody.Content[contentType])
+		sb.WriteString("Content-Type: `" + contentType + "`\n\n")
+		sb.WriteString(g.formatExamples(variant))
+	}
+	return sb.String()
+}
+
+// formatExamples оформляет пример запроса во всех форматах из конфига (по умолчанию curl)
+func (g *Generator) formatExamples(req exampleRequest) string {
+	formats := g.cfg.Examples
+	if len(formats) == 0 {
+		formats = []string{config.ExampleCurl}
+	}
+
+	var sb strings.Builder
+	for _, format := range formats {
+		switch format {
+		case config.ExampleCurl:
+			sb.WriteString(formatCurl(req))
+		case config.ExamplePowerShell:
+			sb.WriteString(formatPowerShell(req))
+		case config.ExampleHTTPie:
+			sb.WriteString(formatHTTPie(req))
+		}
+	}
+	return sb.String()
+}
+
+func (g *Generator) generateCurlExample(ep parser.Endpoint) string {
+	return formatCurl(g.buildExampleRequest(ep))
+}
+
+// formatCurl оформляет пример запроса как команду curl
+func formatCurl(req exampleRequest) string {
+	return "```bash\n" + curlCommand(req, "curl") + "\n```\n\n"
+}
+
+// curlCommand собирает команду curl с заголовками и телом; prefix — начало команды (curl и опции)
+func curlCommand(req exampleRequest, prefix string) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("%s -X %s \"%s\"", prefix, req.Method, req.URL))
+
+	// Headers: Content-Type только при наличии тела; для multipart заголовок с boundary выставляет сам curl
+	if req.ContentType != "" && req.ContentType != contentTypeMultipart {
+		sb.WriteString(" \\\n  -H \"Content-Type: " + req.ContentType + "\"")
+	}
+	for _, h := range req.Headers {
+		sb.WriteString(fmt.Sprintf(" \\\n  -H \"%s: %s\"", h.Name, h.Value))
+	}
+	if cookies := cookieHeader(req.Cookies); cookies != "" {
+		sb.WriteString(" \\\n  -b \"" + cookies + "\"")
+	}
+	if req.BasicAuth != "" {
+		sb.WriteString(" \\\n  -u \"" + req.BasicAuth + "\"")
+	}
+
+	// Request body
+	if req.Body != "" {
+		sb.WriteString(" \\\n  -d " + shellQuote(req.Body))
+	}
+	for _, f := range req.Form {
+		switch {
+		case f.File:
+			sb.WriteString(" \\\n  -F " + shellQuote(f.Name+"=@"+f.Value))
+		case req.ContentType == contentTypeMultipart:
+			sb.WriteString(" \\\n  -F " + shellQuote(f.Name+"="+f.Value))
+		default:
+			sb.WriteString(" \\\n  --data-urlencode " + shellQuote(f.Name+"="+f.Value))
+		}
+	}
+	if req.Output != "" {
+		sb.WriteString(" \\\n  -o " + req.Output)
+	}
+	if req.Stream {
+		sb.WriteString(" \\\n  -N")
+	}
+
+	return sb.String()
+}
+
+// formatPowerShell оформляет пример запроса через Invoke-RestMethod для Windows/PowerShell
+func formatPowerShell(req exampleRequest) string {
+	var sb strings.Builder
+
+	sb.WriteString("```powershell\n")
+
+	// Cookie передаются обычным заголовком, Basic — через учётные данные PowerShell
+	headers := req.Headers
+	if cookies := cookieHeader(req.Cookies); cookies != "" {
+		headers = append(headers[:len(headers):len(headers)], exampleHeader{"Cookie", cookies})
+	}
+	if len(headers) > 0 {
+		sb.WriteString("$headers = @{\n")
+		for _, h := range headers {
+			sb.WriteString(fmt.Sprintf("    %s = %s\n", psQuote(h.Name), psQuote(h.Value)))
+		}
+		sb.WriteString("}\n")
+	}
+
+	if req.Body != "" {
+		// Here-string в одинарных кавычках не интерпретирует $ и кавычки внутри
+		sb.WriteString("$body = @'\n" + req.Body + "\n'@\n")
+	}
+	if len(req.Form) > 0 {
+		// Хеш-таблицу Invoke-RestMethod кодирует как форму; файлы передаются через -Form (PowerShell 6.1+)
+		variable := "$body"
+		if req.ContentType == contentTypeMultipart {
+			variable = "$form"
+		}
+		sb.WriteString(variable + " = @{\n")
+		for _, f := range req.Form {
+			value := psQuote(f.Value)
+			if f.File {
+				value = "Get-Item " + value
+			}
+			sb.WriteString(fmt.Sprintf("    %s = %s\n", psQuote(f.Name), value))
+		}
+		sb.WriteString("}\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("Invoke-RestMethod -Method %s -Uri %s", psMethod(req.Method), psQuote(req.URL)))
+	if len(headers) > 0 {
+		sb.WriteString(" -Headers $headers")
+	}
+	if req.BasicAuth != "" {
+		sb.WriteString(" -Authentication Basic -Credential (Get-Credential)")
+	}
+	switch {
+	case len(req.Form) > 0 && req.ContentType == contentTypeMultipart:
+		sb.WriteString(" -Form $form")
+	case req.Body != "" || len(req.Form) > 0:
+		sb.WriteString(" -ContentType " + psQuote(req.ContentType) + " -Body $body")
+	}
+	if req.Output != "" {
+		sb.WriteString(" -OutFile " + psQuote(req.Output))
+	}
+
+	sb.WriteString("\n```\n\n")
+	return sb.String()
+}
+
+// cookieHeader собирает значение заголовка Cookie: name=value; name2=value2
+func cookieHeader(cookies []exampleHeader) string {
+	pairs := make([]string, 0, len(cookies))
+	for _, c := range cookies {
+		pairs = append(pairs, c.Name+"="+c.Value)
+	}
+	return strings.Join(pairs, "; ")
+}
+
+// psQuote заключает строку в одинарные кавычки PowerShell (без подстановки переменных)
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// psMethod приводит HTTP метод к виду, принятому в PowerShell: GET → Get
+func psMethod(method string) string {
+	if method == "" {
+		return method
+	}
+	return strings.ToUpper(method[:1]) + strings.ToLower(method[1:])
+}
+
+// formatHTTPie оформляет пример запроса для HTTPie: поля JSON-объекта передаются
+// как name=value (строки) и name:=value (прочие JSON значения), что заметно короче curl
+func formatHTTPie(req exampleRequest) string {
+	args := []string{"http"}
+	if len(req.Form) > 0 {
+		if req.ContentType == contentTypeMultipart {
+			args = append(args, "--multipart")
+		} else {
+			args = append(args, "--form")
+		}
+	}
+	if req.BasicAuth != "" {
+		args = append(args, "-a", shellArg(req.BasicAuth))
+	}
+	if req.Output != "" {
+		args = append(args, "--output", req.Output)
+	}
+	if req.Stream {
+		args = append(args, "--stream")
+	}
+	args = append(args, req.Method, shellArg(req.URL))
+	for _, h := range req.Headers {
+		args = append(args, shellArg(h.Name+":"+h.Value))
+	}
+	if cookies := cookieHeader(req.Cookies); cookies != "" {
+		args = append(args, shellArg("Cookie:"+cookies))
+	}
+
+	for _, f := range req.Form {
+		if f.File {
+			args = append(args, shellArg(f.Name+"@"+f.Value))
+		} else {
+			args = append(args, shellArg(f.Name+"="+f.Value))
+		}
+	}
+
+	var stdin string
+	if req.Body != "" {
+		var fields map[string]any
+		if err := json.Unmarshal([]byte(req.Body), &fields); err == nil {
+			names := make([]string, 0, len(fields))
+			for name := range fields {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				if str, ok := fields[name].(string); ok {
+					args = append(args, shellArg(name+"="+str))
+					continue
+				}
+				raw, _ := json.Marshal(fields[name])
+				args = append(args, shellArg(name+":="+string(raw)))
+			}
+		} else {
+			// Тело не является JSON-объектом — передаём как есть через stdin
+			stdin = "echo " + shellQuote(req.Body) + " | "
+		}
+	}
+
+	return "```bash\n" + stdin + strings.Join(args, " ") + "\n```\n\n"
+}
+
+// shellSafe совпадает со строками, которые не нужно заключать в кавычки
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellArg заключает аргумент в кавычки, только если он содержит спецсимволы shell
+func shellArg(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return shellQuote(s)
+}
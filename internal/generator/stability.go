@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// stabilityLevels — известные уровни зрелости в порядке от стабильного к экспериментальному
+var stabilityLevels = []struct {
+	Label   string
+	Meaning string
+}{
+	{"GA", "stable, safe to rely on"},
+	{"beta", "feature-complete, may still change"},
+	{"alpha", "early access, breaking changes likely"},
+	{"experimental", "may change or disappear without notice"},
+}
+
+// normalizeStability приводит значение x-stability / x-maturity к метке бейджа
+func normalizeStability(v any) string {
+	s, _ := v.(string)
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "":
+		return ""
+	case "ga", "stable", "general-availability", "generally-available":
+		return "GA"
+	case "beta", "alpha", "experimental":
+		return strings.ToLower(s)
+	}
+	return s
+}
+
+// extensionStability возвращает уровень зрелости из x-stability или x-maturity
+func extensionStability(ext map[string]any) string {
+	if s := normalizeStability(ext["x-stability"]); s != "" {
+		return s
+	}
+	return normalizeStability(ext["x-maturity"])
+}
+
+// tagStability возвращает уровень зрелости тега
+func (g *Generator) tagStability(name string) string {
+	for _, tag := range g.api.Tags {
+		if tag.Name == name {
+			return extensionStability(tag.Extensions)
+		}
+	}
+	return ""
+}
+
+// stability возвращает уровень зрелости операции; без своей отметки операция наследует её от тега
+func (g *Generator) stability(ep parser.Endpoint) string {
+	if s := extensionStability(ep.Extensions); s != "" {
+		return s
+	}
+	for _, tag := range ep.Tags {
+		if s := g.tagStability(tag); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// stabilityBadge оформляет бейдж для заголовка; пустая строка, если уровень не задан
+func stabilityBadge(level string) string {
+	if level == "" {
+		return ""
+	}
+	return " `" + level + "`"
+}
+
+// generateStabilityLegend генерирует легенду бейджей, встречающихся в документации
+func (g *Generator) generateStabilityLegend(endpoints []parser.Endpoint) string {
+	used := make(map[string]bool)
+	for _, ep := range endpoints {
+		if s := g.stability(ep); s != "" {
+			used[s] = true
+		}
+	}
+	if len(used) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## " + g.heading("Stability") + "\n\n")
+	sb.WriteString("Operations are marked with their maturity. Prefer `GA` operations when alternatives exist.\n\n")
+	for _, level := range stabilityLevels {
+		if used[level.Label] {
+			sb.WriteString(fmt.Sprintf("- `%s` — %s\n", level.Label, level.Meaning))
+			delete(used, level.Label)
+		}
+	}
+	// Нестандартные уровни — как объявлены в спецификации
+	custom := make([]string, 0, len(used))
+	for label := range used {
+		custom = append(custom, label)
+	}
+	sort.Strings(custom)
+	for _, label := range custom {
+		sb.WriteString(fmt.Sprintf("- `%s` — as declared in the spec\n", label))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
@@ -0,0 +1,143 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// writerPool переиспользует буферы записи: для тысяч файлов эндпоинтов новый буфер на каждый
+// файл дал бы больше аллокаций, чем само содержимое
+var writerPool = sync.Pool{New: func() any { return bufio.NewWriterSize(nil, 64*1024) }}
+
+// fileWriter записывает сгенерированный файл потоком через буфер, не собирая его содержимое
+// в памяти целиком. Хеш и размер для манифестов считаются по ходу записи.
+// Ошибки записи запоминаются и возвращаются из Close
+type fileWriter struct {
+	g    *Generator
+	path string // итоговый путь файла
+	rel  string // путь относительно директории вывода через /
+
+	tmp    *os.File      // временный файл рядом с итоговым; nil при генерации в память
+	memory *bytes.Buffer // содержимое при генерации в память (Render)
+	buf    *bufio.Writer
+	hash   hash.Hash
+	size   int64
+}
+
+// createFile начинает запись сгенерированного файла. Содержимое пишется во временный файл
+// в той же директории и заменяет итоговый в Close: прерванная генерация не оставляет обрезанных
+// файлов, а в инкрементальном режиме файл с хешем из манифеста не перезаписывается
+func (g *Generator) createFile(path string) (*fileWriter, error) {
+	if err := g.ctx.Err(); err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(g.cfg.Output, path)
+	if err != nil {
+		return nil, err
+	}
+
+	fw := &fileWriter{g: g, path: path, rel: filepath.ToSlash(rel), hash: sha256.New()}
+	var dst io.Writer
+	if g.memory != nil {
+		fw.memory = &bytes.Buffer{}
+		dst = fw.memory
+	} else {
+		tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+		if err != nil {
+			return nil, err
+		}
+		fw.tmp = tmp
+		dst = tmp
+	}
+	fw.buf = writerPool.Get().(*bufio.Writer)
+	fw.buf.Reset(dst)
+	return fw, nil
+}
+
+func (fw *fileWriter) Write(p []byte) (int, error) {
+	fw.hash.Write(p)
+	fw.size += int64(len(p))
+	return fw.buf.Write(p)
+}
+
+func (fw *fileWriter) WriteString(s string) (int, error) {
+	io.WriteString(fw.hash, s)
+	fw.size += int64(len(s))
+	return fw.buf.WriteString(s)
+}
+
+// fileHash возвращает sha256 содержимого файла; пустая строка, если файл не прочитать
+func fileHash(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// streamFile записывает файл, содержимое которого пишет write
+func (g *Generator) streamFile(path string, write func(w io.StringWriter)) error {
+	fw, err := g.createFile(path)
+	if err != nil {
+		return err
+	}
+	write(fw)
+	return fw.Close()
+}
+
+// Close дописывает буфер и заменяет итоговый файл временным
+func (fw *fileWriter) Close() error {
+	err := fw.buf.Flush()
+	fw.buf.Reset(nil)
+	writerPool.Put(fw.buf)
+	if fw.tmp != nil {
+		// CreateTemp создаёт файл с правами 0600, а сгенерированные файлы публикуются
+		if err == nil {
+			err = fw.tmp.Chmod(0644)
+		}
+		if closeErr := fw.tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(fw.tmp.Name())
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	hash := hex.EncodeToString(fw.hash.Sum(nil))
+	fw.g.recordPublished(fw.rel, hash, fw.size)
+	if fw.memory != nil {
+		fw.g.memory[fw.rel] = fw.memory.Bytes()
+		return nil
+	}
+
+	// Манифест говорит, каким файл был записан, но файл могли изменить после этого:
+	// запись пропускается, только если совпадает и хеш содержимого на диске
+	if fw.g.cfg.Incremental {
+		fw.g.written[fw.rel] = hash
+		if fw.g.previous[fw.rel] == hash && fileHash(fw.path) == hash {
+			return os.Remove(fw.tmp.Name())
+		}
+	}
+	if err := os.Rename(fw.tmp.Name(), fw.path); err != nil {
+		os.Remove(fw.tmp.Name())
+		return fmt.Errorf("failed to replace %s: %w", fw.path, err)
+	}
+	return nil
+}
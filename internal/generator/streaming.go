@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// Форматы потоковых ответов
+const (
+	streamSSE     = "sse"     // Server-Sent Events (text/event-stream)
+	streamNDJSON  = "ndjson"  // JSON-запись на строку
+	streamChunked = "chunked" // тело приходит частями без определённого формата
+)
+
+// streaming — сведения о потоковом ответе операции
+type streaming struct {
+	Format      string // streamSSE, streamNDJSON или streamChunked
+	Terminator  string // чем заканчивается поток, например data: [DONE]
+	Description string // пояснение из x-streaming
+}
+
+// streamingInfo определяет, что операция отвечает потоком: успешный ответ text/event-stream или
+// расширение x-streaming — true, строка с пояснением или объект с полями format, terminator
+// и description. x-streaming: false снимает отметку с text/event-stream
+func streamingInfo(ep parser.Endpoint) (streaming, bool) {
+	var s streaming
+	marked := false
+	switch v := ep.Extensions["x-streaming"].(type) {
+	case bool:
+		if !v {
+			return s, false
+		}
+		marked = true
+	case string:
+		s.Description = strings.TrimSpace(v)
+		marked = s.Description != ""
+	case map[string]any:
+		s.Format = strings.ToLower(extensionField(v, "format"))
+		s.Terminator = extensionField(v, "terminator")
+		s.Description = extensionField(v, "description")
+		marked = true
+	}
+
+	if resp, ok := successResponse(ep); ok && s.Format == "" {
+		for contentType := range resp.Content {
+			switch {
+			case mediaTypeBase(contentType) == "text/event-stream":
+				s.Format = streamSSE
+			case marked && isNDJSONContent(contentType) && s.Format != streamSSE:
+				s.Format = streamNDJSON
+			}
+		}
+	}
+	if s.Format == "" {
+		if !marked {
+			return s, false
+		}
+		s.Format = streamChunked
+	}
+	return s, true
+}
+
+// extensionField возвращает строковое поле объекта расширения
+func extensionField(ext map[string]any, name string) string {
+	v, _ := ext[name].(string)
+	return strings.TrimSpace(v)
+}
+
+// generateStreamingNote описывает семантику потокового ответа: формат событий и условие
+// завершения. Агенту нужно знать, что вызов отвечает потоком, а не один раз
+func (g *Generator) generateStreamingNote(ep parser.Endpoint) string {
+	s, ok := streamingInfo(ep)
+	if !ok {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("### " + g.heading("Streaming") + "\n\n")
+	switch s.Format {
+	case streamSSE:
+		sb.WriteString("The response is a stream of Server-Sent Events (`text/event-stream`), not a single body: " +
+			"keep the connection open and process events as they arrive (`curl -N`). " +
+			"Each event is a block of `event:`, `id:` and `data:` lines ended by a blank line; `data` carries the payload described under Responses.")
+	case streamNDJSON:
+		sb.WriteString("The response is streamed as newline-delimited JSON, not a single body: " +
+			"each line is a complete record, process lines as they arrive (`curl -N`).")
+	default:
+		sb.WriteString("The response is streamed in chunks as it is produced: " +
+			"read it incrementally instead of waiting for the full body (`curl -N`).")
+	}
+	if s.Terminator != "" {
+		sb.WriteString(" The stream ends with `" + s.Terminator + "`.")
+	} else {
+		sb.WriteString(" The stream ends when the server closes the connection.")
+	}
+	sb.WriteString("\n\n")
+	if s.Description != "" {
+		sb.WriteString(g.description(s.Description) + "\n\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,187 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/config"
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// summaryTemplates — шаблоны синтезированных summary; %s — имя ресурса из пути (с артиклем для английского)
+var summaryTemplates = map[string]map[string]string{
+	config.LanguageEN: {
+		"list":    "Retrieve a list of %s",
+		"GET":     "Retrieve %s",
+		"GET one": "Retrieve %s",
+		"POST":    "Create %s",
+		"PUT":     "Replace %s",
+		"PATCH":   "Update %s",
+		"DELETE":  "Delete %s",
+		"HEAD":    "Check %s",
+		"action":  "%s %s",
+	},
+	config.LanguageRU: {
+		"list":    "Получить список %s",
+		"GET":     "Получить %s",
+		"GET one": "Получить %s",
+		"POST":    "Создать %s",
+		"PUT":     "Заменить %s",
+		"PATCH":   "Обновить %s",
+		"DELETE":  "Удалить %s",
+		"HEAD":    "Проверить %s",
+		"action":  "%s %s",
+	},
+}
+
+// fillSummaries подставляет синтезированные summary операциям без него,
+// чтобы строки индекса и заголовки никогда не были пустыми
+func (g *Generator) fillSummaries(endpoints []parser.Endpoint) {
+	if g.cfg.NoAutoSummaries {
+		return
+	}
+	for i := range endpoints {
+		if strings.TrimSpace(endpoints[i].Summary) == "" {
+			endpoints[i].Summary = g.synthesizeSummary(endpoints[i])
+		}
+	}
+}
+
+// synthesizeSummary строит summary по методу, пути и схеме успешного ответа детерминированными шаблонами:
+// GET /users → "Retrieve a list of users", GET /users/{id} → "Retrieve a user",
+// POST /users/{id}/activate → "Activate a user", POST /things/{id}:cancel → "Cancel a thing".
+// Пустая строка — ресурс по пути не определить
+func (g *Generator) synthesizeSummary(ep parser.Endpoint) string {
+	templates := summaryTemplates[config.LanguageEN]
+	if t, ok := summaryTemplates[g.language()]; ok {
+		templates = t
+	}
+
+	// Пользовательский метод в стиле Google AIP после двоеточия: /things/{id}:cancel, /things:batchGet
+	path, method := customMethod(ep.Path)
+
+	// Сегменты ресурсов без версий; byID — путь заканчивается параметром
+	var segments []string
+	byID := false
+	for _, seg := range strings.Split(strings.Trim(path, "/"), "/") {
+		switch {
+		case seg == "" || versionSegment.MatchString(seg):
+		case strings.HasPrefix(seg, "{"):
+			byID = true
+		default:
+			segments = append(segments, seg)
+			byID = false
+		}
+	}
+	if len(segments) == 0 {
+		return ""
+	}
+	resource := resourceWords(segments[len(segments)-1])
+	if resource == "" {
+		return ""
+	}
+
+	// Глагол пользовательского метода относится к одному ресурсу или ко всей коллекции
+	if method != "" {
+		object := resource
+		if byID {
+			object = g.withArticle(singular(resource))
+		}
+		return fmt.Sprintf(templates["action"], capitalize(method), object)
+	}
+
+	// POST /users/{id}/activate — действие над ресурсом, а не создание
+	if ep.Method == "POST" && !byID && len(segments) > 1 && !strings.HasSuffix(resource, "s") &&
+		strings.Contains(ep.Path, "}/"+segments[len(segments)-1]) {
+		return fmt.Sprintf(templates["action"], capitalize(resource), g.withArticle(singular(resourceWords(segments[len(segments)-2]))))
+	}
+
+	switch ep.Method {
+	case "GET":
+		if !byID && (returnsArray(ep) || strings.HasSuffix(resource, "s")) {
+			return fmt.Sprintf(templates["list"], resource)
+		}
+		if byID {
+			return fmt.Sprintf(templates["GET one"], g.withArticle(singular(resource)))
+		}
+		return fmt.Sprintf(templates["GET"], resource)
+	case "POST", "PUT", "PATCH", "DELETE", "HEAD":
+		return fmt.Sprintf(templates[ep.Method], g.withArticle(singular(resource)))
+	}
+	return ""
+}
+
+// customMethod отделяет пользовательский метод от последнего сегмента пути:
+// /things/{id}:cancel → /things/{id} и "cancel", /things:batchGet → /things и "batch get".
+// Двоеточие внутри параметра в фигурных скобках методом не считается
+func customMethod(path string) (string, string) {
+	last := path[strings.LastIndexByte(path, '/')+1:]
+	i := strings.LastIndexByte(last, ':')
+	if i < 0 || i < strings.LastIndexByte(last, '}') {
+		return path, ""
+	}
+	return path[:len(path)-len(last)+i], resourceWords(last[i+1:])
+}
+
+// capitalize переводит первую букву в верхний регистр: cancel → Cancel
+func capitalize(words string) string {
+	if words == "" {
+		return ""
+	}
+	return strings.ToUpper(words[:1]) + words[1:]
+}
+
+// withArticle добавляет английский неопределённый артикль: a user, an address
+func (g *Generator) withArticle(noun string) string {
+	if g.language() == config.LanguageRU {
+		return noun
+	}
+	vowel := func(i int) bool { return i < len(noun) && strings.IndexByte("aeiou", noun[i]) >= 0 }
+	// user, unit читаются с согласного звука: u + согласная + гласная
+	if vowel(0) && !(noun[0] == 'u' && !vowel(1) && vowel(2)) {
+		return "an " + noun
+	}
+	return "a " + noun
+}
+
+// resourceWords превращает сегмент пути в слова: order-items, order_items, orderItems → order items
+func resourceWords(segment string) string {
+	return strings.ToLower(strings.Join(identifierWords(segment), " "))
+}
+
+// singular приводит последнее слово к единственному числу простыми правилами английского
+func singular(words string) string {
+	switch {
+	case strings.HasSuffix(words, "ies"):
+		return strings.TrimSuffix(words, "ies") + "y"
+	case strings.HasSuffix(words, "sses"), strings.HasSuffix(words, "xes"),
+		strings.HasSuffix(words, "ches"), strings.HasSuffix(words, "shes"), strings.HasSuffix(words, "uses"):
+		return strings.TrimSuffix(words, "es")
+	case strings.HasSuffix(words, "ss"), strings.HasSuffix(words, "us"):
+		return words
+	case strings.HasSuffix(words, "s"):
+		return strings.TrimSuffix(words, "s")
+	}
+	return words
+}
+
+// returnsArray сообщает, что первый успешный ответ операции — массив
+func returnsArray(ep parser.Endpoint) bool {
+	codes := make([]string, 0, len(ep.Responses))
+	for code := range ep.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	if len(codes) == 0 {
+		return false
+	}
+	for _, media := range ep.Responses[codes[0]].Content {
+		if media.Schema != nil && media.Schema.Type == "array" {
+			return true
+		}
+	}
+	return false
+}
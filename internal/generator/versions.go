@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Файлы версионированного вывода (versioned) в корне директории вывода
+const (
+	LatestLink   = "latest"       // ссылка на директорию старшей версии
+	VersionsFile = "versions.txt" // список версий со ссылками на их llms.txt
+)
+
+// unsafeVersionChars — символы версии, недопустимые в имени директории
+var unsafeVersionChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// VersionDir возвращает имя директории версии API (info.version) внутри директории вывода
+func VersionDir(version string) (string, error) {
+	dir := strings.Trim(unsafeVersionChars.ReplaceAllString(strings.TrimSpace(version), "-"), "-.")
+	if dir == "" || dir == LatestLink {
+		return "", fmt.Errorf("versioned output requires info.version in the spec, got %q", version)
+	}
+	return dir, nil
+}
+
+// UpdateVersionIndex обновляет корень версионированного вывода: ссылку latest на старшую версию
+// и versions.txt со списком всех версий, для которых сгенерирован llms.txt
+func UpdateVersionIndex(root, title string) error {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", root, err)
+	}
+	var versions []string
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == LatestLink {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(root, entry.Name(), "llms.txt")); err == nil {
+			versions = append(versions, entry.Name())
+		}
+	}
+	if len(versions) == 0 {
+		return nil
+	}
+	// Новые версии первыми
+	slices.SortFunc(versions, func(a, b string) int { return compareVersions(b, a) })
+
+	link := filepath.Join(root, LatestLink)
+	if target, err := os.Readlink(link); err != nil || target != versions[0] {
+		if err := os.Remove(link); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to replace %s: %w", link, err)
+		}
+		if err := os.Symlink(versions[0], link); err != nil {
+			return fmt.Errorf("failed to link %s: %w", link, err)
+		}
+	}
+
+	var sb strings.Builder
+	if title == "" {
+		title = "API"
+	}
+	sb.WriteString("# " + title + " versions\n\n")
+	for i, v := range versions {
+		line := fmt.Sprintf("- [%s](./%s/llms.txt)", v, v)
+		if i == 0 {
+			line += " — latest, also at [./" + LatestLink + "/llms.txt](./" + LatestLink + "/llms.txt)"
+		}
+		sb.WriteString(line + "\n")
+	}
+	if err := os.WriteFile(filepath.Join(root, VersionsFile), []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", VersionsFile, err)
+	}
+	return nil
+}
+
+// compareVersions сравнивает версии по числовым компонентам: 1.10.0 > 1.9.2, v2 > v1.5.
+// Нечисловые компоненты сравниваются как строки; версия с суффиксом (1.0.0-beta) младше версии без него
+func compareVersions(a, b string) int {
+	pa := versionParts(a)
+	pb := versionParts(b)
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		na, errA := strconv.Atoi(pa[i])
+		nb, errB := strconv.Atoi(pb[i])
+		switch {
+		case errA == nil && errB == nil:
+			if na != nb {
+				return na - nb
+			}
+		case pa[i] != pb[i]:
+			return strings.Compare(pa[i], pb[i])
+		}
+	}
+	if len(pa) != len(pb) {
+		// Лишний компонент: числовой (1.0.1) — новее, суффикс предрелиза (1.0.0-beta) — старше
+		longer, sign := pa, 1
+		if len(pb) > len(pa) {
+			longer, sign = pb, -1
+		}
+		if _, err := strconv.Atoi(longer[min(len(pa), len(pb))]); err != nil {
+			sign = -sign
+		}
+		return sign
+	}
+	return 0
+}
+
+// versionParts разбивает версию на компоненты по точкам и дефисам без префикса v
+func versionParts(v string) []string {
+	v = strings.TrimPrefix(strings.ToLower(v), "v")
+	return strings.FieldsFunc(v, func(r rune) bool { return r == '.' || r == '-' || r == '_' })
+}
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// websocketURL возвращает адрес подключения: url из x-websocket или адрес примера запроса
+// со схемой ws(s) вместо http(s)
+func (g *Generator) websocketURL(ep parser.Endpoint, req exampleRequest) string {
+	if ep.WebSocket.URL != "" {
+		return ep.WebSocket.URL
+	}
+	if rest, ok := strings.CutPrefix(req.URL, "https://"); ok {
+		return "wss://" + rest
+	}
+	if rest, ok := strings.CutPrefix(req.URL, "http://"); ok {
+		return "ws://" + rest
+	}
+	return req.URL
+}
+
+// generateWebSocket описывает подключение WebSocket: адрес, подпротоколы и схемы сообщений
+// в обе стороны. Без этой секции в документации виден только HTTP-запрос на upgrade
+func (g *Generator) generateWebSocket(ep parser.Endpoint) string {
+	ws := ep.WebSocket
+	if ws == nil {
+		return ""
+	}
+	req := g.buildExampleRequest(ep)
+	url := g.websocketURL(ep, req)
+
+	var sb strings.Builder
+	sb.WriteString("### WebSocket\n\n")
+	sb.WriteString("This operation upgrades the connection to a WebSocket: messages flow in both directions until either side closes it.\n\n")
+	sb.WriteString("- URL: `" + url + "`\n")
+	if len(ws.Subprotocols) > 0 {
+		sb.WriteString("- Subprotocols: `" + strings.Join(ws.Subprotocols, "`, `") + "` (`Sec-WebSocket-Protocol` header)\n")
+	}
+	if ws.AsyncAPI != "" {
+		sb.WriteString("- AsyncAPI: " + ws.AsyncAPI + "\n")
+	}
+	sb.WriteString("\n")
+	if desc := g.description(ws.Description); desc != "" {
+		sb.WriteString(desc + "\n\n")
+	}
+
+	for _, msg := range ws.Send {
+		sb.WriteString(g.websocketMessage("Client → server", msg))
+	}
+	for _, msg := range ws.Receive {
+		sb.WriteString(g.websocketMessage("Server → client", msg))
+	}
+
+	// Пример подключения через websocat с заголовками аутентификации
+	args := []string{"websocat"}
+	if len(ws.Subprotocols) > 0 {
+		args = append(args, "--protocol", shellArg(ws.Subprotocols[0]))
+	}
+	for _, h := range req.Headers {
+		args = append(args, "-H", "\""+h.Name+": "+h.Value+"\"")
+	}
+	args = append(args, "\""+url+"\"")
+	sb.WriteString("```bash\n" + strings.Join(args, " ") + "\n```\n\n")
+	return sb.String()
+}
+
+// websocketMessage описывает одно сообщение WebSocket: направление, имя, описание и схему
+func (g *Generator) websocketMessage(direction string, msg parser.WebSocketMessage) string {
+	var sb strings.Builder
+	heading := "#### " + direction
+	if msg.Name != "" {
+		heading += ": `" + msg.Name + "`"
+	}
+	sb.WriteString(heading + "\n\n")
+	if desc := g.description(msg.Description); desc != "" {
+		sb.WriteString(desc + "\n\n")
+	}
+	if msg.Schema != nil {
+		if name := msg.Schema.RefName(); name != "" && len(msg.Schema.Properties) == 0 {
+			sb.WriteString("Schema: `" + name + "`\n\n")
+		}
+		sb.WriteString(g.generateSchemaDoc(msg.Schema, 0))
+	}
+	return sb.String()
+}
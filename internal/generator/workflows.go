@@ -0,0 +1,164 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// generateWorkflows описывает сценарии — последовательности вызовов (создать → дождаться → получить
+// результат) с передачей данных между шагами. Агенту готовый рецепт полезнее разрозненных эндпоинтов
+func (g *Generator) generateWorkflows() string {
+	if len(g.workflows) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## " + g.heading("Workflows") + "\n\n")
+	for _, w := range g.workflows {
+		title := w.Summary
+		if title == "" {
+			title = w.ID
+		}
+		sb.WriteString("### " + title + " (`" + w.ID + "`)\n\n")
+		if desc := g.description(w.Description); desc != "" {
+			sb.WriteString(desc + "\n\n")
+		}
+		if len(w.Inputs) > 0 {
+			sb.WriteString("Inputs: `" + strings.Join(w.Inputs, "`, `") + "`\n\n")
+		}
+
+		for i, step := range w.Steps {
+			sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, g.workflowStepLine(step)))
+			for _, detail := range workflowStepDetails(step) {
+				sb.WriteString("  - " + detail + "\n")
+			}
+		}
+		sb.WriteString("\n")
+
+		if len(w.Outputs) > 0 {
+			sb.WriteString("Result: " + describeOutputs(w.Outputs) + "\n\n")
+		}
+	}
+	return sb.String()
+}
+
+// workflowStepLine — первая строка шага: id, вызываемая операция и описание
+func (g *Generator) workflowStepLine(step parser.WorkflowStep) string {
+	line := "**" + step.ID + "**"
+	switch {
+	case step.WorkflowID != "":
+		line += " — run workflow `" + step.WorkflowID + "`"
+	case step.OperationID != "":
+		line += " — " + g.workflowOperation(step.OperationID)
+	case step.OperationPath != "":
+		if op := operationRefTarget(step.OperationPath); op != "" {
+			line += " — " + g.workflowOperation(op)
+		}
+	}
+	if step.Description != "" {
+		line += ": " + g.inlineDescription(step.Description)
+	}
+	return line
+}
+
+// workflowOperation ссылается на операцию сценария по operationId или "METHOD /path"
+func (g *Generator) workflowOperation(op string) string {
+	ep, ok := g.findOperation(op)
+	if !ok {
+		return "`" + op + "`"
+	}
+	return g.indexOperationReference(ep)
+}
+
+// indexOperationReference — ссылка на операцию из llms.txt: в плоском режиме секции операций
+// находятся в том же файле (ссылка на якорь), иначе — ссылка на файл эндпоинта
+func (g *Generator) indexOperationReference(ep parser.Endpoint) string {
+	link := "#" + endpointAnchor(ep)
+	if !g.isFlat() {
+		link = g.endpointLink(ep, g.groupName(ep))
+	}
+	return "[`" + ep.Method + " " + ep.Path + "`](" + link + ")"
+}
+
+// workflowStepDetails перечисляет входные данные, условие успеха, переходы и результаты шага
+func workflowStepDetails(step parser.WorkflowStep) []string {
+	var details []string
+
+	var inputs []string
+	for _, name := range slices.Sorted(maps.Keys(step.Parameters)) {
+		inputs = append(inputs, describeLinkExpression(step.Parameters[name])+" as `"+name+"`")
+	}
+	if step.RequestBody != "" {
+		inputs = append(inputs, describeLinkExpression(step.RequestBody)+" as the request body")
+	}
+	if len(inputs) > 0 {
+		details = append(details, "Pass "+strings.Join(inputs, ", "))
+	}
+
+	if len(step.SuccessCriteria) > 0 {
+		details = append(details, "Succeeds when `"+strings.Join(step.SuccessCriteria, "` and `")+"`")
+	}
+	for _, a := range step.OnSuccess {
+		if action := describeWorkflowAction(a); action != "" {
+			details = append(details, "On success: "+action)
+		}
+	}
+	for _, a := range step.OnFailure {
+		if action := describeWorkflowAction(a); action != "" {
+			details = append(details, "On failure: "+action)
+		}
+	}
+
+	if len(step.Outputs) > 0 {
+		details = append(details, "Saves "+describeOutputs(step.Outputs))
+	}
+	return details
+}
+
+// describeWorkflowAction описывает переход после шага словами
+func describeWorkflowAction(a parser.WorkflowAction) string {
+	target := ""
+	switch {
+	case a.StepID != "":
+		target = "step `" + a.StepID + "`"
+	case a.WorkflowID != "":
+		target = "workflow `" + a.WorkflowID + "`"
+	}
+
+	switch a.Type {
+	case "end":
+		return "end the workflow"
+	case "goto":
+		if target == "" {
+			return ""
+		}
+		return "go to " + target
+	case "retry":
+		action := "retry"
+		if target != "" {
+			action += " from " + target
+		}
+		if a.RetryAfter > 0 {
+			action += " after " + strconv.FormatFloat(a.RetryAfter, 'f', -1, 64) + "s"
+		}
+		if a.RetryLimit > 0 {
+			action += fmt.Sprintf(", up to %d times", a.RetryLimit)
+		}
+		return action
+	}
+	return ""
+}
+
+// describeOutputs перечисляет результаты: `id` = `id` from the response body
+func describeOutputs(outputs map[string]string) string {
+	var parts []string
+	for _, name := range slices.Sorted(maps.Keys(outputs)) {
+		parts = append(parts, "`"+name+"` = "+describeLinkExpression(outputs[name]))
+	}
+	return strings.Join(parts, ", ")
+}
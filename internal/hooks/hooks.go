@@ -0,0 +1,138 @@
+// Package hooks запускает команды и Go-колбэки до парсинга спецификации и после генерации
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Префикс элемента списка хуков, ссылающегося на зарегистрированный Go-колбэк
+const goPrefix = "go:"
+
+// waitDelay ограничивает ожидание вывода команды после её остановки по отмене контекста:
+// дочерние процессы shell могут держать открытыми pipe ещё долго
+const waitDelay = 3 * time.Second
+
+// PreParseFunc преобразует исходный текст спецификации до парсинга
+type PreParseFunc func(source string, data []byte) ([]byte, error)
+
+// PostGenerateFunc вызывается после генерации с директорией вывода
+type PostGenerateFunc func(source, output string) error
+
+// Stdout получает вывод команд postGenerate. CLI перенаправляет его в stderr, когда stdout
+// занят машиночитаемым отчётом
+var Stdout io.Writer = os.Stdout
+
+var (
+	mu           sync.RWMutex
+	preParse     = map[string]PreParseFunc{}
+	postGenerate = map[string]PostGenerateFunc{}
+)
+
+// RegisterPreParse регистрирует колбэк, доступный в hooks.preParse как go:<name>
+func RegisterPreParse(name string, fn PreParseFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+	preParse[name] = fn
+}
+
+// RegisterPostGenerate регистрирует колбэк, доступный в hooks.postGenerate как go:<name>
+func RegisterPostGenerate(name string, fn PostGenerateFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+	postGenerate[name] = fn
+}
+
+// PreParse пропускает спецификацию через хуки по порядку. Команда получает
+// спецификацию на stdin и печатает изменённую на stdout. Отмена ctx останавливает команду
+func PreParse(ctx context.Context, hooks []string, source string, data []byte) ([]byte, error) {
+	for _, hook := range hooks {
+		var err error
+		if name, ok := strings.CutPrefix(hook, goPrefix); ok {
+			mu.RLock()
+			fn, found := preParse[name]
+			mu.RUnlock()
+			if !found {
+				return nil, fmt.Errorf("unknown preParse hook %q", hook)
+			}
+			data, err = fn(source, data)
+		} else {
+			data, err = runPreParse(ctx, hook, source, data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("preParse hook %q: %w", hook, err)
+		}
+	}
+	return data, nil
+}
+
+// PostGenerate запускает хуки после генерации. Вывод команд идёт в Stdout и stderr процесса,
+// отмена ctx останавливает команду
+func PostGenerate(ctx context.Context, hooks []string, source, output string) error {
+	for _, hook := range hooks {
+		var err error
+		if name, ok := strings.CutPrefix(hook, goPrefix); ok {
+			mu.RLock()
+			fn, found := postGenerate[name]
+			mu.RUnlock()
+			if !found {
+				return fmt.Errorf("unknown postGenerate hook %q", hook)
+			}
+			err = fn(source, output)
+		} else {
+			cmd := shellCommand(ctx, hook, source, output)
+			cmd.Stdout = Stdout
+			cmd.Stderr = os.Stderr
+			if err = cmd.Run(); ctx.Err() != nil {
+				err = ctx.Err()
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("postGenerate hook %q: %w", hook, err)
+		}
+	}
+	return nil
+}
+
+func runPreParse(ctx context.Context, command, source string, data []byte) ([]byte, error) {
+	cmd := shellCommand(ctx, command, source, "")
+	cmd.Stdin = bytes.NewReader(data)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if stdout.Len() == 0 {
+		return nil, fmt.Errorf("command printed an empty spec")
+	}
+	return stdout.Bytes(), nil
+}
+
+// shellCommand запускает команду через shell платформы. Источник и директория вывода
+// передаются в переменных окружения SPEC2LLMS_SOURCE и SPEC2LLMS_OUTPUT
+func shellCommand(ctx context.Context, command, source, output string) *exec.Cmd {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.WaitDelay = waitDelay
+	cmd.Env = append(os.Environ(), "SPEC2LLMS_SOURCE="+source)
+	if output != "" {
+		cmd.Env = append(cmd.Env, "SPEC2LLMS_OUTPUT="+output)
+	}
+	return cmd
+}
@@ -0,0 +1,68 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPreParse(t *testing.T) {
+	RegisterPreParse("upper-title", func(source string, data []byte) ([]byte, error) {
+		return []byte(strings.ReplaceAll(string(data), "title: pets", "title: Pets")), nil
+	})
+
+	data, err := PreParse(context.Background(), []string{"sed s/v1/v2/", "go:upper-title"}, "spec.yaml", []byte("title: pets\nversion: v1\n"))
+	if err != nil {
+		t.Fatalf("PreParse failed: %v", err)
+	}
+	if string(data) != "title: Pets\nversion: v2\n" {
+		t.Errorf("unexpected preprocessed spec: %q", data)
+	}
+
+	if _, err := PreParse(context.Background(), []string{"go:missing"}, "spec.yaml", data); err == nil {
+		t.Error("expected error for unregistered hook")
+	}
+	if _, err := PreParse(context.Background(), []string{"exit 3"}, "spec.yaml", data); err == nil {
+		t.Error("expected error for failing command")
+	}
+}
+
+func TestPostGenerate(t *testing.T) {
+	output := t.TempDir()
+	var called string
+	RegisterPostGenerate("record", func(source, out string) error {
+		called = source + " -> " + out
+		return nil
+	})
+
+	hooks := []string{`echo "$SPEC2LLMS_SOURCE" > "$SPEC2LLMS_OUTPUT/uploaded"`, "go:record"}
+	if err := PostGenerate(context.Background(), hooks, "spec.yaml", output); err != nil {
+		t.Fatalf("PostGenerate failed: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(output, "uploaded")); string(data) != "spec.yaml\n" {
+		t.Errorf("command did not see environment: %q", data)
+	}
+	if called != "spec.yaml -> "+output {
+		t.Errorf("callback not called with source and output: %q", called)
+	}
+}
+
+func TestHooksStopOnCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := PreParse(ctx, []string{"sleep 10"}, "spec.yaml", []byte("openapi: 3.0.0\n")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected preParse hook to stop with the context, got %v", err)
+	}
+	if err := PostGenerate(ctx, []string{"sleep 10"}, "spec.yaml", t.TempDir()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected postGenerate hook to stop with the context, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("hooks kept running after cancellation: %v", elapsed)
+	}
+}
@@ -0,0 +1,133 @@
+// Package lint проверяет качество спецификации с точки зрения документации для LLM-агентов
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// Уровни серьёзности замечаний
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Finding — замечание линтера с местом в исходной спецификации
+type Finding struct {
+	Rule     string          `json:"rule"`
+	Severity string          `json:"severity"`
+	Message  string          `json:"message"`
+	Location parser.Location `json:"location"`
+}
+
+// Format форматирует замечание как file:line:column: severity: message (rule),
+// который понимают редакторы и CI
+func (f Finding) Format(file string) string {
+	return fmt.Sprintf("%s:%s: %s: %s (%s)", file, f.Location, f.Severity, f.Message, f.Rule)
+}
+
+// GitHubAnnotation форматирует замечание как команду GitHub Actions (::error / ::warning / ::notice),
+// чтобы оно отображалось в PR на строке спецификации
+func (f Finding) GitHubAnnotation(file string) string {
+	level := "notice"
+	switch f.Severity {
+	case SeverityError:
+		level = "error"
+	case SeverityWarning:
+		level = "warning"
+	}
+
+	props := []string{"file=" + escapeAnnotationProperty(file)}
+	if f.Location.Line > 0 {
+		props = append(props, fmt.Sprintf("line=%d", f.Location.Line))
+		if f.Location.Column > 0 {
+			props = append(props, fmt.Sprintf("col=%d", f.Location.Column))
+		}
+	}
+	if f.Rule != "" {
+		props = append(props, "title="+escapeAnnotationProperty(f.Rule))
+	}
+	return "::" + level + " " + strings.Join(props, ",") + "::" + escapeAnnotationData(f.Message)
+}
+
+// escapeAnnotationData экранирует текст команды GitHub Actions: %, переводы строк
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeAnnotationProperty экранирует значение свойства команды: дополнительно : и ,
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(escapeAnnotationData(s))
+}
+
+// Lint проверяет операции и параметры API. Набор правил rs отключает правила, меняет их уровни
+// и добавляет собственные; nil — встроенные правила с уровнями по умолчанию
+func Lint(api *parser.API, rs *Ruleset) []Finding {
+	var findings []Finding
+	add := func(rule, severity string, loc parser.Location, format string, args ...any) {
+		if severity == SeverityOff {
+			return
+		}
+		findings = append(findings, Finding{
+			Rule:     rule,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+			Location: loc,
+		})
+	}
+	custom := rs.customRules()
+
+	for _, ep := range api.Endpoints {
+		op := ep.Method + " " + ep.Path
+
+		if strings.TrimSpace(ep.Summary) == "" {
+			add("operation-summary", rs.severity("operation-summary"), ep.Location, "operation %s missing summary", op)
+		}
+		if ep.OperationID == "" {
+			add("operation-operationId", rs.severity("operation-operationId"), ep.Location, "operation %s missing operationId", op)
+		}
+		if strings.TrimSpace(ep.Description) == "" {
+			add("operation-description", rs.severity("operation-description"), ep.Location, "operation %s missing description", op)
+		}
+		for _, p := range ep.Parameters {
+			if strings.TrimSpace(p.Description) == "" {
+				add("parameter-description", rs.severity("parameter-description"), p.Location, "parameter %q (%s) of %s missing description", p.Name, p.In, op)
+			}
+		}
+
+		for _, name := range custom {
+			rule := rs.Rules[name]
+			value := ep.Summary
+			if rule.Given == GivenDescription {
+				value = ep.Description
+			}
+			if rule.check(value) {
+				continue
+			}
+			severity := rule.Severity
+			if severity == "" {
+				severity = SeverityWarning
+			}
+			if rule.Message != "" {
+				add(name, severity, ep.Location, "operation %s: %s", op, rule.Message)
+			} else {
+				add(name, severity, ep.Location, "operation %s %s does not satisfy rule %s", op, rule.Given, name)
+			}
+		}
+	}
+
+	return findings
+}
+
+// HasErrors сообщает, что среди замечаний есть ошибки
+func HasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
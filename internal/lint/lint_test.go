@@ -0,0 +1,114 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+func TestLint(t *testing.T) {
+	api := &parser.API{
+		Endpoints: []parser.Endpoint{
+			{
+				Method: "GET", Path: "/users", OperationID: "listUsers", Summary: "List users", Description: "Returns users",
+				Location: parser.Location{Pointer: "/paths/~1users/get", Line: 7, Column: 5},
+				Parameters: []parser.Parameter{{
+					Name: "limit", In: "query",
+					Location: parser.Location{Pointer: "/paths/~1users/get/parameters/0", Line: 10, Column: 9},
+				}},
+			},
+			{
+				Method: "GET", Path: "/health",
+				Location: parser.Location{Pointer: "/paths/~1health/get"},
+			},
+		},
+	}
+
+	findings := Lint(api, nil)
+
+	rules := make(map[string]int)
+	for _, f := range findings {
+		rules[f.Rule]++
+	}
+	if rules["operation-summary"] != 1 || rules["operation-operationId"] != 1 || rules["parameter-description"] != 1 {
+		t.Errorf("unexpected findings: %+v", findings)
+	}
+
+	if got := findings[0].Format("openapi.yaml"); got != `openapi.yaml:10:9: warning: parameter "limit" (query) of GET /users missing description (parameter-description)` {
+		t.Errorf("unexpected format: %s", got)
+	}
+	if got := findings[1].Format("openapi.yaml"); !strings.HasPrefix(got, "openapi.yaml:#/paths/~1health/get: warning:") {
+		t.Errorf("finding without line should fall back to JSON pointer, got: %s", got)
+	}
+	if HasErrors(findings) {
+		t.Error("default rules should not report errors")
+	}
+	if got := findings[0].GitHubAnnotation("specs/openapi.yaml"); got != `::warning file=specs/openapi.yaml,line=10,col=9,title=parameter-description::parameter "limit" (query) of GET /users missing description` {
+		t.Errorf("unexpected annotation: %s", got)
+	}
+	validation := Finding{Rule: "openapi-validation", Severity: SeverityError, Message: "invalid spec: 100%\nbad"}
+	if got := validation.GitHubAnnotation("a,b:c.yaml"); got != "::error file=a%2Cb%3Ac.yaml,title=openapi-validation::invalid spec: 100%25%0Abad" {
+		t.Errorf("unexpected escaped annotation: %s", got)
+	}
+}
+
+func TestLintRuleset(t *testing.T) {
+	api := &parser.API{
+		Endpoints: []parser.Endpoint{
+			{Method: "GET", Path: "/users", OperationID: "listUsers", Summary: "List users.", Description: "TODO"},
+			{Method: "GET", Path: "/health", OperationID: "health", Summary: "Health check"},
+		},
+	}
+
+	rs, err := ParseRuleset([]byte(`
+rules:
+  operation-description: error
+  operation-summary: false
+  summary-no-trailing-period:
+    given: summary
+    notMatch: '\.$'
+    message: summary should not end with a period
+  no-todo:
+    severity: warn
+    given: description
+    notMatch: '(?i)\btodo\b'
+`))
+	if err != nil {
+		t.Fatalf("ParseRuleset failed: %v", err)
+	}
+
+	findings := Lint(api, rs)
+	got := make(map[string]string)
+	for _, f := range findings {
+		got[f.Rule] = f.Severity + ": " + f.Message
+	}
+	want := map[string]string{
+		"operation-description":      "error: operation GET /health missing description",
+		"summary-no-trailing-period": "warning: operation GET /users: summary should not end with a period",
+		"no-todo":                    "warning: operation GET /users description does not satisfy rule no-todo",
+	}
+	if len(got) != len(want) {
+		t.Errorf("unexpected findings: %+v", findings)
+	}
+	for rule, msg := range want {
+		if got[rule] != msg {
+			t.Errorf("rule %s: got %q, want %q", rule, got[rule], msg)
+		}
+	}
+	if !HasErrors(findings) {
+		t.Error("raised severity should report errors")
+	}
+
+	for _, bad := range []string{
+		"rules:\n  unknown-rule: error\n",
+		"rules:\n  operation-summary: fatal\n",
+		"rules:\n  custom:\n    given: tags\n    match: x\n",
+		"rules:\n  custom:\n    given: summary\n",
+		"rules:\n  custom:\n    given: summary\n    match: '('\n",
+	} {
+		if _, err := ParseRuleset([]byte(bad)); err == nil {
+			t.Errorf("expected error for ruleset %q", bad)
+		}
+	}
+}
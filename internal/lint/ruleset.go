@@ -0,0 +1,177 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SeverityOff отключает правило в наборе правил
+const SeverityOff = "off"
+
+// Поля операции, к которым применяются собственные правила
+const (
+	GivenSummary     = "summary"
+	GivenDescription = "description"
+)
+
+// builtinRules — встроенные правила и их уровни по умолчанию
+var builtinRules = map[string]string{
+	"operation-summary":     SeverityWarning,
+	"operation-operationId": SeverityWarning,
+	"operation-description": SeverityInfo,
+	"parameter-description": SeverityWarning,
+}
+
+// Ruleset — набор правил в стиле Spectral: включение и отключение встроенных правил,
+// их уровни и собственные правила-регулярки для summary и description операций
+//
+//	rules:
+//	  operation-description: off
+//	  parameter-description: error
+//	  summary-no-trailing-period:
+//	    given: summary
+//	    notMatch: '\.$'
+//	    message: summary should not end with a period
+type Ruleset struct {
+	Rules map[string]Rule `yaml:"rules"`
+}
+
+// Rule — настройка правила. Для встроенного правила задаётся только уровень;
+// правило с given — собственное и проверяет поле операции регулярными выражениями
+type Rule struct {
+	Severity string `yaml:"severity"` // error, warning, info или off
+	Given    string `yaml:"given"`    // summary, description
+	Match    string `yaml:"match"`    // поле должно соответствовать
+	NotMatch string `yaml:"notMatch"` // поле не должно соответствовать
+	Message  string `yaml:"message"`
+
+	match    *regexp.Regexp
+	notMatch *regexp.Regexp
+}
+
+// UnmarshalYAML разрешает короткую запись правила уровнем: `rule: off`, `rule: error` или `rule: false`
+func (r *Rule) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		switch node.Value {
+		case "false":
+			r.Severity = SeverityOff
+		case "true":
+		default:
+			r.Severity = node.Value
+		}
+		return nil
+	}
+	type plain Rule
+	return node.Decode((*plain)(r))
+}
+
+// LoadRuleset читает набор правил из YAML-файла (JSON тоже подходит)
+func LoadRuleset(path string) (*Ruleset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ruleset: %w", err)
+	}
+	rs, err := ParseRuleset(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ruleset %s: %w", path, err)
+	}
+	return rs, nil
+}
+
+// ParseRuleset разбирает набор правил и проверяет уровни и регулярные выражения
+func ParseRuleset(data []byte) (*Ruleset, error) {
+	var rs Ruleset
+	if err := yaml.Unmarshal(data, &rs); err != nil {
+		return nil, err
+	}
+	for name, rule := range rs.Rules {
+		rule.Severity = normalizeSeverity(rule.Severity)
+		switch rule.Severity {
+		case "", SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+		default:
+			return nil, fmt.Errorf("rule %s: invalid severity %q (expected error, warning, info or off)", name, rule.Severity)
+		}
+
+		if rule.Given == "" {
+			if _, ok := builtinRules[name]; !ok {
+				return nil, fmt.Errorf("unknown rule %s (custom rules need given: summary or description)", name)
+			}
+			rs.Rules[name] = rule
+			continue
+		}
+		if rule.Given != GivenSummary && rule.Given != GivenDescription {
+			return nil, fmt.Errorf("rule %s: invalid given %q (expected summary or description)", name, rule.Given)
+		}
+		if rule.Match == "" && rule.NotMatch == "" {
+			return nil, fmt.Errorf("rule %s: match or notMatch is required", name)
+		}
+		var err error
+		if rule.Match != "" {
+			if rule.match, err = regexp.Compile(rule.Match); err != nil {
+				return nil, fmt.Errorf("rule %s: invalid match: %w", name, err)
+			}
+		}
+		if rule.NotMatch != "" {
+			if rule.notMatch, err = regexp.Compile(rule.NotMatch); err != nil {
+				return nil, fmt.Errorf("rule %s: invalid notMatch: %w", name, err)
+			}
+		}
+		rs.Rules[name] = rule
+	}
+	return &rs, nil
+}
+
+// normalizeSeverity принимает написание уровней Spectral: warn и hint
+func normalizeSeverity(s string) string {
+	switch s = strings.ToLower(s); s {
+	case "warn":
+		return SeverityWarning
+	case "hint":
+		return SeverityInfo
+	}
+	return s
+}
+
+// severity возвращает уровень встроенного правила с учётом набора правил
+func (rs *Ruleset) severity(rule string) string {
+	if rs != nil {
+		if r, ok := rs.Rules[rule]; ok && r.Severity != "" {
+			return r.Severity
+		}
+	}
+	return builtinRules[rule]
+}
+
+// customRules возвращает имена собственных правил в стабильном порядке
+func (rs *Ruleset) customRules() []string {
+	if rs == nil {
+		return nil
+	}
+	var names []string
+	for name, r := range rs.Rules {
+		if r.Given != "" && r.Severity != SeverityOff {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// check проверяет значение поля собственным правилом; пустые поля покрывают встроенные правила
+func (r Rule) check(value string) bool {
+	if strings.TrimSpace(value) == "" {
+		return true
+	}
+	if r.match != nil && !r.match.MatchString(value) {
+		return false
+	}
+	if r.notMatch != nil && r.notMatch.MatchString(value) {
+		return false
+	}
+	return true
+}
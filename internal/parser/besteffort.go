@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Warning — проблема спецификации, найденная при разборе в режиме BestEffort:
+// операция с ошибкой пропускается, остальная спецификация документируется
+type Warning struct {
+	Method   string   `json:"method,omitempty"` // пусто вместе с Path — проблема вне paths
+	Path     string   `json:"path,omitempty"`
+	Message  string   `json:"message"`
+	Location Location `json:"location,omitzero"`
+}
+
+// String форматирует предупреждение: METHOD /path: сообщение
+func (w Warning) String() string {
+	switch {
+	case w.Method != "":
+		return w.Method + " " + w.Path + " skipped: " + w.Message
+	case w.Path != "":
+		return w.Path + " skipped: " + w.Message
+	}
+	return w.Message
+}
+
+// bestEffortValidate проверяет каждый путь и каждую операцию отдельно и удаляет из документа
+// те, что не проходят валидацию. Ошибки вне paths (info, components) не мешают документировать
+// операции и возвращаются предупреждением без пути
+func bestEffortValidate(ctx context.Context, doc *openapi3.T) []Warning {
+	if err := doc.Validate(ctx); err == nil {
+		return nil
+	}
+
+	validate := func(path string, item *openapi3.PathItem) error {
+		single := *doc
+		single.Paths = openapi3.NewPaths(openapi3.WithPath(path, item))
+		return single.Validate(ctx)
+	}
+	withoutPaths := *doc
+	withoutPaths.Paths = openapi3.NewPaths()
+	if err := withoutPaths.Validate(ctx); err != nil {
+		// Ошибка общая для всего документа: отдельные операции проверить нельзя, документируем всё
+		return []Warning{{Message: "invalid OpenAPI spec: " + err.Error()}}
+	}
+
+	var warnings []Warning
+	paths := doc.Paths.Map()
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		item := paths[path]
+		err := validate(path, item)
+		if err == nil {
+			continue
+		}
+
+		// Ищем операции с ошибкой, проверяя каждую в пути без остальных
+		skipped := 0
+		operations := item.Operations()
+		for _, method := range slices.Sorted(maps.Keys(operations)) {
+			single := onlyOperation(item, method, operations[method])
+			if opErr := validate(path, single); opErr != nil {
+				warnings = append(warnings, Warning{Method: strings.ToUpper(method), Path: path, Message: trimValidationContext(opErr, path, method)})
+				item.SetOperation(method, nil)
+				skipped++
+			}
+		}
+
+		// Ошибка на уровне пути (параметры пути, шаблон) — пропускаем путь целиком
+		if skipped == 0 || len(item.Operations()) == 0 {
+			if skipped == 0 {
+				warnings = append(warnings, Warning{Path: path, Message: trimValidationContext(err, path, "")})
+			}
+			doc.Paths.Delete(path)
+		}
+	}
+	return warnings
+}
+
+// trimValidationContext убирает из ошибки валидации префиксы пути и операции, которые уже есть в предупреждении
+func trimValidationContext(err error, path, method string) string {
+	msg := strings.TrimPrefix(err.Error(), "invalid paths: ")
+	msg = strings.TrimPrefix(msg, "invalid path "+path+": ")
+	if method != "" {
+		msg = strings.TrimPrefix(msg, "invalid operation "+method+": ")
+	}
+	return msg
+}
+
+// onlyOperation возвращает копию пути с единственной операцией method
+func onlyOperation(item *openapi3.PathItem, method string, op *openapi3.Operation) *openapi3.PathItem {
+	single := *item
+	for _, m := range []string{http.MethodConnect, http.MethodDelete, http.MethodGet, http.MethodHead,
+		http.MethodOptions, http.MethodPatch, http.MethodPost, http.MethodPut, http.MethodTrace} {
+		single.SetOperation(m, nil)
+	}
+	single.SetOperation(method, op)
+	return &single
+}
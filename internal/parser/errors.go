@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Виды ошибок разбора: проверяются через errors.Is
+var (
+	// ErrSpecNotFound — файл спецификации не существует или URL ответил 404
+	ErrSpecNotFound = errors.New("spec not found")
+	// ErrUnsupportedVersion — документ не OpenAPI 3.x (например, Swagger 2.0)
+	ErrUnsupportedVersion = errors.New("unsupported spec version")
+	// ErrInvalidSpec — документ не загружается или не проходит валидацию; подробности в *InvalidSpecError
+	ErrInvalidSpec = errors.New("invalid OpenAPI spec")
+)
+
+// InvalidSpecError возвращается, если спецификация не загружается или не проходит валидацию.
+// errors.Is(err, ErrInvalidSpec) для неё истинно
+type InvalidSpecError struct {
+	Details []string // сообщения об ошибках в документе
+	Err     error    // исходная ошибка загрузчика или валидатора
+}
+
+func (e *InvalidSpecError) Error() string {
+	if len(e.Details) == 1 {
+		return "invalid OpenAPI spec: " + e.Details[0]
+	}
+	return "invalid OpenAPI spec:\n  - " + strings.Join(e.Details, "\n  - ")
+}
+
+func (e *InvalidSpecError) Unwrap() error {
+	return e.Err
+}
+
+func (e *InvalidSpecError) Is(target error) bool {
+	return target == ErrInvalidSpec
+}
+
+// invalidSpec оборачивает ошибку загрузчика или валидатора; MultiError раскладывается по сообщениям
+func invalidSpec(err error) *InvalidSpecError {
+	var details []string
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		for _, e := range multi {
+			details = append(details, e.Error())
+		}
+	}
+	if len(details) == 0 {
+		details = []string{err.Error()}
+	}
+	return &InvalidSpecError{Details: details, Err: err}
+}
+
+// checkVersion отклоняет документы, которые не являются OpenAPI 3.x. Документ без поля openapi
+// пропускается: об этом сообщит валидация
+func checkVersion(data []byte) error {
+	doc := parseDocumentNode(data)
+	if doc == nil {
+		return nil
+	}
+	if swagger := scalarValue(doc, "swagger"); swagger != "" {
+		return fmt.Errorf("%w: Swagger %s (only OpenAPI 3.x is supported)", ErrUnsupportedVersion, swagger)
+	}
+	if version := scalarValue(doc, "openapi"); version != "" && !strings.HasPrefix(version, "3.") {
+		return fmt.Errorf("%w: OpenAPI %s (only OpenAPI 3.x is supported)", ErrUnsupportedVersion, version)
+	}
+	return nil
+}
+
+// readError оборачивает ошибку чтения источника; отсутствующий файл — ErrSpecNotFound
+func readError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %w", ErrSpecNotFound, err)
+	}
+	return fmt.Errorf("failed to load OpenAPI spec: %w", err)
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// escapePointer экранирует сегмент JSON Pointer (RFC 6901): ~ → ~0, / → ~1
+func escapePointer(segment string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(segment)
+}
+
+// nodeLocation возвращает место узла в документе
+func nodeLocation(pointer string, node *yaml.Node) Location {
+	loc := Location{Pointer: pointer}
+	if node != nil {
+		loc.Line, loc.Column = node.Line, node.Column
+	}
+	return loc
+}
+
+// annotateLocations проставляет операциям и их параметрам JSON Pointer и позицию в исходном тексте,
+// чтобы предупреждения и отчёты указывали на конкретное место спецификации
+func annotateLocations(api *API, doc *yaml.Node) {
+	paths := mappingValue(doc, "paths")
+
+	for i := range api.Endpoints {
+		ep := &api.Endpoints[i]
+		method := strings.ToLower(ep.Method)
+		pointer := "/paths/" + escapePointer(ep.Path) + "/" + method
+
+		pathItem := mappingValue(paths, ep.Path)
+		opKey, op := mappingEntry(pathItem, method)
+		ep.Location = nodeLocation(pointer, opKey)
+
+		// Параметр ищется сначала в операции, затем среди унаследованных от пути
+		pathPointer := "/paths/" + escapePointer(ep.Path)
+		for j := range ep.Parameters {
+			p := &ep.Parameters[j]
+			p.Location = ep.Location
+			if loc, ok := parameterLocation(mappingValue(op, "parameters"), pointer, p); ok {
+				p.Location = loc
+			} else if loc, ok := parameterLocation(mappingValue(pathItem, "parameters"), pathPointer, p); ok {
+				p.Location = loc
+			}
+		}
+	}
+}
+
+// annotateWarnings указывает предупреждениям разбора место пропущенного пути или операции
+func annotateWarnings(warnings []Warning, doc *yaml.Node) {
+	paths := mappingValue(doc, "paths")
+	for i := range warnings {
+		w := &warnings[i]
+		if w.Path == "" {
+			continue
+		}
+		pointer := "/paths/" + escapePointer(w.Path)
+		key, item := mappingEntry(paths, w.Path)
+		if w.Method != "" {
+			method := strings.ToLower(w.Method)
+			key, _ = mappingEntry(item, method)
+			pointer += "/" + method
+		}
+		w.Location = nodeLocation(pointer, key)
+	}
+}
+
+// parameterLocation ищет параметр с теми же name и in в списке parameters узла pointer
+func parameterLocation(params *yaml.Node, pointer string, p *Parameter) (Location, bool) {
+	if params == nil || params.Kind != yaml.SequenceNode {
+		return Location{}, false
+	}
+	for k, item := range params.Content {
+		if scalarValue(item, "name") == p.Name && scalarValue(item, "in") == p.In {
+			return nodeLocation(pointer+"/parameters/"+strconv.Itoa(k), item), true
+		}
+	}
+	return Location{}, false
+}
+
+// mappingEntry возвращает ключ и значение в YAML mapping
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// scalarValue возвращает строковое значение ключа в YAML mapping
+func scalarValue(node *yaml.Node, key string) string {
+	if value := mappingValue(node, key); value != nil && value.Kind == yaml.ScalarNode {
+		return value.Value
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// NetworkRequiredError возвращается в offline-режиме, если спецификации нужна сеть
+type NetworkRequiredError struct {
+	Resources []string // что именно требует сети
+}
+
+func (e *NetworkRequiredError) Error() string {
+	return "offline mode: network access required by:\n  - " + strings.Join(e.Resources, "\n  - ")
+}
+
+// remoteResources находит в документе удалённые ссылки: $ref и externalValue с http(s) URL
+func remoteResources(data []byte) []string {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i].Value, node.Content[i+1]
+				if (key == "$ref" || key == "externalValue") && value.Kind == yaml.ScalarNode && isURL(value.Value) {
+					seen[fmt.Sprintf("%s %s (line %d)", key, value.Value, value.Line)] = true
+				}
+			}
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(&root)
+
+	resources := make([]string, 0, len(seen))
+	for r := range seen {
+		resources = append(resources, r)
+	}
+	sort.Strings(resources)
+	return resources
+}
+
+// offlineReadFromURI читает только локальные файлы и отказывает любым сетевым ссылкам,
+// в том числе найденным во внешних файлах, которые подключаются через $ref
+func offlineReadFromURI(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	if location.Scheme == "http" || location.Scheme == "https" {
+		return nil, &NetworkRequiredError{Resources: []string{"$ref " + location.String()}}
+	}
+	return openapi3.ReadFromFile(loader, location)
+}
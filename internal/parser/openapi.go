@@ -2,12 +2,17 @@ package parser
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,68 +22,157 @@ import (
 // ParseOptions опции парсинга
 type ParseOptions struct {
 	SkipValidation bool
+	Offline        bool // запретить любой доступ к сети
+
+	// BestEffort пропускает пути и операции, не прошедшие валидацию, вместо ошибки разбора;
+	// пропущенные перечисляются в API.Warnings
+	BestEffort bool
+
+	// Preprocess преобразует исходный текст спецификации до парсинга (хуки preParse)
+	Preprocess func(data []byte) ([]byte, error)
 }
 
 // Parse парсит OpenAPI спецификацию из файла или URL
 func Parse(source string, opts *ParseOptions) (*API, error) {
+	return ParseContext(context.Background(), source, opts)
+}
+
+// ParseContext парсит спецификацию как Parse; отмена ctx прерывает скачивание спецификации
+// и внешних $ref, а также валидацию
+func ParseContext(ctx context.Context, source string, opts *ParseOptions) (*API, error) {
 	if opts == nil {
 		opts = &ParseOptions{}
 	}
 
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
+	loader.Context = ctx
+	loader.ReadFromURIFunc = readFromURIContext(ctx)
 
-	var doc *openapi3.T
+	// В offline-режиме заранее перечисляем всё, что потребовало бы сети
+	if opts.Offline {
+		if err := checkOffline(source); err != nil {
+			return nil, err
+		}
+		loader.ReadFromURIFunc = offlineReadFromURI
+	}
+
+	var data []byte
+	var isYAML bool
 	var err error
 
 	if isURL(source) {
-		doc, err = loadFromURL(loader, source)
+		data, isYAML, err = fetchURL(ctx, source)
 	} else {
-		doc, err = loader.LoadFromFile(source)
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return nil, readError(err)
 	}
 
+	if opts.Preprocess != nil {
+		if data, err = opts.Preprocess(data); err != nil {
+			return nil, err
+		}
+	}
+
+	// Postman-коллекция конвертируется в модель API напрямую, без загрузчика OpenAPI
+	if isPostmanCollection(data) {
+		return parsePostman(data)
+	}
+	if err := checkVersion(data); err != nil {
+		return nil, err
+	}
+
+	var doc *openapi3.T
+	switch {
+	case isURL(source):
+		doc, err = loadFromData(loader, data, isYAML)
+	case opts.Preprocess != nil:
+		// Изменённый текст загружается с исходным путём, чтобы относительные $ref разрешались как раньше
+		doc, err = loader.LoadFromDataWithPath(data, &url.URL{Path: filepath.ToSlash(source)})
+	default:
+		doc, err = loader.LoadFromFile(source)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+		return nil, invalidSpec(err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	if !opts.SkipValidation {
-		if err := doc.Validate(context.Background()); err != nil {
-			return nil, fmt.Errorf("invalid OpenAPI spec: %w\n\nUse --skip-validation to ignore validation errors", err)
+	var warnings []Warning
+	switch {
+	case opts.SkipValidation:
+	case opts.BestEffort:
+		warnings = bestEffortValidate(ctx, doc)
+	default:
+		if err := doc.Validate(ctx); err != nil {
+			return nil, invalidSpec(err)
 		}
 	}
 
-	return convertToAPI(doc), nil
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	api := convertToAPI(doc)
+	api.Warnings = warnings
+	annotateSource(api, data)
+	return api, nil
 }
 
-func loadFromURL(loader *openapi3.Loader, rawURL string) (*openapi3.T, error) {
+// fetchURL скачивает спецификацию и определяет её формат по расширению или Content-Type
+func fetchURL(ctx context.Context, rawURL string) ([]byte, bool, error) {
 	u, err := url.Parse(rawURL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid URL: %w", err)
+		return nil, false, fmt.Errorf("invalid URL: %w", err)
 	}
 
 	// Скачиваем файл
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid URL: %w", err)
+	}
 	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Get(rawURL)
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch URL: %w", err)
+		return nil, false, fmt.Errorf("failed to fetch URL: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, false, fmt.Errorf("%w: %s returned %s", ErrSpecNotFound, rawURL, resp.Status)
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error: %s", resp.Status)
+		return nil, false, fmt.Errorf("HTTP error: %s", resp.Status)
 	}
 
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, false, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	// Определяем формат по расширению или Content-Type
 	isYAML := strings.HasSuffix(u.Path, ".yaml") ||
 		strings.HasSuffix(u.Path, ".yml") ||
 		strings.Contains(resp.Header.Get("Content-Type"), "yaml")
+	return data, isYAML, nil
+}
 
-	// Создаём временный файл
+// readFromURIContext читает внешние $ref: удалённые — с отменой через ctx, локальные — с диска
+func readFromURIContext(ctx context.Context) openapi3.ReadFromURIFunc {
+	readHTTP := func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Scheme != "http" && location.Scheme != "https" {
+			return nil, openapi3.ErrURINotSupported
+		}
+		data, _, err := fetchURL(ctx, location.String())
+		return data, err
+	}
+	return openapi3.URIMapCache(openapi3.ReadFromURIs(readHTTP, openapi3.ReadFromFile))
+}
+
+// loadFromData загружает скачанную спецификацию через временный файл
+func loadFromData(loader *openapi3.Loader, data []byte, isYAML bool) (*openapi3.T, error) {
 	ext := ".json"
 	if isYAML {
 		ext = ".yaml"
@@ -110,16 +204,42 @@ func ParseFile(path string) (*API, error) {
 		return nil, fmt.Errorf("unsupported file format: %s (expected .json, .yaml, or .yml)", ext)
 	}
 
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, readError(err)
+	}
+	if err := checkVersion(data); err != nil {
+		return nil, err
+	}
+
 	doc, err := loader.LoadFromFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+		return nil, invalidSpec(err)
 	}
 
 	if err := doc.Validate(context.Background()); err != nil {
-		return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+		return nil, invalidSpec(err)
+	}
+
+	api := convertToAPI(doc)
+	annotateSource(api, data)
+	return api, nil
+}
+
+// checkOffline проверяет, что спецификацию можно загрузить без сети
+func checkOffline(source string) error {
+	if isURL(source) {
+		return &NetworkRequiredError{Resources: []string{"spec source " + source}}
 	}
 
-	return convertToAPI(doc), nil
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return readError(err)
+	}
+	if resources := remoteResources(data); len(resources) > 0 {
+		return &NetworkRequiredError{Resources: resources}
+	}
+	return nil
 }
 
 func isURL(s string) bool {
@@ -128,21 +248,41 @@ func isURL(s string) bool {
 
 func convertToAPI(doc *openapi3.T) *API {
 	api := &API{
-		Title:       doc.Info.Title,
-		Description: doc.Info.Description,
-		Version:     doc.Info.Version,
+		Title:        doc.Info.Title,
+		Description:  doc.Info.Description,
+		Version:      doc.Info.Version,
+		ExternalDocs: convertExternalDocs(doc.ExternalDocs),
+		Extensions:   doc.Extensions,
+		Workflows:    workflowsFromExtension(doc.Extensions["x-workflows"]),
 	}
 
+	// Контакты, лицензия и условия использования
+	if c := doc.Info.Contact; c != nil && (c.Name != "" || c.URL != "" || c.Email != "") {
+		api.Contact = &Contact{Name: c.Name, URL: c.URL, Email: c.Email}
+	}
+	if l := doc.Info.License; l != nil && l.Name != "" {
+		api.License = &License{Name: l.Name, URL: l.URL}
+	}
+	api.TermsOfService = doc.Info.TermsOfService
+
 	// Извлекаем базовый URL из серверов
 	if len(doc.Servers) > 0 {
 		api.BaseURL = doc.Servers[0].URL
 	}
+	for _, server := range doc.Servers {
+		if server == nil {
+			continue
+		}
+		api.Servers = append(api.Servers, convertServer(server))
+	}
 
 	// Конвертируем теги
 	for _, tag := range doc.Tags {
 		api.Tags = append(api.Tags, Tag{
-			Name:        tag.Name,
-			Description: tag.Description,
+			Name:         tag.Name,
+			Description:  tag.Description,
+			ExternalDocs: convertExternalDocs(tag.ExternalDocs),
+			Extensions:   tag.Extensions,
 		})
 	}
 
@@ -152,11 +292,28 @@ func convertToAPI(doc *openapi3.T) *API {
 			if op == nil {
 				continue
 			}
-			endpoint := convertOperation(path, method, op)
+			endpoint := convertOperation(path, method, op, pathItem.Parameters)
+			// security операции переопределяет глобальный, пустой список отключает аутентификацию
+			security := doc.Security
+			if op.Security != nil {
+				security = *op.Security
+			}
+			endpoint.Security = convertSecurity(security)
+			endpoint.WebSocket = convertWebSocket(op, doc.Components)
 			api.Endpoints = append(api.Endpoints, endpoint)
 		}
 	}
 
+	// Именованные схемы для глоссария
+	if doc.Components != nil && len(doc.Components.Schemas) > 0 {
+		api.Schemas = make(map[string]*Schema, len(doc.Components.Schemas))
+		for name, ref := range doc.Components.Schemas {
+			if ref.Value != nil {
+				api.Schemas[name] = convertSchemaRef(ref)
+			}
+		}
+	}
+
 	// Конвертируем security schemes
 	if doc.Components != nil && doc.Components.SecuritySchemes != nil {
 		for name, schemeRef := range doc.Components.SecuritySchemes {
@@ -171,26 +328,123 @@ func convertToAPI(doc *openapi3.T) *API {
 				In:          scheme.In,
 				ParamName:   scheme.Name,
 				Scheme:      scheme.Scheme,
+
+				Flows:            convertOAuthFlows(scheme.Flows),
+				OpenIDConnectURL: scheme.OpenIdConnectUrl,
 			}
 			api.SecuritySchemes = append(api.SecuritySchemes, ss)
 		}
+		sort.Slice(api.SecuritySchemes, func(i, j int) bool {
+			return api.SecuritySchemes[i].Name < api.SecuritySchemes[j].Name
+		})
 	}
 
 	return api
 }
 
-func convertOperation(path, method string, op *openapi3.Operation) Endpoint {
-	endpoint := Endpoint{
-		Method:      method,
-		Path:        path,
-		Summary:     op.Summary,
-		Description: op.Description,
-		Tags:        op.Tags,
-		Deprecated:  op.Deprecated,
-		Responses:   make(map[string]Response),
+// convertOAuthFlows конвертирует потоки OAuth 2.0 в порядке от наиболее к наименее
+// распространённому для серверных интеграций
+func convertOAuthFlows(flows *openapi3.OAuthFlows) []OAuthFlow {
+	if flows == nil {
+		return nil
+	}
+
+	var result []OAuthFlow
+	for _, f := range []struct {
+		name string
+		flow *openapi3.OAuthFlow
+	}{
+		{"authorizationCode", flows.AuthorizationCode},
+		{"clientCredentials", flows.ClientCredentials},
+		{"password", flows.Password},
+		{"implicit", flows.Implicit},
+	} {
+		if f.flow == nil {
+			continue
+		}
+		result = append(result, OAuthFlow{
+			Type:             f.name,
+			AuthorizationURL: f.flow.AuthorizationURL,
+			TokenURL:         f.flow.TokenURL,
+			RefreshURL:       f.flow.RefreshURL,
+			Scopes:           f.flow.Scopes,
+		})
+	}
+	return result
+}
+
+func convertExternalDocs(d *openapi3.ExternalDocs) *ExternalDocs {
+	if d == nil || d.URL == "" {
+		return nil
+	}
+	return &ExternalDocs{
+		Description: d.Description,
+		URL:         d.URL,
+	}
+}
+
+func convertSecurity(reqs openapi3.SecurityRequirements) []SecurityRequirement {
+	var result []SecurityRequirement
+	for _, req := range reqs {
+		// Пустое требование ({}) означает, что аутентификация необязательна
+		if len(req) == 0 {
+			continue
+		}
+		converted := make(SecurityRequirement, len(req))
+		for name, scopes := range req {
+			converted[name] = scopes
+		}
+		result = append(result, converted)
+	}
+	return result
+}
+
+func convertServer(s *openapi3.Server) Server {
+	server := Server{
+		URL:         s.URL,
+		Description: s.Description,
+	}
+
+	if len(s.Variables) > 0 {
+		server.Variables = make(map[string]ServerVariable)
+		for name, v := range s.Variables {
+			if v == nil {
+				continue
+			}
+			server.Variables[name] = ServerVariable{
+				Default:     v.Default,
+				Enum:        v.Enum,
+				Description: v.Description,
+			}
+		}
 	}
 
-	// Конвертируем параметры
+	return server
+}
+
+// convertOperation конвертирует операцию. pathParams — параметры уровня пути, общие для всех
+// методов: они наследуются операцией, если она не переопределяет параметр с теми же name и in
+func convertOperation(path, method string, op *openapi3.Operation, pathParams openapi3.Parameters) Endpoint {
+	endpoint := Endpoint{
+		Method:       method,
+		Path:         path,
+		OperationID:  op.OperationID,
+		Summary:      op.Summary,
+		Description:  op.Description,
+		Tags:         op.Tags,
+		Deprecated:   op.Deprecated,
+		Responses:    make(map[string]Response),
+		ExternalDocs: convertExternalDocs(op.ExternalDocs),
+		Extensions:   op.Extensions,
+	}
+
+	// Конвертируем параметры: сначала унаследованные от пути, затем собственные
+	for _, paramRef := range pathParams {
+		if paramRef.Value == nil || op.Parameters.GetByInAndName(paramRef.Value.In, paramRef.Value.Name) != nil {
+			continue
+		}
+		endpoint.Parameters = append(endpoint.Parameters, convertParameter(paramRef.Value))
+	}
 	for _, paramRef := range op.Parameters {
 		if paramRef.Value == nil {
 			continue
@@ -223,6 +477,11 @@ func convertParameter(p *openapi3.Parameter) Parameter {
 		In:          p.In,
 		Description: p.Description,
 		Required:    p.Required,
+		Style:       p.Style,
+		Explode:     p.Explode,
+	}
+	if p.Example != nil {
+		param.Example = p.Example
 	}
 
 	if p.Schema != nil && p.Schema.Value != nil {
@@ -230,12 +489,21 @@ func convertParameter(p *openapi3.Parameter) Parameter {
 		param.Type = schema.Type.Slice()[0]
 		param.Format = schema.Format
 		param.Default = schema.Default
-		param.Example = schema.Example
+		if param.Example == nil {
+			param.Example = schema.Example
+		}
+		if schema.Items != nil && schema.Items.Value != nil && schema.Items.Value.Type != nil {
+			param.ItemType = schema.Items.Value.Type.Slice()[0]
+		}
+		for name := range schema.Properties {
+			param.Properties = append(param.Properties, name)
+		}
+		sort.Strings(param.Properties)
 
-		for _, e := range schema.Enum {
-			if s, ok := e.(string); ok {
-				param.Enum = append(param.Enum, s)
-			}
+		param.Enum = convertEnum(schema.Enum)
+		param.EnumDescriptions = enumDescriptions(param.Enum, schema.Extensions)
+		if param.EnumDescriptions == nil {
+			param.EnumDescriptions = enumDescriptions(param.Enum, p.Extensions)
 		}
 	}
 
@@ -254,7 +522,7 @@ func convertRequestBody(rb *openapi3.RequestBody) *RequestBody {
 			Example: mediaType.Example,
 		}
 		if mediaType.Schema != nil && mediaType.Schema.Value != nil {
-			mt.Schema = convertSchema(mediaType.Schema.Value)
+			mt.Schema = convertSchemaRef(mediaType.Schema)
 		}
 		reqBody.Content[contentType] = mt
 	}
@@ -271,28 +539,192 @@ func convertResponse(r *openapi3.Response) Response {
 		resp.Description = *r.Description
 	}
 
+	for name, headerRef := range r.Headers {
+		if headerRef == nil || headerRef.Value == nil {
+			continue
+		}
+		if resp.Headers == nil {
+			resp.Headers = make(map[string]Header)
+		}
+		header := Header{Description: headerRef.Value.Description}
+		if schema := headerRef.Value.Schema; schema != nil && schema.Value != nil && len(schema.Value.Type.Slice()) > 0 {
+			header.Type = schema.Value.Type.Slice()[0]
+		}
+		resp.Headers[name] = header
+	}
+
 	for contentType, mediaType := range r.Content {
 		mt := MediaType{
 			Example: mediaType.Example,
 		}
 		if mediaType.Schema != nil && mediaType.Schema.Value != nil {
-			mt.Schema = convertSchema(mediaType.Schema.Value)
+			mt.Schema = convertSchemaRef(mediaType.Schema)
 		}
 		resp.Content[contentType] = mt
 	}
 
+	for _, name := range slices.Sorted(maps.Keys(r.Links)) {
+		linkRef := r.Links[name]
+		if linkRef == nil || linkRef.Value == nil {
+			continue
+		}
+		link := Link{
+			Name:         name,
+			OperationID:  linkRef.Value.OperationID,
+			OperationRef: linkRef.Value.OperationRef,
+			Description:  linkRef.Value.Description,
+		}
+		for param, value := range linkRef.Value.Parameters {
+			if link.Parameters == nil {
+				link.Parameters = make(map[string]string)
+			}
+			link.Parameters[param] = linkValue(value)
+		}
+		if linkRef.Value.RequestBody != nil {
+			link.RequestBody = linkValue(linkRef.Value.RequestBody)
+		}
+		resp.Links = append(resp.Links, link)
+	}
+
 	return resp
 }
 
+// linkValue переводит выражение или значение параметра link в строку
+func linkValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// convertEnum переводит значения enum в строки: числа и логические значения — в их
+// JSON-запись (404, 1.5, true), null — в "null", объекты и массивы — в JSON
+func convertEnum(values []any) []string {
+	var result []string
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			result = append(result, v)
+		case nil:
+			result = append(result, "null")
+		case float64:
+			result = append(result, strconv.FormatFloat(v, 'f', -1, 64))
+		case bool, int, int64:
+			result = append(result, fmt.Sprint(v))
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			result = append(result, string(data))
+		}
+	}
+	return result
+}
+
+// enumDescriptions сопоставляет значениям enum их смысл из расширений:
+// x-enum-descriptions (x-enumDescriptions) — список в порядке enum или объект значение → описание,
+// x-enumNames (x-enum-varnames) — имена констант в порядке enum. Имя, совпадающее со значением,
+// не добавляет смысла и пропускается; имя и описание вместе дают "ACTIVE — описание"
+func enumDescriptions(values []string, ext map[string]any) map[string]string {
+	if len(values) == 0 || len(ext) == 0 {
+		return nil
+	}
+	names := enumExtension(values, ext, "x-enumNames", "x-enum-varnames")
+	descriptions := enumExtension(values, ext, "x-enum-descriptions", "x-enumDescriptions")
+
+	result := make(map[string]string)
+	for _, value := range values {
+		name, desc := names[value], strings.TrimSpace(descriptions[value])
+		if name == value {
+			name = ""
+		}
+		switch {
+		case name != "" && desc != "":
+			result[value] = name + " — " + desc
+		case name != "":
+			result[value] = name
+		case desc != "":
+			result[value] = desc
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// enumExtension читает первое из расширений keys: список сопоставляется значениям enum по позиции,
+// объект — по значению
+func enumExtension(values []string, ext map[string]any, keys ...string) map[string]string {
+	for _, key := range keys {
+		result := make(map[string]string)
+		switch raw := ext[key].(type) {
+		case []any:
+			for i, item := range raw {
+				if s, ok := item.(string); ok && i < len(values) {
+					result[values[i]] = s
+				}
+			}
+		case map[string]any:
+			for value, item := range raw {
+				if s, ok := item.(string); ok {
+					result[value] = s
+				}
+			}
+		default:
+			continue
+		}
+		return result
+	}
+	return nil
+}
+
+// convertSchemaRef конвертирует схему, сохраняя ссылку на компонент
+func convertSchemaRef(ref *openapi3.SchemaRef) *Schema {
+	return convertSchemaRefIn(ref, map[*openapi3.Schema]bool{})
+}
+
+// convertSchemaRefIn конвертирует схему по ссылке; path — схемы, которые сейчас конвертируются
+// выше по дереву (см. convertSchemaIn)
+func convertSchemaRefIn(ref *openapi3.SchemaRef, path map[*openapi3.Schema]bool) *Schema {
+	schema := convertSchemaIn(ref.Value, path)
+	if schema != nil {
+		schema.Ref = ref.Ref
+	}
+	return schema
+}
+
 func convertSchema(s *openapi3.Schema) *Schema {
+	return convertSchemaIn(s, map[*openapi3.Schema]bool{})
+}
+
+// convertSchemaIn конвертирует схему, обрезая рекурсию: схема, которая уже конвертируется выше
+// по дереву (Node.children → Node), во второй раз не разворачивается, а становится заглушкой
+// с типом и описанием. Так модель API остаётся ациклической при любых $ref
+func convertSchemaIn(s *openapi3.Schema, path map[*openapi3.Schema]bool) *Schema {
 	if s == nil {
 		return nil
 	}
+	if path[s] {
+		stub := &Schema{Description: s.Description}
+		if types := s.Type.Slice(); len(types) > 0 {
+			stub.Type = types[0]
+		}
+		return stub
+	}
+	path[s] = true
+	defer delete(path, s)
 
 	schema := &Schema{
 		Format:      s.Format,
 		Description: s.Description,
 		Required:    s.Required,
+		Default:     s.Default,
 		Example:     s.Example,
 	}
 
@@ -301,18 +733,15 @@ func convertSchema(s *openapi3.Schema) *Schema {
 	}
 
 	// Конвертируем enum
-	for _, e := range s.Enum {
-		if str, ok := e.(string); ok {
-			schema.Enum = append(schema.Enum, str)
-		}
-	}
+	schema.Enum = convertEnum(s.Enum)
+	schema.EnumDescriptions = enumDescriptions(schema.Enum, s.Extensions)
 
 	// Конвертируем properties для объектов
 	if s.Properties != nil {
 		schema.Properties = make(map[string]*Schema)
 		for name, propRef := range s.Properties {
 			if propRef.Value != nil {
-				schema.Properties[name] = convertSchema(propRef.Value)
+				schema.Properties[name] = convertSchemaRefIn(propRef, path)
 			}
 		}
 	}
@@ -324,7 +753,7 @@ func convertSchema(s *openapi3.Schema) *Schema {
 		}
 		for _, ref := range s.AllOf {
 			if ref.Value != nil {
-				merged := convertSchema(ref.Value)
+				merged := convertSchemaIn(ref.Value, path)
 				if merged != nil {
 					// Копируем тип если не задан
 					if schema.Type == "" && merged.Type != "" {
@@ -346,7 +775,7 @@ func convertSchema(s *openapi3.Schema) *Schema {
 	// Обрабатываем oneOf/anyOf — берём первую схему как пример
 	if len(s.OneOf) > 0 && len(schema.Properties) == 0 {
 		if s.OneOf[0].Value != nil {
-			first := convertSchema(s.OneOf[0].Value)
+			first := convertSchemaIn(s.OneOf[0].Value, path)
 			if first != nil {
 				schema.Type = first.Type
 				schema.Properties = first.Properties
@@ -356,7 +785,7 @@ func convertSchema(s *openapi3.Schema) *Schema {
 	}
 	if len(s.AnyOf) > 0 && len(schema.Properties) == 0 {
 		if s.AnyOf[0].Value != nil {
-			first := convertSchema(s.AnyOf[0].Value)
+			first := convertSchemaIn(s.AnyOf[0].Value, path)
 			if first != nil {
 				schema.Type = first.Type
 				schema.Properties = first.Properties
@@ -367,7 +796,7 @@ func convertSchema(s *openapi3.Schema) *Schema {
 
 	// Конвертируем items для массивов
 	if s.Items != nil && s.Items.Value != nil {
-		schema.Items = convertSchema(s.Items.Value)
+		schema.Items = convertSchemaRefIn(s.Items, path)
 	}
 
 	return schema
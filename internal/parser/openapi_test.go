@@ -1,9 +1,20 @@
 package parser
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
 )
 
 func TestParseJSON(t *testing.T) {
@@ -164,11 +175,62 @@ func TestParseJSON(t *testing.T) {
 	}
 }
 
+func TestParseSchemaRef(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Ref API
+  version: "1.0.0"
+paths:
+  /users:
+    get:
+      responses:
+        "404":
+          description: Not found
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Error"
+components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	schema := api.Endpoints[0].Responses["404"].Content["application/json"].Schema
+	if schema.Ref != "#/components/schemas/Error" {
+		t.Errorf("Expected ref '#/components/schemas/Error', got '%s'", schema.Ref)
+	}
+	if schema.RefName() != "Error" {
+		t.Errorf("Expected ref name 'Error', got '%s'", schema.RefName())
+	}
+	if named := api.Schemas["Error"]; named == nil || named.Properties["message"] == nil {
+		t.Errorf("Expected named schema Error in API.Schemas, got %+v", api.Schemas)
+	}
+}
+
 func TestParseYAML(t *testing.T) {
 	spec := `openapi: "3.0.0"
 info:
   title: YAML Test API
   version: "2.0.0"
+  termsOfService: https://example.com/terms
+  contact:
+    name: API Support
+    email: support@example.com
+  license:
+    name: MIT
 paths:
   /health:
     get:
@@ -194,24 +256,1498 @@ paths:
 	if len(api.Endpoints) != 1 {
 		t.Errorf("Expected 1 endpoint, got %d", len(api.Endpoints))
 	}
+	if api.Contact == nil || api.Contact.Email != "support@example.com" || api.Contact.Name != "API Support" {
+		t.Errorf("unexpected contact %+v", api.Contact)
+	}
+	if api.License == nil || api.License.Name != "MIT" || api.TermsOfService != "https://example.com/terms" {
+		t.Errorf("unexpected license %+v or terms %q", api.License, api.TermsOfService)
+	}
 }
 
-func TestIsURL(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected bool
-	}{
-		{"https://example.com/api.json", true},
-		{"http://localhost:8080/spec.yaml", true},
-		{"./openapi.json", false},
-		{"/path/to/spec.json", false},
-		{"file.json", false},
+func TestParsePreprocess(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Internal API
+  version: "1.0.0"
+paths:
+  /health:
+    get:
+      summary: Health check
+      responses:
+        "200":
+          description: OK
+`
+	tmpDir := t.TempDir()
+	tmpFile := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
 	}
 
-	for _, tt := range tests {
-		result := isURL(tt.input)
-		if result != tt.expected {
-			t.Errorf("isURL(%q) = %v, expected %v", tt.input, result, tt.expected)
+	api, err := Parse(tmpFile, &ParseOptions{
+		Preprocess: func(data []byte) ([]byte, error) {
+			return []byte(strings.Replace(string(data), "Internal API", "Public API", 1)), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if api.Title != "Public API" {
+		t.Errorf("Expected preprocessed title 'Public API', got '%s'", api.Title)
+	}
+}
+
+func TestParseServers(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Servers API
+  version: "1.0.0"
+servers:
+  - url: https://{region}.api.example.com/{version}
+    description: Production
+    variables:
+      region:
+        default: eu
+        enum: [eu, us]
+      version:
+        default: v1
+  - url: https://sandbox.example.com
+    description: Sandbox
+paths: {}
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(api.Servers) != 2 {
+		t.Fatalf("Expected 2 servers, got %d", len(api.Servers))
+	}
+	if api.Servers[1].Description != "Sandbox" {
+		t.Errorf("Expected second server 'Sandbox', got '%s'", api.Servers[1].Description)
+	}
+	if got := api.Servers[0].ResolvedURL(); got != "https://eu.api.example.com/v1" {
+		t.Errorf("Expected resolved URL 'https://eu.api.example.com/v1', got '%s'", got)
+	}
+	if len(api.Servers[0].Variables["region"].Enum) != 2 {
+		t.Errorf("Expected 2 enum values for region, got %v", api.Servers[0].Variables["region"].Enum)
+	}
+}
+
+func TestParseKeepsSpecOrder(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Ordered API
+  version: "1.0.0"
+paths:
+  /zebras:
+    post:
+      responses:
+        "201":
+          description: Created
+    get:
+      responses:
+        "200":
+          description: OK
+  /apples:
+    get:
+      responses:
+        "200":
+          description: OK
+  /mangos:
+    delete:
+      responses:
+        "204":
+          description: Deleted
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	expected := []string{"POST /zebras", "GET /zebras", "GET /apples", "DELETE /mangos"}
+	if len(api.Endpoints) != len(expected) {
+		t.Fatalf("Expected %d endpoints, got %d", len(expected), len(api.Endpoints))
+	}
+	for i, ep := range api.Endpoints {
+		if got := ep.Method + " " + ep.Path; got != expected[i] {
+			t.Errorf("Endpoint %d: expected %q, got %q", i, expected[i], got)
+		}
+	}
+}
+
+func TestParseExternalDocs(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Docs API
+  version: "1.0.0"
+externalDocs:
+  url: https://docs.example.com
+tags:
+  - name: users
+    externalDocs:
+      description: User guide
+      url: https://docs.example.com/users
+paths:
+  /users:
+    get:
+      tags: [users]
+      externalDocs:
+        url: https://docs.example.com/users/list
+      responses:
+        "200":
+          description: OK
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if api.ExternalDocs == nil || api.ExternalDocs.URL != "https://docs.example.com" {
+		t.Errorf("Expected root externalDocs, got %+v", api.ExternalDocs)
+	}
+	if api.Tags[0].ExternalDocs == nil || api.Tags[0].ExternalDocs.Description != "User guide" {
+		t.Errorf("Expected tag externalDocs, got %+v", api.Tags[0].ExternalDocs)
+	}
+	if api.Endpoints[0].ExternalDocs == nil || api.Endpoints[0].ExternalDocs.URL != "https://docs.example.com/users/list" {
+		t.Errorf("Expected operation externalDocs, got %+v", api.Endpoints[0].ExternalDocs)
+	}
+}
+
+func TestParseSecurityRequirements(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Secure API
+  version: "1.0.0"
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+    post:
+      security:
+        - petstore_auth: [write:pets]
+      responses:
+        "201":
+          description: Created
+  /health:
+    get:
+      security: []
+      responses:
+        "200":
+          description: OK
+components:
+  securitySchemes:
+    petstore_auth:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: https://example.com/oauth
+          scopes:
+            write:pets: modify pets
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	security := make(map[string][]SecurityRequirement)
+	for _, ep := range api.Endpoints {
+		security[ep.Method+" "+ep.Path] = ep.Security
+	}
+
+	if got := security["GET /pets"]; len(got) != 1 || got[0]["apiKey"] == nil {
+		t.Errorf("GET /pets should inherit global security, got %v", got)
+	}
+	if got := security["POST /pets"]; len(got) != 1 || len(got[0]["petstore_auth"]) != 1 {
+		t.Errorf("POST /pets should use operation security, got %v", got)
+	}
+	if got := security["GET /health"]; len(got) != 0 {
+		t.Errorf("GET /health should have no security, got %v", got)
+	}
+	if api.SecuritySchemes[0].Name != "apiKey" {
+		t.Errorf("security schemes should be sorted by name, got %s first", api.SecuritySchemes[0].Name)
+	}
+	flows := api.SecuritySchemes[1].Flows
+	if len(flows) != 1 || flows[0].Type != "implicit" || flows[0].AuthorizationURL != "https://example.com/oauth" || flows[0].Scopes["write:pets"] != "modify pets" {
+		t.Errorf("unexpected oauth2 flows: %+v", flows)
+	}
+}
+
+func TestParseParameterStyle(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Styles
+  version: "1.0.0"
+paths:
+  /orders:
+    get:
+      parameters:
+        - name: ids
+          in: query
+          style: form
+          explode: false
+          schema:
+            type: array
+            items:
+              type: integer
+        - name: filter
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              status: {type: string}
+              created: {type: string}
+      responses:
+        "200":
+          description: OK
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	params := api.Endpoints[0].Parameters
+	if ids := params[0]; ids.Style != "form" || ids.Explode == nil || *ids.Explode || ids.ItemType != "integer" {
+		t.Errorf("unexpected ids parameter: %+v", ids)
+	}
+	if filter := params[1]; filter.Style != "deepObject" || len(filter.Properties) != 2 || filter.Properties[0] != "created" {
+		t.Errorf("unexpected filter parameter: %+v", filter)
+	}
+}
+
+func TestParsePathLevelParameters(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Path params
+  version: "1.0.0"
+paths:
+  /users/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema: {type: string}
+      - name: X-Tenant
+        in: header
+        description: Tenant from path item
+        schema: {type: string}
+    get:
+      responses:
+        "200":
+          description: OK
+    delete:
+      parameters:
+        - name: X-Tenant
+          in: header
+          description: Tenant override
+          schema: {type: string}
+        - name: force
+          in: query
+          schema: {type: boolean}
+      responses:
+        "204":
+          description: Deleted
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	params := make(map[string][]Parameter)
+	for _, ep := range api.Endpoints {
+		params[ep.Method] = ep.Parameters
+	}
+	if got := params["GET"]; len(got) != 2 || got[0].Name != "id" || got[0].Location.Pointer != "/paths/~1users~1{id}/parameters/0" {
+		t.Errorf("GET should inherit path-level parameters, got %+v", got)
+	}
+	got := params["DELETE"]
+	if len(got) != 3 {
+		t.Fatalf("DELETE should have id, X-Tenant and force, got %+v", got)
+	}
+	for _, p := range got {
+		if p.Name == "X-Tenant" && (p.Description != "Tenant override" || p.Location.Pointer != "/paths/~1users~1{id}/delete/parameters/0") {
+			t.Errorf("operation parameter should override the path-level one, got %+v", p)
+		}
+	}
+}
+
+func TestParseNonStringEnums(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Enums
+  version: "1.0.0"
+paths:
+  /jobs:
+    get:
+      parameters:
+        - name: priority
+          in: query
+          schema:
+            type: integer
+            enum: [1, 2, 3]
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  ratio:
+                    type: number
+                    enum: [0.5, 1.5]
+                  done:
+                    type: boolean
+                    enum: [true, false]
+                  state:
+                    type: string
+                    nullable: true
+                    enum: [open, null]
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, &ParseOptions{SkipValidation: true})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	ep := api.Endpoints[0]
+	if got := strings.Join(ep.Parameters[0].Enum, ","); got != "1,2,3" {
+		t.Errorf("integer enum = %q, expected 1,2,3", got)
+	}
+	props := ep.Responses["200"].Content["application/json"].Schema.Properties
+	for name, expected := range map[string]string{"ratio": "0.5,1.5", "done": "true,false", "state": "open,null"} {
+		if got := strings.Join(props[name].Enum, ","); got != expected {
+			t.Errorf("%s enum = %q, expected %q", name, got, expected)
 		}
 	}
 }
+
+func TestParseEnumDescriptions(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Enums
+  version: "1.0.0"
+paths:
+  /accounts:
+    get:
+      parameters:
+        - name: status
+          in: query
+          schema:
+            type: integer
+            enum: [1, 2]
+            x-enumNames: [ACTIVE, SUSPENDED]
+            x-enum-descriptions: [Account can sign in, Sign-in is blocked]
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  plan:
+                    type: string
+                    enum: [free, pro]
+                    x-enum-varnames: [free, PRO]
+                    x-enum-descriptions:
+                      pro: Paid plan
+                  color:
+                    type: string
+                    enum: [red]
+                    x-enumNames: [red]
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, &ParseOptions{SkipValidation: true})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	ep := api.Endpoints[0]
+	expected := map[string]string{"1": "ACTIVE — Account can sign in", "2": "SUSPENDED — Sign-in is blocked"}
+	if got := ep.Parameters[0].EnumDescriptions; !maps.Equal(got, expected) {
+		t.Errorf("parameter enum descriptions = %v, expected %v", got, expected)
+	}
+	props := ep.Responses["200"].Content["application/json"].Schema.Properties
+	if got := props["plan"].EnumDescriptions; !maps.Equal(got, map[string]string{"pro": "PRO — Paid plan"}) {
+		t.Errorf("plan enum descriptions = %v", got)
+	}
+	// Имена, совпадающие со значениями, ничего не объясняют
+	if got := props["color"].EnumDescriptions; got != nil {
+		t.Errorf("expected no descriptions for color, got %v", got)
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Defaults
+  version: "1.0.0"
+paths:
+  /pets:
+    post:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            default: 20
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                status:
+                  type: string
+                  default: available
+      responses:
+        "200":
+          description: OK
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	ep := api.Endpoints[0]
+	if got := ep.Parameters[0].Default; got != 20.0 {
+		t.Errorf("parameter default = %v, expected 20", got)
+	}
+	if got := ep.RequestBody.Content["application/json"].Schema.Properties["status"].Default; got != "available" {
+		t.Errorf("field default = %v, expected available", got)
+	}
+}
+
+func TestParseWebSocket(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Streams
+  version: "1.0.0"
+paths:
+  /ws:
+    get:
+      x-websocket:
+        subprotocols: [graphql-ws]
+        send:
+          - name: subscribe
+            schema:
+              $ref: "#/components/schemas/Subscribe"
+        receive:
+          - name: tick
+            description: Price update
+            schema:
+              type: object
+              properties:
+                price:
+                  type: number
+      responses:
+        "101":
+          description: Switching Protocols
+  /events:
+    get:
+      responses:
+        "101":
+          description: Switching Protocols
+  /users:
+    get:
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Subscribe:
+      type: object
+      properties:
+        channel:
+          type: string
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	endpoints := map[string]Endpoint{}
+	for _, ep := range api.Endpoints {
+		endpoints[ep.Path] = ep
+	}
+	ws := endpoints["/ws"].WebSocket
+	if ws == nil {
+		t.Fatal("expected WebSocket for x-websocket operation")
+	}
+	if len(ws.Subprotocols) != 1 || ws.Subprotocols[0] != "graphql-ws" {
+		t.Errorf("unexpected subprotocols %v", ws.Subprotocols)
+	}
+	if len(ws.Send) != 1 || ws.Send[0].Schema.RefName() != "Subscribe" || ws.Send[0].Schema.Properties["channel"] == nil {
+		t.Errorf("expected send message resolved to Subscribe, got %+v", ws.Send)
+	}
+	if len(ws.Receive) != 1 || ws.Receive[0].Description != "Price update" || ws.Receive[0].Schema.Properties["price"] == nil {
+		t.Errorf("unexpected receive messages %+v", ws.Receive)
+	}
+	if endpoints["/events"].WebSocket == nil {
+		t.Error("101 response should mark the operation as WebSocket")
+	}
+	if endpoints["/users"].WebSocket != nil {
+		t.Error("plain HTTP operation should not be WebSocket")
+	}
+}
+
+func TestParseResponseLinks(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Links
+  version: "1.0.0"
+paths:
+  /users:
+    post:
+      responses:
+        "201":
+          description: Created
+          links:
+            GetUser:
+              operationId: getUser
+              description: Fetch the created user
+              parameters:
+                userId: $response.body#/id
+  /users/{userId}:
+    get:
+      operationId: getUser
+      parameters:
+        - name: userId
+          in: path
+          required: true
+          schema: {type: string}
+      responses:
+        "200":
+          description: OK
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	for _, ep := range api.Endpoints {
+		if ep.Method != "POST" {
+			continue
+		}
+		links := ep.Responses["201"].Links
+		if len(links) != 1 || links[0].Name != "GetUser" || links[0].OperationID != "getUser" ||
+			links[0].Parameters["userId"] != "$response.body#/id" || links[0].Description != "Fetch the created user" {
+			t.Errorf("unexpected links: %+v", links)
+		}
+	}
+}
+
+func TestParseOffline(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Remote API
+  version: "1.0.0"
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: "https://schemas.example.com/user.yaml"
+              examples:
+                user:
+                  externalValue: "https://examples.example.com/user.json"
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	_, err := Parse(tmpFile, &ParseOptions{Offline: true})
+	var netErr *NetworkRequiredError
+	if !errors.As(err, &netErr) {
+		t.Fatalf("Expected NetworkRequiredError, got %v", err)
+	}
+	if len(netErr.Resources) != 2 {
+		t.Errorf("Expected 2 remote resources, got %v", netErr.Resources)
+	}
+	if !strings.Contains(err.Error(), "https://examples.example.com/user.json") {
+		t.Errorf("Error should list externalValue URL, got %q", err.Error())
+	}
+
+	if _, err := Parse("https://api.example.com/openapi.json", &ParseOptions{Offline: true}); !errors.As(err, &netErr) {
+		t.Errorf("Expected NetworkRequiredError for URL source, got %v", err)
+	}
+}
+
+func TestParseLocations(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Located API
+  version: "1.0.0"
+paths:
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	ep := api.Endpoints[0]
+	if ep.Location != (Location{Pointer: "/paths/~1users~1{id}/get", Line: 7, Column: 5}) {
+		t.Errorf("unexpected operation location: %+v", ep.Location)
+	}
+	if got := ep.Parameters[0].Location; got != (Location{Pointer: "/paths/~1users~1{id}/get/parameters/0", Line: 9, Column: 11}) {
+		t.Errorf("unexpected parameter location: %+v", got)
+	}
+}
+
+func TestParsePostmanCollection(t *testing.T) {
+	collection := `{
+  "info": {
+    "name": "Shop API",
+    "description": "Postman export",
+    "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+  },
+  "auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{token}}"}]},
+  "variable": [{"key": "baseUrl", "value": "https://api.shop.example/v1"}],
+  "item": [
+    {
+      "name": "Orders",
+      "description": "Order management",
+      "item": [
+        {
+          "name": "Get order",
+          "request": {
+            "method": "GET",
+            "url": {
+              "raw": "{{baseUrl}}/orders/:orderId?expand=items",
+              "host": ["{{baseUrl}}"],
+              "path": ["orders", ":orderId"],
+              "query": [{"key": "expand", "value": "items", "description": "Related objects"}],
+              "variable": [{"key": "orderId", "value": "42", "description": "Order ID"}]
+            }
+          },
+          "response": [
+            {"name": "Found", "code": 200, "body": "{\"id\": 42, \"total\": 9.5}"}
+          ]
+        },
+        {
+          "name": "Create order",
+          "request": {
+            "method": "POST",
+            "auth": {"type": "noauth"},
+            "header": [{"key": "Content-Type", "value": "application/json"}],
+            "body": {"mode": "raw", "raw": "{\"sku\": \"abc\", \"qty\": 2}"},
+            "url": "{{baseUrl}}/orders"
+          }
+        }
+      ]
+    }
+  ]
+}`
+	tmpFile := filepath.Join(t.TempDir(), "collection.json")
+	if err := os.WriteFile(tmpFile, []byte(collection), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if api.Title != "Shop API" || api.BaseURL != "https://api.shop.example/v1" {
+		t.Errorf("unexpected title/base URL: %q %q", api.Title, api.BaseURL)
+	}
+	if len(api.Tags) != 1 || api.Tags[0].Description != "Order management" {
+		t.Errorf("folder should become a tag, got %+v", api.Tags)
+	}
+	if len(api.Endpoints) != 2 {
+		t.Fatalf("Expected 2 endpoints, got %d", len(api.Endpoints))
+	}
+
+	get := api.Endpoints[0]
+	if get.Method != "GET" || get.Path != "/orders/{orderId}" || get.Tags[0] != "Orders" {
+		t.Errorf("unexpected endpoint: %s %s %v", get.Method, get.Path, get.Tags)
+	}
+	if len(get.Parameters) != 2 || get.Parameters[0].Description != "Order ID" || get.Parameters[1].In != "query" {
+		t.Errorf("unexpected parameters: %+v", get.Parameters)
+	}
+	if len(get.Security) != 1 || get.Security[0]["bearerAuth"] == nil {
+		t.Errorf("collection auth should be inherited, got %v", get.Security)
+	}
+	schema := get.Responses["200"].Content["application/json"].Schema
+	if schema == nil || schema.Properties["total"].Type != "number" || schema.Properties["id"].Type != "integer" {
+		t.Errorf("response schema should be inferred from example, got %+v", schema)
+	}
+
+	post := api.Endpoints[1]
+	if post.Path != "/orders" || len(post.Security) != 0 {
+		t.Errorf("noauth request should have no security, got %s %v", post.Path, post.Security)
+	}
+	if post.RequestBody == nil || post.RequestBody.Content["application/json"].Schema.Properties["qty"].Type != "integer" {
+		t.Errorf("request body schema should be inferred, got %+v", post.RequestBody)
+	}
+	if len(api.SecuritySchemes) != 1 || api.SecuritySchemes[0].Scheme != "bearer" {
+		t.Errorf("unexpected security schemes: %+v", api.SecuritySchemes)
+	}
+}
+
+func TestIsURL(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"https://example.com/api.json", true},
+		{"http://localhost:8080/spec.yaml", true},
+		{"./openapi.json", false},
+		{"/path/to/spec.json", false},
+		{"file.json", false},
+	}
+
+	for _, tt := range tests {
+		result := isURL(tt.input)
+		if result != tt.expected {
+			t.Errorf("isURL(%q) = %v, expected %v", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestParseWorkflows(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Workflows
+  version: "1.0.0"
+x-workflows:
+  - workflowId: createReport
+    summary: Create a report
+    steps:
+      - stepId: create
+        operationId: $sourceDescriptions.api.createReport
+        outputs:
+          id: $response.body#/id
+paths:
+  /reports:
+    post:
+      operationId: createReport
+      responses:
+        "202":
+          description: Accepted
+`
+	dir := t.TempDir()
+	tmpFile := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	api, err := Parse(tmpFile, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(api.Workflows) != 1 || len(api.Workflows[0].Steps) != 1 {
+		t.Fatalf("expected one workflow with one step, got %+v", api.Workflows)
+	}
+	step := api.Workflows[0].Steps[0]
+	if step.OperationID != "createReport" || step.Outputs["id"] != "$response.body#/id" {
+		t.Errorf("unexpected step %+v", step)
+	}
+
+	arazzo := `arazzo: 1.0.0
+info: {title: Reports, version: "1.0.0"}
+workflows:
+  - workflowId: fetchReport
+    inputs:
+      type: object
+      properties:
+        reportId: {type: string}
+    steps:
+      - stepId: poll
+        operationPath: '{$sourceDescriptions.api.url}#/paths/~1reports~1{id}/get'
+        parameters:
+          - name: id
+            in: path
+            value: $inputs.reportId
+        successCriteria:
+          - condition: $statusCode == 200
+        onFailure:
+          - name: wait
+            type: retry
+            retryAfter: 5
+            retryLimit: 10
+`
+	arazzoFile := filepath.Join(dir, "workflows.arazzo.yaml")
+	if err := os.WriteFile(arazzoFile, []byte(arazzo), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+	workflows, err := ParseWorkflowsFile(arazzoFile)
+	if err != nil {
+		t.Fatalf("ParseWorkflowsFile failed: %v", err)
+	}
+	if len(workflows) != 1 || len(workflows[0].Inputs) != 1 || workflows[0].Inputs[0] != "reportId" {
+		t.Fatalf("unexpected workflows %+v", workflows)
+	}
+	poll := workflows[0].Steps[0]
+	if poll.OperationPath != "#/paths/~1reports~1{id}/get" || poll.Parameters["id"] != "$inputs.reportId" {
+		t.Errorf("unexpected step %+v", poll)
+	}
+	if len(poll.OnFailure) != 1 || poll.OnFailure[0].Type != "retry" || poll.OnFailure[0].RetryLimit != 10 {
+		t.Errorf("unexpected onFailure %+v", poll.OnFailure)
+	}
+
+	if _, err := ParseWorkflowsFile(tmpFile); err == nil {
+		t.Error("expected an error for a non-Arazzo document")
+	}
+}
+
+func TestOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.yaml")
+	data := `operations:
+  getUser:
+    description: Returns the user profile.
+  post /users:
+    description: Creates a user.
+    appendDescription: Emails must be unique.
+    notes: [Rate limited.]
+    examples:
+      request: {email: jane@example.com}
+  deleteGhost:
+    description: Stale entry.
+`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	overrides, err := LoadOverrides(path)
+	if err != nil {
+		t.Fatalf("LoadOverrides failed: %v", err)
+	}
+
+	api := &API{Endpoints: []Endpoint{
+		{Method: "GET", Path: "/users/{id}", OperationID: "getUser", Description: "User"},
+		{Method: "POST", Path: "/users", Description: "Create"},
+		{Method: "GET", Path: "/users"},
+	}}
+	applied, unused := overrides.Apply(api)
+
+	if applied.Endpoints[0].Description != "Returns the user profile." {
+		t.Errorf("overrides not applied: %+v", applied.Endpoints)
+	}
+	if got := applied.Endpoints[1]; got.Description != "Creates a user.\n\nEmails must be unique." || len(got.Notes) != 1 {
+		t.Errorf("expected appended description and notes, got %+v", got)
+	}
+	if examples := overrides.Examples(api.Endpoints[1]); examples == nil || examples.Request.(map[string]any)["email"] != "jane@example.com" {
+		t.Errorf("unexpected examples: %+v", examples)
+	}
+	if overrides.Examples(api.Endpoints[0]) != nil {
+		t.Error("operation without example overrides should have no examples")
+	}
+	if applied.Endpoints[2].Description != "" {
+		t.Errorf("unmatched operation should be unchanged, got %q", applied.Endpoints[2].Description)
+	}
+	if api.Endpoints[0].Description != "User" {
+		t.Error("Apply must not modify the source API")
+	}
+	if len(unused) != 1 || unused[0] != "deleteGhost" {
+		t.Errorf("expected deleteGhost to be reported as unused, got %v", unused)
+	}
+
+}
+
+func TestSaveDescriptions(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "overrides.yaml")
+	data := `# Hand-curated corrections, reviewed by the docs team
+operations:
+  # Keep in sync with the billing FAQ
+  getUser:
+    description: Returns the user profile. # confirmed by support
+  post /users:
+    notes: [Rate limited.]
+    examples:
+      request: {email: jane@example.com}
+`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err := SaveDescriptions(path, map[string]string{
+		"post /users": "Creates a user.",
+		"listUsers":   "Lists users.\nPaginated by cursor.",
+	})
+	if err != nil {
+		t.Fatalf("SaveDescriptions failed: %v", err)
+	}
+	got, _ := os.ReadFile(path)
+	want := `# Hand-curated corrections, reviewed by the docs team
+operations:
+  # Keep in sync with the billing FAQ
+  getUser:
+    description: Returns the user profile. # confirmed by support
+  post /users:
+    notes: [Rate limited.]
+    examples:
+      request: {email: jane@example.com}
+    description: Creates a user.
+  listUsers:
+    description: |-
+      Lists users.
+      Paginated by cursor.
+`
+	if string(got) != want {
+		t.Errorf("unexpected overrides file:\n%s\nwant:\n%s", got, want)
+	}
+
+	// JSON остаётся JSON с прежним порядком ключей
+	path = filepath.Join(dir, "overrides.json")
+	data = `{
+  "operations": {
+    "post /users": {"notes": ["Rate limited."], "examples": {"request": {"age": 30}}},
+    "getUser": {"description": "Returns the user profile."}
+  }
+}
+`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveDescriptions(path, map[string]string{"post /users": "Creates a user."}); err != nil {
+		t.Fatalf("SaveDescriptions failed: %v", err)
+	}
+	got, _ = os.ReadFile(path)
+	want = `{
+  "operations": {
+    "post /users": {
+      "notes": [
+        "Rate limited."
+      ],
+      "examples": {
+        "request": {
+          "age": 30
+        }
+      },
+      "description": "Creates a user."
+    },
+    "getUser": {
+      "description": "Returns the user profile."
+    }
+  }
+}
+`
+	if string(got) != want {
+		t.Errorf("unexpected JSON overrides file:\n%s\nwant:\n%s", got, want)
+	}
+	if _, err := LoadOverrides(path); err != nil {
+		t.Errorf("rewritten JSON overrides do not load: %v", err)
+	}
+
+	// Отсутствующий файл создаётся
+	path = filepath.Join(dir, "new.yaml")
+	if err := SaveDescriptions(path, map[string]string{"getUser": "Returns the user."}); err != nil {
+		t.Fatalf("SaveDescriptions failed: %v", err)
+	}
+	got, _ = os.ReadFile(path)
+	if want := "operations:\n  getUser:\n    description: Returns the user.\n"; string(got) != want {
+		t.Errorf("unexpected new overrides file:\n%s", got)
+	}
+}
+
+func TestParseBestEffort(t *testing.T) {
+	spec := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      summary: List users
+      responses:
+        "200":
+          description: OK
+    post:
+      summary: Broken parameter
+      parameters:
+        - name: mode
+          in: body
+          schema:
+            type: string
+      responses:
+        "201":
+          description: Created
+  /users/{id}:
+    get:
+      summary: Missing path parameter
+      responses:
+        "200":
+          description: OK
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Parse(tmpFile, nil); err == nil {
+		t.Fatal("expected validation error without BestEffort")
+	}
+
+	api, err := Parse(tmpFile, &ParseOptions{BestEffort: true})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(api.Endpoints) != 1 || api.Endpoints[0].Method != "GET" || api.Endpoints[0].Path != "/users" {
+		t.Errorf("expected only GET /users to survive, got %+v", api.Endpoints)
+	}
+	if len(api.Warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %+v", api.Warnings)
+	}
+	post, missing := api.Warnings[0], api.Warnings[1]
+	if post.Method != "POST" || post.Path != "/users" || post.Location.Line != 12 || !strings.HasPrefix(post.String(), "POST /users skipped: ") {
+		t.Errorf("unexpected warning for POST /users: %+v", post)
+	}
+	if missing.Method != "GET" || missing.Path != "/users/{id}" || missing.Location.Pointer != "/paths/~1users~1{id}/get" {
+		t.Errorf("unexpected warning for GET /users/{id}: %+v", missing)
+	}
+}
+
+func TestParseErrorKinds(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, spec string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(spec), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	_, err := Parse(filepath.Join(dir, "missing.yaml"), nil)
+	if !errors.Is(err, ErrSpecNotFound) {
+		t.Errorf("missing file: expected ErrSpecNotFound, got %v", err)
+	}
+
+	swagger := write("swagger.yaml", "swagger: \"2.0\"\ninfo:\n  title: Old API\n  version: 1.0.0\npaths: {}\n")
+	_, err = Parse(swagger, &ParseOptions{SkipValidation: true})
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("swagger 2.0: expected ErrUnsupportedVersion, got %v", err)
+	}
+	if _, err := ParseFile(swagger); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("ParseFile swagger 2.0: expected ErrUnsupportedVersion, got %v", err)
+	}
+
+	invalid := write("invalid.yaml", `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      responses:
+        "200":
+          description: OK
+`)
+	_, err = Parse(invalid, nil)
+	var specErr *InvalidSpecError
+	if !errors.As(err, &specErr) || !errors.Is(err, ErrInvalidSpec) {
+		t.Fatalf("expected InvalidSpecError, got %v", err)
+	}
+	if len(specErr.Details) == 0 || !strings.Contains(specErr.Details[0], "id") {
+		t.Errorf("expected details about path parameter id, got %v", specErr.Details)
+	}
+	if errors.Is(err, ErrSpecNotFound) || errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("invalid spec matched another error kind: %v", err)
+	}
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	if _, err := Parse(srv.URL+"/openapi.yaml", nil); !errors.Is(err, ErrSpecNotFound) {
+		t.Errorf("HTTP 404: expected ErrSpecNotFound, got %v", err)
+	}
+}
+
+func TestParseContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := ParseContext(ctx, srv.URL+"/openapi.yaml", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("fetch was not canceled, took %s", elapsed)
+	}
+}
+
+// writeLargeSpec записывает синтетическую спецификацию из resources ресурсов по четыре операции
+// с $ref на схемы компонентов; 2000 ресурсов дают около 5 МБ YAML
+func writeLargeSpec(tb testing.TB, resources int) string {
+	var sb strings.Builder
+	sb.WriteString("openapi: 3.0.3\ninfo:\n  title: Large API\n  version: 1.0.0\nservers:\n  - url: https://api.example.com\npaths:\n")
+	for i := range resources {
+		fmt.Fprintf(&sb, `  /resources%[1]d:
+    get:
+      operationId: listResources%[1]d
+      summary: List resources %[1]d
+      description: Returns a page of resources of kind %[1]d ordered by creation time, newest first.
+      tags: [group%[2]d]
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+        - name: status
+          in: query
+          schema:
+            type: string
+            enum: [active, archived]
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Resource%[1]d'
+        "401":
+          $ref: '#/components/responses/Unauthorized'
+    post:
+      operationId: createResource%[1]d
+      summary: Create resource %[1]d
+      tags: [group%[2]d]
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Resource%[1]d'
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Resource%[1]d'
+  /resources%[1]d/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+    get:
+      operationId: getResource%[1]d
+      summary: Get resource %[1]d
+      tags: [group%[2]d]
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Resource%[1]d'
+        "404":
+          $ref: '#/components/responses/NotFound'
+    delete:
+      operationId: deleteResource%[1]d
+      summary: Delete resource %[1]d
+      tags: [group%[2]d]
+      responses:
+        "204":
+          description: Deleted
+`, i, i/50)
+	}
+	sb.WriteString(`components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+        minimum: 1
+        maximum: 100
+  responses:
+    Unauthorized:
+      description: Missing or invalid token
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Error'
+    NotFound:
+      description: Resource not found
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Error'
+  schemas:
+    Error:
+      type: object
+      required: [code, message]
+      properties:
+        code:
+          type: string
+        message:
+          type: string
+    Address:
+      type: object
+      properties:
+        street:
+          type: string
+        city:
+          type: string
+        country:
+          type: string
+          pattern: '^[A-Z]{2}$'
+`)
+	for i := range resources {
+		fmt.Fprintf(&sb, `    Resource%d:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+          maxLength: 120
+          description: Human-readable name shown in listings and search results.
+        status:
+          type: string
+          enum: [active, archived]
+        address:
+          $ref: '#/components/schemas/Address'
+        tags:
+          type: array
+          items:
+            type: string
+        created_at:
+          type: string
+          format: date-time
+          readOnly: true
+`, i)
+	}
+
+	path := filepath.Join(tb.TempDir(), "large.yaml")
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		tb.Fatal(err)
+	}
+	return path
+}
+
+func BenchmarkParseLarge(b *testing.B) {
+	path := writeLargeSpec(b, 2000)
+	if info, err := os.Stat(path); err == nil {
+		b.SetBytes(info.Size())
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		api, err := Parse(path, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(api.Endpoints) != 8000 {
+			b.Fatalf("expected 8000 endpoints, got %d", len(api.Endpoints))
+		}
+	}
+}
+
+func BenchmarkParseLargeSkipValidation(b *testing.B) {
+	path := writeLargeSpec(b, 2000)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := Parse(path, &ParseOptions{SkipValidation: true}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestParseRecursiveSchema(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /nodes:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Node'
+components:
+  schemas:
+    Node:
+      type: object
+      description: Tree node
+      properties:
+        name:
+          type: string
+        parent:
+          $ref: '#/components/schemas/Node'
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Node'
+`
+	tmpFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(tmpFile, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	api, err := Parse(tmpFile, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	node := api.Endpoints[0].Responses["200"].Content["application/json"].Schema
+	parent := node.Properties["parent"]
+	if parent == nil || parent.Type != "object" || parent.Ref != "#/components/schemas/Node" || len(parent.Properties) != 0 {
+		t.Errorf("expected recursive reference cut to a stub, got %+v", parent)
+	}
+	if items := node.Properties["children"].Items; items == nil || items.Description != "Tree node" || items.Properties != nil {
+		t.Errorf("expected recursive items cut to a stub, got %+v", items)
+	}
+}
+
+// fuzzSchema строит дерево схем по байтам: младшие три бита выбирают операцию над текущим узлом,
+// старшие — тип нового узла или предка, на которого ссылается цикл
+func fuzzSchema(data []byte) *openapi3.Schema {
+	types := []string{"object", "array", "string", "integer", "number", "boolean", "null", "", "weird"}
+	root := &openapi3.Schema{}
+	stack := []*openapi3.Schema{root}
+	for i, b := range data {
+		current := stack[len(stack)-1]
+		child := &openapi3.Schema{Type: &openapi3.Types{types[int(b>>3)%len(types)]}}
+		switch b % 8 {
+		case 0:
+			if current.Properties == nil {
+				current.Properties = openapi3.Schemas{}
+			}
+			current.Properties[fmt.Sprintf("p%d", i)] = openapi3.NewSchemaRef("", child)
+			stack = append(stack, child)
+		case 1:
+			current.Items = openapi3.NewSchemaRef("", child)
+			stack = append(stack, child)
+		case 2:
+			current.AllOf = append(current.AllOf, openapi3.NewSchemaRef("", child))
+			stack = append(stack, child)
+		case 3:
+			current.OneOf = append(current.OneOf, openapi3.NewSchemaRef("", child))
+			stack = append(stack, child)
+		case 4:
+			current.AnyOf = append(current.AnyOf, openapi3.NewSchemaRef("", child))
+			stack = append(stack, child)
+		case 5:
+			// Ссылка на предка — цикл, как у рекурсивного $ref
+			ancestor := stack[int(b>>3)%len(stack)]
+			if b&0x80 != 0 {
+				current.Items = openapi3.NewSchemaRef("#/components/schemas/Cycle", ancestor)
+			} else {
+				if current.Properties == nil {
+					current.Properties = openapi3.Schemas{}
+				}
+				current.Properties[fmt.Sprintf("self%d", i)] = openapi3.NewSchemaRef("#/components/schemas/Cycle", ancestor)
+			}
+		case 6:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case 7:
+			values := []any{nil, "text", float64(b), true, map[string]any{"k": []any{1.5}}, []any{}}
+			current.Enum = append(current.Enum, values[int(b>>3)%len(values)])
+			current.Example = values[int(b>>4)%len(values)]
+		}
+	}
+	return root
+}
+
+func FuzzConvertSchema(f *testing.F) {
+	f.Add([]byte{0, 8, 6, 1, 5})
+	f.Add([]byte{2, 0, 0x85, 3, 0x0d, 4, 7, 0x2f})
+	f.Add([]byte{1, 1, 1, 1, 0x85, 6, 6, 0x45})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		schema := convertSchemaRef(openapi3.NewSchemaRef("", fuzzSchema(data)))
+		// Модель должна оставаться ациклической: json.Marshal сообщает о циклах ошибкой
+		if _, err := json.Marshal(schema); err != nil {
+			t.Fatalf("converted schema is not serializable: %v", err)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// httpMethods — ключи path item, которые являются операциями
+var httpMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true,
+	"options": true, "head": true, "patch": true, "trace": true,
+}
+
+// parseDocumentNode разбирает исходный текст спецификации в дерево YAML-узлов с позициями.
+// JSON является подмножеством YAML, поэтому оба формата разбираются одинаково
+func parseDocumentNode(data []byte) *yaml.Node {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil || len(root.Content) == 0 {
+		return nil
+	}
+	return root.Content[0]
+}
+
+// annotateSource упорядочивает эндпоинты как в документе и проставляет места элементов в исходном тексте
+func annotateSource(api *API, data []byte) {
+	doc := parseDocumentNode(data)
+	sortBySpecOrder(api.Endpoints, specOrder(doc))
+	annotateLocations(api, doc)
+	annotateWarnings(api.Warnings, doc)
+}
+
+// specOrder возвращает порядок операций ("METHOD /path") так, как они записаны в документе
+func specOrder(doc *yaml.Node) map[string]int {
+	paths := mappingValue(doc, "paths")
+	if paths == nil || paths.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	order := make(map[string]int)
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		path := paths.Content[i].Value
+		item := paths.Content[i+1]
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			method := strings.ToLower(item.Content[j].Value)
+			if httpMethods[method] {
+				order[strings.ToUpper(method)+" "+path] = len(order)
+			}
+		}
+	}
+	return order
+}
+
+// mappingValue возвращает значение ключа в YAML mapping
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	_, value := mappingEntry(node, key)
+	return value
+}
+
+// sortBySpecOrder упорядочивает эндпоинты как в документе.
+// Эндпоинты без известной позиции идут в конце, отсортированные по пути и методу
+func sortBySpecOrder(endpoints []Endpoint, order map[string]int) {
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		oi, iok := order[endpoints[i].Method+" "+endpoints[i].Path]
+		oj, jok := order[endpoints[j].Method+" "+endpoints[j].Path]
+		switch {
+		case iok && jok:
+			return oi < oj
+		case iok != jok:
+			return iok
+		case endpoints[i].Path != endpoints[j].Path:
+			return endpoints[i].Path < endpoints[j].Path
+		default:
+			return endpoints[i].Method < endpoints[j].Method
+		}
+	})
+}
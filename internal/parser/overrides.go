@@ -0,0 +1,260 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Overrides — правки документации поверх спецификации, ключ — operationId или "METHOD /path"
+//
+//	operations:
+//	  getUser:
+//	    description: Returns the user profile with its settings.
+//	  POST /users:
+//	    appendDescription: Emails must be unique within an organization.
+//	    notes: [Rate limited to 10 requests per minute per token.]
+//	    examples:
+//	      request: {email: jane@example.com}
+//	      responses:
+//	        "201": {id: 42, email: jane@example.com}
+type Overrides struct {
+	Operations map[string]OperationOverride `yaml:"operations"`
+}
+
+// OperationOverride — правка одной операции; пустые поля не меняют спецификацию
+type OperationOverride struct {
+	Summary           string            `yaml:"summary,omitempty"`           // заменяет summary
+	Description       string            `yaml:"description,omitempty"`       // заменяет описание операции
+	AppendDescription string            `yaml:"appendDescription,omitempty"` // дописывается абзацем после описания
+	Notes             []string          `yaml:"notes,omitempty"`             // примечания отдельной секцией
+	Examples          *ExampleOverrides `yaml:"examples,omitempty"`
+}
+
+// ExampleOverrides — готовые примеры вместо синтезированных по схеме. Строка выводится как есть,
+// остальные значения — как JSON
+type ExampleOverrides struct {
+	Request   any            `yaml:"request,omitempty"`   // тело примера запроса
+	Responses map[string]any `yaml:"responses,omitempty"` // код статуса → тело ответа
+}
+
+// LoadOverrides читает файл правок (YAML или JSON)
+func LoadOverrides(path string) (*Overrides, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overrides: %w", err)
+	}
+	var o Overrides
+	if err := yaml.Unmarshal(data, &o); err != nil {
+		return nil, fmt.Errorf("failed to parse overrides %s: %w", path, err)
+	}
+	return &o, nil
+}
+
+// SaveDescriptions записывает описания операций (ключ правки → описание) в файл правок, меняя только
+// поля description: комментарии, порядок и оформление остальных правок сохраняются, JSON остаётся JSON.
+// Новые операции добавляются в конец operations по алфавиту; отсутствующий файл создаётся
+func SaveDescriptions(path string, descriptions map[string]string) error {
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("failed to read overrides: %w", err)
+	default:
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("failed to parse overrides %s: %w", path, err)
+		}
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if !asMapping(root) {
+		return fmt.Errorf("failed to update overrides %s: expected a mapping with operations", path)
+	}
+	operations := ensureMapping(root, "operations")
+	if operations == nil {
+		return fmt.Errorf("failed to update overrides %s: operations is not a mapping", path)
+	}
+	for _, key := range slices.Sorted(maps.Keys(descriptions)) {
+		op := ensureMapping(operations, key)
+		if op == nil {
+			return fmt.Errorf("failed to update overrides %s: operation %q is not a mapping", path, key)
+		}
+		text := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: descriptions[key]}
+		if strings.Contains(text.Value, "\n") {
+			text.Style = yaml.LiteralStyle
+		}
+		_, old := mappingEntry(op, "description")
+		switch {
+		case old == nil:
+			op.Content = append(op.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "description"}, text)
+		case old.Kind != yaml.ScalarNode:
+			return fmt.Errorf("failed to update overrides %s: description of %q is not a string", path, key)
+		default:
+			text.HeadComment, text.LineComment, text.FootComment = old.HeadComment, old.LineComment, old.FootComment
+			*old = *text
+		}
+	}
+
+	var buf bytes.Buffer
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		writeJSONNode(&buf, root, "")
+		buf.WriteByte('\n')
+	} else {
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(&doc); err != nil {
+			return fmt.Errorf("failed to encode overrides: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write overrides: %w", err)
+	}
+	return nil
+}
+
+// ensureMapping возвращает значение-отображение ключа key, добавляя пустое, если ключа нет;
+// nil — значение есть, но это не отображение
+func ensureMapping(m *yaml.Node, key string) *yaml.Node {
+	if _, v := mappingEntry(m, key); v != nil {
+		if asMapping(v) {
+			return v
+		}
+		return nil
+	}
+	v := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Style: m.Style & yaml.FlowStyle}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, v)
+	return v
+}
+
+// asMapping сообщает, что узел — отображение; пустое значение (key: без значения) превращает в отображение
+func asMapping(n *yaml.Node) bool {
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		n.Kind, n.Tag, n.Value = yaml.MappingNode, "!!map", ""
+	}
+	return n.Kind == yaml.MappingNode
+}
+
+// writeJSONNode записывает узел как JSON с отступом в два пробела, сохраняя порядок ключей
+func writeJSONNode(buf *bytes.Buffer, n *yaml.Node, indent string) {
+	switch n.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		open, close, step := "{", "}", 2
+		if n.Kind == yaml.SequenceNode {
+			open, close, step = "[", "]", 1
+		}
+		if len(n.Content) == 0 {
+			buf.WriteString(open + close)
+			return
+		}
+		buf.WriteString(open)
+		for i := 0; i < len(n.Content); i += step {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString("\n" + indent + "  ")
+			if step == 2 {
+				key, _ := json.Marshal(n.Content[i].Value)
+				buf.Write(key)
+				buf.WriteString(": ")
+			}
+			writeJSONNode(buf, n.Content[i+step-1], indent+"  ")
+		}
+		buf.WriteString("\n" + indent + close)
+	case yaml.AliasNode:
+		writeJSONNode(buf, n.Alias, indent)
+	default:
+		var v any
+		n.Decode(&v)
+		data, err := json.Marshal(v)
+		if err != nil {
+			data, _ = json.Marshal(n.Value)
+		}
+		buf.Write(data)
+	}
+}
+
+// Apply возвращает копию API с применёнными правками; исходная модель не меняется.
+// Ключи, не совпавшие ни с одной операцией, возвращаются отдельно, чтобы сообщить об опечатках
+func (o *Overrides) Apply(api *API) (*API, []string) {
+	keys := slices.Sorted(maps.Keys(o.Operations))
+	used := make(map[string]bool)
+	endpoints := slices.Clone(api.Endpoints)
+	for i, ep := range endpoints {
+		for _, key := range keys {
+			if !matchesOverride(ep, key) {
+				continue
+			}
+			used[key] = true
+			endpoints[i] = o.Operations[key].apply(endpoints[i])
+		}
+	}
+
+	var unused []string
+	for _, key := range keys {
+		if !used[key] {
+			unused = append(unused, key)
+		}
+	}
+
+	copied := *api
+	copied.Endpoints = endpoints
+	return &copied, unused
+}
+
+// Examples возвращает примеры из правок операции; nil — правок примеров нет.
+// Примеры не входят в модель API: их подставляет генератор
+func (o *Overrides) Examples(ep Endpoint) *ExampleOverrides {
+	var merged *ExampleOverrides
+	for _, key := range slices.Sorted(maps.Keys(o.Operations)) {
+		examples := o.Operations[key].Examples
+		if examples == nil || !matchesOverride(ep, key) {
+			continue
+		}
+		if merged == nil {
+			merged = &ExampleOverrides{Responses: make(map[string]any)}
+		}
+		if examples.Request != nil {
+			merged.Request = examples.Request
+		}
+		maps.Copy(merged.Responses, examples.Responses)
+	}
+	return merged
+}
+
+// apply применяет текстовые правки к операции
+func (o OperationOverride) apply(ep Endpoint) Endpoint {
+	if o.Summary != "" {
+		ep.Summary = o.Summary
+	}
+	if o.Description != "" {
+		ep.Description = o.Description
+	}
+	if o.AppendDescription != "" {
+		if strings.TrimSpace(ep.Description) == "" {
+			ep.Description = o.AppendDescription
+		} else {
+			ep.Description = strings.TrimRight(ep.Description, "\n") + "\n\n" + o.AppendDescription
+		}
+	}
+	if len(o.Notes) > 0 {
+		ep.Notes = append(slices.Clip(ep.Notes), o.Notes...)
+	}
+	return ep
+}
+
+// matchesOverride сообщает, что ключ правки — operationId операции или "METHOD /path" (метод без учёта регистра)
+func matchesOverride(ep Endpoint, key string) bool {
+	return (ep.OperationID != "" && ep.OperationID == key) || strings.EqualFold(ep.Method+" "+ep.Path, key)
+}
@@ -0,0 +1,528 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// postmanCollection — коллекция Postman v2.1 (только используемые поля)
+type postmanCollection struct {
+	Info struct {
+		Name        string      `json:"name"`
+		Description postmanText `json:"description"`
+		Schema      string      `json:"schema"`
+	} `json:"info"`
+	Item     []postmanItem     `json:"item"`
+	Auth     *postmanAuth      `json:"auth"`
+	Variable []postmanKeyValue `json:"variable"`
+}
+
+// postmanItem — запрос или папка (если есть вложенные item)
+type postmanItem struct {
+	Name        string            `json:"name"`
+	Description postmanText       `json:"description"`
+	Item        []postmanItem     `json:"item"`
+	Auth        *postmanAuth      `json:"auth"`
+	Request     *postmanRequest   `json:"request"`
+	Response    []postmanResponse `json:"response"`
+}
+
+type postmanRequest struct {
+	Method      string            `json:"method"`
+	Description postmanText       `json:"description"`
+	Header      []postmanKeyValue `json:"header"`
+	URL         postmanURL        `json:"url"`
+	Body        *postmanBody      `json:"body"`
+	Auth        *postmanAuth      `json:"auth"`
+}
+
+type postmanResponse struct {
+	Name   string            `json:"name"`
+	Code   int               `json:"code"`
+	Status string            `json:"status"`
+	Header []postmanKeyValue `json:"header"`
+	Body   string            `json:"body"`
+}
+
+type postmanBody struct {
+	Mode       string            `json:"mode"` // raw, urlencoded, formdata
+	Raw        string            `json:"raw"`
+	URLEncoded []postmanKeyValue `json:"urlencoded"`
+	FormData   []postmanKeyValue `json:"formdata"`
+}
+
+// postmanAuth — настройки аутентификации; параметры каждого типа лежат в поле с именем типа
+type postmanAuth struct {
+	Type   string            `json:"type"` // bearer, basic, apikey, noauth, ...
+	APIKey []postmanKeyValue `json:"apikey"`
+}
+
+type postmanKeyValue struct {
+	Key         string      `json:"key"`
+	Value       any         `json:"value"`
+	Description postmanText `json:"description"`
+	Disabled    bool        `json:"disabled"`
+}
+
+// postmanURL — URL запроса: строка или разобранный объект
+type postmanURL struct {
+	Raw      string            `json:"raw"`
+	Host     []string          `json:"host"`
+	Path     []string          `json:"path"`
+	Query    []postmanKeyValue `json:"query"`
+	Variable []postmanKeyValue `json:"variable"`
+}
+
+func (u *postmanURL) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &u.Raw)
+	}
+	type plain postmanURL
+	return json.Unmarshal(data, (*plain)(u))
+}
+
+// postmanText — описание: строка или объект {content, type}
+type postmanText string
+
+func (t *postmanText) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*t = postmanText(s)
+		return nil
+	}
+	var obj struct {
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*t = postmanText(obj.Content)
+	return nil
+}
+
+// isPostmanCollection сообщает, что документ — коллекция Postman, а не OpenAPI
+func isPostmanCollection(data []byte) bool {
+	if !bytes.Contains(data, []byte("postman.com")) {
+		return false
+	}
+	var probe struct {
+		Info struct {
+			Schema string `json:"schema"`
+		} `json:"info"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+	return strings.Contains(probe.Info.Schema, "schema.getpostman.com") ||
+		strings.Contains(probe.Info.Schema, "schema.postman.com")
+}
+
+// postmanVariable совпадает с переменными Postman: {{baseUrl}}
+var postmanVariable = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// postmanHostVariable совпадает с переменной в начале URL: {{baseUrl}}/users
+var postmanHostVariable = regexp.MustCompile(`^\{\{[^{}]+\}\}`)
+
+// postmanConverter переводит коллекцию Postman в модель API
+type postmanConverter struct {
+	api       *API
+	variables map[string]string
+	schemes   map[string]SecurityScheme
+}
+
+// parsePostman конвертирует коллекцию Postman v2.1: папки становятся тегами,
+// запросы — эндпоинтами, настройки auth — схемами аутентификации
+func parsePostman(data []byte) (*API, error) {
+	var collection postmanCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, fmt.Errorf("invalid Postman collection: %w", err)
+	}
+
+	c := &postmanConverter{
+		api: &API{
+			Title:       collection.Info.Name,
+			Description: string(collection.Info.Description),
+		},
+		variables: make(map[string]string),
+		schemes:   make(map[string]SecurityScheme),
+	}
+	for _, v := range collection.Variable {
+		c.variables[v.Key] = valueString(v.Value)
+	}
+
+	c.convertItems(collection.Item, "", collection.Auth, "")
+
+	names := make([]string, 0, len(c.schemes))
+	for name := range c.schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c.api.SecuritySchemes = append(c.api.SecuritySchemes, c.schemes[name])
+	}
+
+	if c.api.BaseURL != "" {
+		c.api.Servers = []Server{{URL: c.api.BaseURL}}
+	}
+	return c.api, nil
+}
+
+// convertItems обходит элементы коллекции; auth наследуется от папки, если не задан у запроса
+func (c *postmanConverter) convertItems(items []postmanItem, folder string, auth *postmanAuth, pointer string) {
+	for i, item := range items {
+		itemPointer := pointer + "/item/" + strconv.Itoa(i)
+		itemAuth := auth
+		if item.Auth != nil {
+			itemAuth = item.Auth
+		}
+
+		if item.Request == nil {
+			// Папка — тег с описанием папки
+			c.api.Tags = append(c.api.Tags, Tag{Name: item.Name, Description: string(item.Description)})
+			c.convertItems(item.Item, item.Name, itemAuth, itemPointer)
+			continue
+		}
+
+		ep := c.convertRequest(item, itemAuth)
+		ep.Location = Location{Pointer: itemPointer}
+		if folder != "" {
+			ep.Tags = []string{folder}
+		}
+		c.api.Endpoints = append(c.api.Endpoints, ep)
+	}
+}
+
+// convertRequest переводит запрос Postman в эндпоинт
+func (c *postmanConverter) convertRequest(item postmanItem, auth *postmanAuth) Endpoint {
+	req := item.Request
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = "GET"
+	}
+
+	desc := string(req.Description)
+	if desc == "" {
+		desc = string(item.Description)
+	}
+
+	ep := Endpoint{
+		Method:      method,
+		Summary:     item.Name,
+		Description: desc,
+		Responses:   make(map[string]Response),
+	}
+
+	base, path, query := c.splitURL(req.URL)
+	if c.api.BaseURL == "" {
+		c.api.BaseURL = base
+	}
+	ep.Path = path
+
+	// Параметры пути: :id в Postman → {id}
+	pathVars := make(map[string]postmanKeyValue)
+	for _, v := range req.URL.Variable {
+		pathVars[v.Key] = v
+	}
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if !strings.HasPrefix(segment, "{") {
+			continue
+		}
+		name := strings.Trim(segment, "{}")
+		v := pathVars[name]
+		ep.Parameters = append(ep.Parameters, Parameter{
+			Name:        name,
+			In:          "path",
+			Required:    true,
+			Type:        "string",
+			Description: string(v.Description),
+			Example:     exampleValue(v.Value),
+		})
+	}
+
+	for _, q := range query {
+		if q.Disabled {
+			continue
+		}
+		ep.Parameters = append(ep.Parameters, Parameter{
+			Name:        q.Key,
+			In:          "query",
+			Type:        "string",
+			Description: string(q.Description),
+			Example:     exampleValue(q.Value),
+		})
+	}
+
+	contentType := ""
+	for _, h := range req.Header {
+		if h.Disabled {
+			continue
+		}
+		switch strings.ToLower(h.Key) {
+		case "content-type":
+			contentType = valueString(h.Value)
+		case "authorization":
+			// Аутентификация описывается через схемы
+		default:
+			ep.Parameters = append(ep.Parameters, Parameter{
+				Name:        h.Key,
+				In:          "header",
+				Type:        "string",
+				Description: string(h.Description),
+				Example:     exampleValue(h.Value),
+			})
+		}
+	}
+
+	if req.Body != nil {
+		ep.RequestBody = convertPostmanBody(req.Body, contentType)
+	}
+
+	for _, resp := range item.Response {
+		code := strconv.Itoa(resp.Code)
+		if resp.Code == 0 {
+			code = "default"
+		}
+		if _, exists := ep.Responses[code]; exists {
+			continue
+		}
+		ep.Responses[code] = convertPostmanResponse(resp)
+	}
+
+	if req.Auth != nil {
+		auth = req.Auth
+	}
+	if scheme, ok := c.securityScheme(auth); ok {
+		c.schemes[scheme.Name] = scheme
+		ep.Security = []SecurityRequirement{{scheme.Name: []string{}}}
+	}
+
+	return ep
+}
+
+// splitURL разбирает URL запроса на базовый URL, путь в нотации OpenAPI и query-параметры
+func (c *postmanConverter) splitURL(u postmanURL) (string, string, []postmanKeyValue) {
+	query := u.Query
+	if len(u.Host) == 0 && len(u.Path) == 0 {
+		// URL задан строкой: {{baseUrl}}/orders/:id?x=1 или полный URL
+		raw, rawQuery, _ := strings.Cut(u.Raw, "?")
+		if len(query) == 0 {
+			values, _ := url.ParseQuery(rawQuery)
+			for key, vs := range values {
+				for _, v := range vs {
+					query = append(query, postmanKeyValue{Key: key, Value: v})
+				}
+			}
+			sort.Slice(query, func(i, j int) bool { return query[i].Key < query[j].Key })
+		}
+
+		if loc := postmanHostVariable.FindStringIndex(raw); loc != nil {
+			// Переменная хоста может содержать и путь, поэтому он остаётся в базовом URL
+			return c.baseURL(raw[:loc[1]]), postmanPath(strings.Split(raw[loc[1]:], "/")), query
+		}
+		if !strings.Contains(raw, "://") {
+			raw = "https://" + raw
+		}
+		parsed, err := url.Parse(c.resolve(raw))
+		if err != nil {
+			return "", "/", query
+		}
+		return parsed.Scheme + "://" + parsed.Host, postmanPath(strings.Split(parsed.Path, "/")), query
+	}
+
+	// Переменная хоста может содержать и путь: {{baseUrl}} = https://api.example.com/v1
+	return c.baseURL(strings.Join(u.Host, ".")), postmanPath(u.Path), query
+}
+
+// baseURL подставляет переменные в хост и дополняет его схемой
+func (c *postmanConverter) baseURL(host string) string {
+	base := c.resolve(host)
+	if base != "" && !strings.Contains(base, "://") {
+		base = "https://" + base
+	}
+	return strings.TrimSuffix(base, "/")
+}
+
+// postmanPath собирает путь OpenAPI из сегментов Postman: :id и {{id}} → {id}
+func postmanPath(segments []string) string {
+	var parts []string
+	for _, segment := range segments {
+		switch {
+		case segment == "":
+			continue
+		case strings.HasPrefix(segment, ":"):
+			segment = "{" + strings.TrimPrefix(segment, ":") + "}"
+		default:
+			segment = postmanVariable.ReplaceAllString(segment, "{$1}")
+		}
+		parts = append(parts, segment)
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// resolve подставляет значения переменных коллекции
+func (c *postmanConverter) resolve(s string) string {
+	return postmanVariable.ReplaceAllStringFunc(s, func(m string) string {
+		name := postmanVariable.FindStringSubmatch(m)[1]
+		if v, ok := c.variables[name]; ok && v != "" {
+			return v
+		}
+		return m
+	})
+}
+
+// securityScheme переводит auth Postman в схему аутентификации
+func (c *postmanConverter) securityScheme(auth *postmanAuth) (SecurityScheme, bool) {
+	if auth == nil {
+		return SecurityScheme{}, false
+	}
+	switch auth.Type {
+	case "bearer":
+		return SecurityScheme{Name: "bearerAuth", Type: "http", Scheme: "bearer"}, true
+	case "basic":
+		return SecurityScheme{Name: "basicAuth", Type: "http", Scheme: "basic"}, true
+	case "oauth2":
+		return SecurityScheme{Name: "oauth2", Type: "oauth2"}, true
+	case "apikey":
+		scheme := SecurityScheme{Name: "apiKeyAuth", Type: "apiKey", In: "header", ParamName: "X-API-Key"}
+		for _, kv := range auth.APIKey {
+			switch kv.Key {
+			case "key":
+				scheme.ParamName = valueString(kv.Value)
+			case "in":
+				scheme.In = valueString(kv.Value)
+			}
+		}
+		return scheme, true
+	}
+	return SecurityScheme{}, false
+}
+
+// convertPostmanBody переводит тело запроса; схема выводится из примера
+func convertPostmanBody(body *postmanBody, contentType string) *RequestBody {
+	var media MediaType
+	switch body.Mode {
+	case "raw":
+		if body.Raw == "" {
+			return nil
+		}
+		var value any
+		if err := json.Unmarshal([]byte(body.Raw), &value); err == nil {
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			media = MediaType{Schema: inferSchema(value), Example: value}
+		} else {
+			if contentType == "" {
+				contentType = "text/plain"
+			}
+			media = MediaType{Schema: &Schema{Type: "string"}, Example: body.Raw}
+		}
+	case "urlencoded", "formdata":
+		fields := body.URLEncoded
+		contentType = "application/x-www-form-urlencoded"
+		if body.Mode == "formdata" {
+			fields = body.FormData
+			contentType = "multipart/form-data"
+		}
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		for _, f := range fields {
+			if f.Disabled {
+				continue
+			}
+			schema.Properties[f.Key] = &Schema{Type: "string", Description: string(f.Description), Example: exampleValue(f.Value)}
+		}
+		media = MediaType{Schema: schema}
+	default:
+		return nil
+	}
+
+	return &RequestBody{Content: map[string]MediaType{contentType: media}}
+}
+
+// convertPostmanResponse переводит сохранённый пример ответа
+func convertPostmanResponse(resp postmanResponse) Response {
+	desc := resp.Name
+	if desc == "" {
+		desc = resp.Status
+	}
+	response := Response{Description: desc}
+	if resp.Body == "" {
+		return response
+	}
+
+	contentType := ""
+	for _, h := range resp.Header {
+		if strings.EqualFold(h.Key, "Content-Type") {
+			contentType = strings.TrimSpace(strings.Split(valueString(h.Value), ";")[0])
+		}
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(resp.Body), &value); err == nil {
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		response.Content = map[string]MediaType{contentType: {Schema: inferSchema(value), Example: value}}
+	} else {
+		if contentType == "" {
+			contentType = "text/plain"
+		}
+		response.Content = map[string]MediaType{contentType: {Schema: &Schema{Type: "string"}, Example: resp.Body}}
+	}
+	return response
+}
+
+// inferSchema выводит схему из JSON-примера; значения листьев становятся примерами
+func inferSchema(value any) *Schema {
+	switch v := value.(type) {
+	case map[string]any:
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema, len(v))}
+		for name, prop := range v {
+			schema.Properties[name] = inferSchema(prop)
+		}
+		return schema
+	case []any:
+		schema := &Schema{Type: "array"}
+		if len(v) > 0 {
+			schema.Items = inferSchema(v[0])
+		}
+		return schema
+	case string:
+		return &Schema{Type: "string", Example: v}
+	case float64:
+		if v == float64(int64(v)) {
+			return &Schema{Type: "integer", Example: int64(v)}
+		}
+		return &Schema{Type: "number", Example: v}
+	case bool:
+		return &Schema{Type: "boolean", Example: v}
+	default:
+		return &Schema{}
+	}
+}
+
+// valueString приводит значение Postman к строке
+func valueString(v any) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// exampleValue возвращает пример параметра; переменные Postman ({{token}}) примером не считаются
+func exampleValue(v any) any {
+	s := valueString(v)
+	if s == "" || postmanVariable.MatchString(s) {
+		return nil
+	}
+	return s
+}
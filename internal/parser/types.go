@@ -1,86 +1,263 @@
 package parser
 
+import (
+	"fmt"
+	"strings"
+)
+
 // API представляет распарсенную OpenAPI спецификацию
 type API struct {
-	Title           string
-	Description     string
-	Version         string
-	BaseURL         string
-	Tags            []Tag
-	Endpoints       []Endpoint
-	SecuritySchemes []SecurityScheme
+	Title           string             `json:"title"`
+	Description     string             `json:"description,omitempty"`
+	Version         string             `json:"version,omitempty"`
+	BaseURL         string             `json:"baseUrl,omitempty"`
+	Servers         []Server           `json:"servers,omitempty"`
+	Tags            []Tag              `json:"tags,omitempty"`
+	Endpoints       []Endpoint         `json:"endpoints,omitempty"`
+	SecuritySchemes []SecurityScheme   `json:"securitySchemes,omitempty"`
+	ExternalDocs    *ExternalDocs      `json:"externalDocs,omitempty"`
+	Contact         *Contact           `json:"contact,omitempty"`
+	License         *License           `json:"license,omitempty"`
+	TermsOfService  string             `json:"termsOfService,omitempty"`
+	Extensions      map[string]any     `json:"extensions,omitempty"` // x-* расширения корня документа
+	Workflows       []Workflow         `json:"workflows,omitempty"`  // сценарии из x-workflows
+	Schemas         map[string]*Schema `json:"schemas,omitempty"`    // именованные схемы components.schemas
+	Warnings        []Warning          `json:"-"`                    // пропущенное при разборе с BestEffort
+}
+
+// ExternalDocs представляет ссылку на внешнюю документацию
+type ExternalDocs struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// Contact — контакты поддержки API (info.contact)
+type Contact struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// License — лицензия API (info.license)
+type License struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Server представляет сервер из секции servers
+type Server struct {
+	URL         string                    `json:"url,omitempty"`
+	Description string                    `json:"description,omitempty"`
+	Variables   map[string]ServerVariable `json:"variables,omitempty"`
+}
+
+// ServerVariable представляет переменную в URL сервера
+type ServerVariable struct {
+	Default     string   `json:"default,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// ResolvedURL возвращает URL сервера с подставленными значениями переменных
+func (s Server) ResolvedURL() string {
+	url := s.URL
+	for name, v := range s.Variables {
+		url = strings.ReplaceAll(url, "{"+name+"}", v.Value())
+	}
+	return url
+}
+
+// Value возвращает значение переменной для подстановки: default, иначе первое из enum
+func (v ServerVariable) Value() string {
+	if v.Default == "" && len(v.Enum) > 0 {
+		return v.Enum[0]
+	}
+	return v.Default
 }
 
 // SecurityScheme представляет схему аутентификации
 type SecurityScheme struct {
-	Name        string
-	Type        string // apiKey, http, oauth2, openIdConnect
-	Description string
-	In          string // header, query, cookie (для apiKey)
-	ParamName   string // имя параметра (для apiKey)
-	Scheme      string // bearer, basic (для http)
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"` // apiKey, http, oauth2, openIdConnect
+	Description string `json:"description,omitempty"`
+	In          string `json:"in,omitempty"`        // header, query, cookie (для apiKey)
+	ParamName   string `json:"paramName,omitempty"` // имя параметра (для apiKey)
+	Scheme      string `json:"scheme,omitempty"`    // bearer, basic (для http)
+
+	Flows            []OAuthFlow `json:"flows,omitempty"`            // потоки OAuth 2.0 (для oauth2)
+	OpenIDConnectURL string      `json:"openIdConnectUrl,omitempty"` // discovery-документ (для openIdConnect)
+}
+
+// OAuthFlow — поток получения токена OAuth 2.0
+type OAuthFlow struct {
+	Type             string            `json:"type"` // authorizationCode, clientCredentials, password, implicit
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes,omitempty"` // scope → описание
 }
 
+// SecurityRequirement — набор схем, требуемых одновременно: имя схемы → scopes
+type SecurityRequirement map[string][]string
+
 // Tag представляет группу эндпоинтов
 type Tag struct {
-	Name        string
-	Description string
+	Name         string         `json:"name"`
+	Description  string         `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs  `json:"externalDocs,omitempty"`
+	Extensions   map[string]any `json:"extensions,omitempty"` // x-* расширения тега
 }
 
 // Endpoint представляет один API эндпоинт
 type Endpoint struct {
-	Method      string // GET, POST, PUT, DELETE, PATCH
-	Path        string
-	Summary     string
-	Description string
-	Tags        []string
-	Parameters  []Parameter
-	RequestBody *RequestBody
-	Responses   map[string]Response
-	Deprecated  bool
+	Method       string                `json:"method"` // GET, POST, PUT, DELETE, PATCH
+	Path         string                `json:"path"`
+	OperationID  string                `json:"operationId,omitempty"`
+	Summary      string                `json:"summary,omitempty"`
+	Description  string                `json:"description,omitempty"`
+	Tags         []string              `json:"tags,omitempty"`
+	Parameters   []Parameter           `json:"parameters,omitempty"`
+	RequestBody  *RequestBody          `json:"requestBody,omitempty"`
+	Responses    map[string]Response   `json:"responses,omitempty"`
+	Deprecated   bool                  `json:"deprecated,omitempty"`
+	Security     []SecurityRequirement `json:"security,omitempty"` // альтернативы (OR); пусто — аутентификация не требуется
+	ExternalDocs *ExternalDocs         `json:"externalDocs,omitempty"`
+	WebSocket    *WebSocket            `json:"websocket,omitempty"`  // операция открывает соединение WebSocket
+	Notes        []string              `json:"notes,omitempty"`      // примечания из файла overrides
+	Extensions   map[string]any        `json:"extensions,omitempty"` // x-* расширения операции
+	Location     Location              `json:"location,omitzero"`    // место операции в исходном документе
+}
+
+// Location указывает на элемент исходного документа: JSON Pointer и, если известны, строка и колонка
+type Location struct {
+	Pointer string `json:"pointer"` // например /paths/~1users/get
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// String форматирует место как line:column, а без позиции — как JSON Pointer
+func (l Location) String() string {
+	if l.Line > 0 {
+		return fmt.Sprintf("%d:%d", l.Line, l.Column)
+	}
+	return "#" + l.Pointer
 }
 
 // Parameter представляет параметр запроса
 type Parameter struct {
-	Name        string
-	In          string // query, path, header, cookie
-	Description string
-	Required    bool
-	Type        string
-	Format      string
-	Enum        []string
-	Default     any
-	Example     any
+	Name        string   `json:"name"`
+	In          string   `json:"in,omitempty"` // query, path, header, cookie
+	Description string   `json:"description,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Format      string   `json:"format,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	Default     any      `json:"default,omitempty"`
+	// Значение enum → смысл из x-enum-descriptions / x-enumNames
+	EnumDescriptions map[string]string `json:"enumDescriptions,omitempty"`
+	Example          any               `json:"example,omitempty"`
+	Location         Location          `json:"location,omitzero"`
+
+	// Сериализация массивов и объектов: style (form, spaceDelimited, pipeDelimited, deepObject,
+	// simple, label, matrix) и explode; пустой style и nil explode — значения по умолчанию для in
+	Style      string   `json:"style,omitempty"`
+	Explode    *bool    `json:"explode,omitempty"`
+	ItemType   string   `json:"itemType,omitempty"`   // тип элементов массива
+	Properties []string `json:"properties,omitempty"` // поля объекта по алфавиту
 }
 
 // RequestBody представляет тело запроса
 type RequestBody struct {
-	Description string
-	Required    bool
-	Content     map[string]MediaType // application/json, etc.
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"` // application/json, etc.
 }
 
 // MediaType представляет тип контента
 type MediaType struct {
-	Schema  *Schema
-	Example any
+	Schema  *Schema `json:"schema,omitempty"`
+	Example any     `json:"example,omitempty"`
 }
 
 // Response представляет ответ API
 type Response struct {
-	Description string
-	Content     map[string]MediaType
+	Description string               `json:"description,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Links       []Link               `json:"links,omitempty"` // операции, которые можно вызвать с данными ответа
+}
+
+// Link — связь ответа со следующей операцией (links из OpenAPI)
+type Link struct {
+	Name         string            `json:"name"`
+	OperationID  string            `json:"operationId,omitempty"`
+	OperationRef string            `json:"operationRef,omitempty"` // JSON Pointer: #/paths/~1users~1{id}/get
+	Description  string            `json:"description,omitempty"`
+	Parameters   map[string]string `json:"parameters,omitempty"` // параметр → выражение ($response.body#/id) или значение
+	RequestBody  string            `json:"requestBody,omitempty"`
+}
+
+// Workflow — сценарий из нескольких операций (Arazzo или расширение x-workflows)
+type Workflow struct {
+	ID          string            `json:"workflowId"`
+	Summary     string            `json:"summary,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Inputs      []string          `json:"inputs,omitempty"` // имена входных данных сценария
+	Steps       []WorkflowStep    `json:"steps"`
+	Outputs     map[string]string `json:"outputs,omitempty"` // результат → выражение ($steps.get.outputs.id)
 }
 
-// Schema представляет JSON Schema
+// WorkflowStep — шаг сценария: вызов операции или вложенного сценария
+type WorkflowStep struct {
+	ID              string            `json:"stepId"`
+	Description     string            `json:"description,omitempty"`
+	OperationID     string            `json:"operationId,omitempty"`
+	OperationPath   string            `json:"operationPath,omitempty"` // JSON Pointer: #/paths/~1users/post
+	WorkflowID      string            `json:"workflowId,omitempty"`
+	Parameters      map[string]string `json:"parameters,omitempty"` // параметр → выражение ($inputs.name) или значение
+	RequestBody     string            `json:"requestBody,omitempty"`
+	SuccessCriteria []string          `json:"successCriteria,omitempty"` // условия: $statusCode == 200
+	OnSuccess       []WorkflowAction  `json:"onSuccess,omitempty"`
+	OnFailure       []WorkflowAction  `json:"onFailure,omitempty"`
+	Outputs         map[string]string `json:"outputs,omitempty"`
+}
+
+// WorkflowAction — переход после шага: end, goto или retry
+type WorkflowAction struct {
+	Type       string  `json:"type"`
+	StepID     string  `json:"stepId,omitempty"`
+	WorkflowID string  `json:"workflowId,omitempty"`
+	RetryAfter float64 `json:"retryAfter,omitempty"` // секунды
+	RetryLimit int     `json:"retryLimit,omitempty"`
+}
+
+// Header представляет заголовок ответа
+type Header struct {
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+}
+
+// Schema представляет JSON Schema. Граф схем ациклический: рекурсивные $ref обрезаются при разборе
 type Schema struct {
-	Type        string
-	Format      string
-	Description string
-	Properties  map[string]*Schema
-	Items       *Schema // для массивов
-	Required    []string
-	Enum        []string
-	Example     any
-	Ref         string // ссылка на компонент
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Items       *Schema            `json:"items,omitempty"` // для массивов
+	Required    []string           `json:"required,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	Default     any                `json:"default,omitempty"`
+	Example     any                `json:"example,omitempty"`
+	Ref         string             `json:"ref,omitempty"` // ссылка на компонент
+
+	// Значение enum → смысл из x-enum-descriptions / x-enumNames
+	EnumDescriptions map[string]string `json:"enumDescriptions,omitempty"`
+}
+
+// RefName возвращает имя компонента из ссылки: #/components/schemas/Error → Error
+func (s *Schema) RefName() string {
+	if s == nil || s.Ref == "" {
+		return ""
+	}
+	return s.Ref[strings.LastIndex(s.Ref, "/")+1:]
 }
@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// WebSocket описывает операцию, которая переключает соединение на WebSocket
+type WebSocket struct {
+	URL          string             `json:"url,omitempty"` // ws(s):// адрес; пусто — адрес операции
+	Subprotocols []string           `json:"subprotocols,omitempty"`
+	Description  string             `json:"description,omitempty"`
+	Send         []WebSocketMessage `json:"send,omitempty"`     // сообщения клиента серверу
+	Receive      []WebSocketMessage `json:"receive,omitempty"`  // сообщения сервера клиенту
+	AsyncAPI     string             `json:"asyncapi,omitempty"` // ссылка на документ AsyncAPI с описанием канала
+}
+
+// WebSocketMessage — сообщение WebSocket со схемой содержимого
+type WebSocketMessage struct {
+	Name        string  `json:"name,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// rawWebSocket — значение расширения x-websocket
+type rawWebSocket struct {
+	URL          string       `json:"url"`
+	Subprotocols []string     `json:"subprotocols"`
+	Description  string       `json:"description"`
+	Send         []rawMessage `json:"send"`
+	Receive      []rawMessage `json:"receive"`
+	AsyncAPI     string       `json:"asyncapi"`
+}
+
+type rawMessage struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Schema      json.RawMessage `json:"schema"`
+}
+
+// convertWebSocket читает описание WebSocket операции: расширение x-websocket (true или объект
+// с url, subprotocols, description, send, receive и asyncapi), x-asyncapi со ссылкой на канал
+// AsyncAPI или ответ 101 Switching Protocols. nil — операция обычная HTTP
+func convertWebSocket(op *openapi3.Operation, components *openapi3.Components) *WebSocket {
+	ws := &WebSocket{}
+	switch v := op.Extensions["x-websocket"].(type) {
+	case bool:
+		if !v {
+			return nil
+		}
+	case map[string]any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			break
+		}
+		var raw rawWebSocket
+		if err := json.Unmarshal(data, &raw); err != nil {
+			break
+		}
+		ws.URL = raw.URL
+		ws.Subprotocols = raw.Subprotocols
+		ws.Description = raw.Description
+		ws.AsyncAPI = raw.AsyncAPI
+		ws.Send = convertMessages(raw.Send, components)
+		ws.Receive = convertMessages(raw.Receive, components)
+	default:
+		asyncAPI, _ := op.Extensions["x-asyncapi"].(string)
+		if asyncAPI == "" && (op.Responses == nil || op.Responses.Value("101") == nil) {
+			return nil
+		}
+	}
+	if asyncAPI, ok := op.Extensions["x-asyncapi"].(string); ok && ws.AsyncAPI == "" {
+		ws.AsyncAPI = strings.TrimSpace(asyncAPI)
+	}
+	return ws
+}
+
+// convertMessages конвертирует сообщения x-websocket. Схема задаётся на месте или ссылкой
+// #/components/schemas/Name; вложенные $ref внутри схемы на месте не разрешаются
+func convertMessages(raw []rawMessage, components *openapi3.Components) []WebSocketMessage {
+	var messages []WebSocketMessage
+	for _, m := range raw {
+		msg := WebSocketMessage{Name: m.Name, Description: m.Description}
+		if len(m.Schema) > 0 {
+			msg.Schema = messageSchema(m.Schema, components)
+		}
+		messages = append(messages, msg)
+	}
+	return messages
+}
+
+// messageSchema конвертирует схему сообщения, разрешая ссылку на компонент
+func messageSchema(data json.RawMessage, components *openapi3.Components) *Schema {
+	var ref struct {
+		Ref string `json:"$ref"`
+	}
+	if json.Unmarshal(data, &ref) == nil && ref.Ref != "" {
+		name, ok := strings.CutPrefix(ref.Ref, "#/components/schemas/")
+		if !ok || components == nil || components.Schemas[name] == nil || components.Schemas[name].Value == nil {
+			return &Schema{Ref: ref.Ref}
+		}
+		schema := convertSchemaRef(components.Schemas[name])
+		schema.Ref = ref.Ref
+		return schema
+	}
+	var schema openapi3.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil
+	}
+	return convertSchema(&schema)
+}
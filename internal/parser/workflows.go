@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// arazzoDocument — документ Arazzo (OpenAPI Workflows), только используемые поля
+type arazzoDocument struct {
+	Arazzo    string           `yaml:"arazzo"`
+	Workflows []arazzoWorkflow `yaml:"workflows"`
+}
+
+type arazzoWorkflow struct {
+	WorkflowID  string `yaml:"workflowId"`
+	Summary     string `yaml:"summary"`
+	Description string `yaml:"description"`
+	Inputs      struct {
+		Properties map[string]any `yaml:"properties"`
+	} `yaml:"inputs"`
+	Steps   []arazzoStep   `yaml:"steps"`
+	Outputs map[string]any `yaml:"outputs"`
+}
+
+type arazzoStep struct {
+	StepID        string `yaml:"stepId"`
+	Description   string `yaml:"description"`
+	OperationID   string `yaml:"operationId"`
+	OperationPath string `yaml:"operationPath"`
+	WorkflowID    string `yaml:"workflowId"`
+	Parameters    []struct {
+		Name  string `yaml:"name"`
+		In    string `yaml:"in"`
+		Value any    `yaml:"value"`
+	} `yaml:"parameters"`
+	RequestBody *struct {
+		Payload any `yaml:"payload"`
+	} `yaml:"requestBody"`
+	SuccessCriteria []struct {
+		Condition string `yaml:"condition"`
+	} `yaml:"successCriteria"`
+	OnSuccess []arazzoAction `yaml:"onSuccess"`
+	OnFailure []arazzoAction `yaml:"onFailure"`
+	Outputs   map[string]any `yaml:"outputs"`
+}
+
+type arazzoAction struct {
+	Type       string  `yaml:"type"` // end, goto, retry
+	StepID     string  `yaml:"stepId"`
+	WorkflowID string  `yaml:"workflowId"`
+	RetryAfter float64 `yaml:"retryAfter"`
+	RetryLimit int     `yaml:"retryLimit"`
+}
+
+// ParseWorkflowsFile читает сценарии из документа Arazzo (YAML или JSON)
+func ParseWorkflowsFile(path string) ([]Workflow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflows: %w", err)
+	}
+	var doc arazzoDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse workflows %s: %w", path, err)
+	}
+	if doc.Arazzo == "" {
+		return nil, fmt.Errorf("failed to parse workflows %s: not an Arazzo document (no arazzo version)", path)
+	}
+	return convertWorkflows(doc.Workflows), nil
+}
+
+// workflowsFromExtension читает сценарии из расширения x-workflows корня спецификации:
+// список в формате workflows документа Arazzo
+func workflowsFromExtension(ext any) []Workflow {
+	if ext == nil {
+		return nil
+	}
+	// Значение расширения уже разобрано; JSON — подмножество YAML, поэтому переиспользуем структуры Arazzo
+	data, err := json.Marshal(ext)
+	if err != nil {
+		return nil
+	}
+	var workflows []arazzoWorkflow
+	if err := yaml.Unmarshal(data, &workflows); err != nil {
+		return nil
+	}
+	return convertWorkflows(workflows)
+}
+
+func convertWorkflows(raw []arazzoWorkflow) []Workflow {
+	var result []Workflow
+	for _, w := range raw {
+		if w.WorkflowID == "" || len(w.Steps) == 0 {
+			continue
+		}
+		workflow := Workflow{
+			ID:          w.WorkflowID,
+			Summary:     w.Summary,
+			Description: w.Description,
+			Inputs:      slices.Sorted(maps.Keys(w.Inputs.Properties)),
+			Outputs:     expressionMap(w.Outputs),
+		}
+		for _, s := range w.Steps {
+			step := WorkflowStep{
+				ID:            s.StepID,
+				Description:   s.Description,
+				OperationID:   sourceOperationID(s.OperationID),
+				OperationPath: sourceOperationPath(s.OperationPath),
+				WorkflowID:    s.WorkflowID,
+				Outputs:       expressionMap(s.Outputs),
+			}
+			for _, p := range s.Parameters {
+				if step.Parameters == nil {
+					step.Parameters = make(map[string]string)
+				}
+				step.Parameters[p.Name] = linkValue(p.Value)
+			}
+			if s.RequestBody != nil && s.RequestBody.Payload != nil {
+				step.RequestBody = linkValue(s.RequestBody.Payload)
+			}
+			for _, c := range s.SuccessCriteria {
+				if c.Condition != "" {
+					step.SuccessCriteria = append(step.SuccessCriteria, c.Condition)
+				}
+			}
+			step.OnSuccess = convertActions(s.OnSuccess)
+			step.OnFailure = convertActions(s.OnFailure)
+			workflow.Steps = append(workflow.Steps, step)
+		}
+		result = append(result, workflow)
+	}
+	return result
+}
+
+func convertActions(raw []arazzoAction) []WorkflowAction {
+	var actions []WorkflowAction
+	for _, a := range raw {
+		actions = append(actions, WorkflowAction(a))
+	}
+	return actions
+}
+
+// expressionMap переводит значения outputs в строки выражений
+func expressionMap(m map[string]any) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = linkValue(v)
+	}
+	return result
+}
+
+// sourceOperationID убирает префикс источника: $sourceDescriptions.petstore.addPet → addPet
+func sourceOperationID(id string) string {
+	rest, ok := strings.CutPrefix(id, "$sourceDescriptions.")
+	if !ok {
+		return id
+	}
+	_, op, _ := strings.Cut(rest, ".")
+	return op
+}
+
+// sourceOperationPath оставляет от operationPath только JSON Pointer на операцию:
+// {$sourceDescriptions.petstore.url}#/paths/~1pets/get → #/paths/~1pets/get
+func sourceOperationPath(path string) string {
+	if _, pointer, ok := strings.Cut(path, "#"); ok {
+		return "#" + pointer
+	}
+	return path
+}
@@ -0,0 +1,207 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// azureVersion — версия REST API Blob Storage (x-ms-version)
+const azureVersion = "2021-08-06"
+
+// azureStore — контейнер Azure Blob Storage
+type azureStore struct {
+	client  *http.Client
+	base    *url.URL // адрес контейнера: https://account.blob.core.windows.net/container
+	account string
+	prefix  string // префикс имён блобов с / на конце или пусто
+	key     []byte // ключ учётной записи для подписи SharedKey; nil при доступе по SAS
+	sas     url.Values
+	now     func() time.Time // nil — time.Now; задаётся в тестах
+}
+
+// newAzureStore создаёт хранилище az://account/container/prefix. Доступ — ключ учётной записи
+// AZURE_STORAGE_KEY (подпись SharedKey) или SAS-токен AZURE_STORAGE_SAS_TOKEN
+func newAzureStore(account, container, prefix string, opts Options) (*azureStore, error) {
+	s := &azureStore{client: opts.Client, account: account, prefix: keyPrefix(prefix)}
+	switch key, sas := opts.Env("AZURE_STORAGE_KEY"), opts.Env("AZURE_STORAGE_SAS_TOKEN"); {
+	case key != "":
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AZURE_STORAGE_KEY: %w", err)
+		}
+		s.key = decoded
+	case sas != "":
+		values, err := url.ParseQuery(strings.TrimPrefix(sas, "?"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid AZURE_STORAGE_SAS_TOKEN: %w", err)
+		}
+		s.sas = values
+	default:
+		return nil, errors.New("publishing to az:// needs AZURE_STORAGE_KEY or AZURE_STORAGE_SAS_TOKEN")
+	}
+	base, err := url.Parse("https://" + account + ".blob.core.windows.net/" + container)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Azure storage account: %w", err)
+	}
+	s.base = base
+	return s, nil
+}
+
+// enumerationResults — используемые поля ответа List Blobs
+type enumerationResults struct {
+	Blobs []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			ContentMD5 string `xml:"Content-MD5"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+func (s *azureStore) List(ctx context.Context) (map[string]string, error) {
+	blobs := make(map[string]string)
+	marker := ""
+	for {
+		u := *s.base
+		query := url.Values{"restype": {"container"}, "comp": {"list"}, "prefix": {s.prefix}}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+		u.RawQuery = query.Encode()
+		data, err := s.do(ctx, http.MethodGet, &u, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var page enumerationResults
+		if err := xml.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("invalid list response: %w", err)
+		}
+		for _, blob := range page.Blobs {
+			name := strings.TrimPrefix(blob.Name, s.prefix)
+			if name == "" {
+				continue
+			}
+			// Content-MD5 блоба — base64; для сравнения с локальными файлами переводим в hex
+			sum, _ := base64.StdEncoding.DecodeString(blob.Properties.ContentMD5)
+			blobs[name] = hex.EncodeToString(sum)
+		}
+		if page.NextMarker == "" {
+			return blobs, nil
+		}
+		marker = page.NextMarker
+	}
+}
+
+func (s *azureStore) Put(ctx context.Context, key string, data []byte, contentType, cacheControl string) error {
+	sum := md5.Sum(data)
+	header := http.Header{}
+	header.Set("x-ms-blob-type", "BlockBlob")
+	header.Set("x-ms-blob-content-type", contentType)
+	header.Set("x-ms-blob-cache-control", cacheControl)
+	header.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+	_, err := s.do(ctx, http.MethodPut, objectURL(s.base, s.prefix+key), data, header)
+	return err
+}
+
+func (s *azureStore) Delete(ctx context.Context, key string) error {
+	_, err := s.do(ctx, http.MethodDelete, objectURL(s.base, s.prefix+key), nil, nil)
+	return err
+}
+
+// do подписывает и выполняет запрос к Blob Storage
+func (s *azureStore) do(ctx context.Context, method string, u *url.URL, body []byte, header http.Header) ([]byte, error) {
+	if s.sas != nil {
+		query := u.Query()
+		for name, values := range s.sas {
+			query[name] = values
+		}
+		u.RawQuery = query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		req.Body, req.ContentLength = http.NoBody, 0
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	req.Header.Set("x-ms-date", now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureVersion)
+	if s.key != nil {
+		req.Header.Set("Authorization", "SharedKey "+s.account+":"+s.signature(req))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	return checkResponse(resp)
+}
+
+// signature подписывает запрос ключом учётной записи по схеме SharedKey
+func (s *azureStore) signature(req *http.Request) string {
+	length := ""
+	if req.ContentLength > 0 {
+		length = strconv.FormatInt(req.ContentLength, 10)
+	}
+	lines := []string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		length,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date: вместо него x-ms-date
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+	}
+
+	var headers []string
+	for name, values := range req.Header {
+		if name = strings.ToLower(name); strings.HasPrefix(name, "x-ms-") {
+			headers = append(headers, name+":"+strings.TrimSpace(strings.Join(values, ",")))
+		}
+	}
+	slices.Sort(headers)
+
+	resource := "/" + s.account + req.URL.EscapedPath()
+	query := req.URL.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		values := slices.Clone(query[name])
+		slices.Sort(values)
+		resource += "\n" + strings.ToLower(name) + ":" + strings.Join(values, ",")
+	}
+
+	stringToSign := strings.Join(lines, "\n") + "\n" + strings.Join(headers, "\n") + "\n" + resource
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,147 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+)
+
+// DefaultAuthHeader — заголовок авторизации для http(s):// по умолчанию
+const DefaultAuthHeader = "Authorization"
+
+// httpStore выгружает файлы PUT-запросами на портал документации или WebDAV-сервер.
+// Список файлов такие серверы не отдают, поэтому выгружается всё, а устаревшие файлы не удаляются
+type httpStore struct {
+	client     *http.Client
+	base       *url.URL // адрес директории вывода на сервере
+	authHeader string
+	auth       string
+
+	mu          sync.Mutex
+	collections map[string]bool // директории, созданные MKCOL в этом запуске
+}
+
+func newHTTPStore(u *url.URL, opts Options) (*httpStore, error) {
+	// Учётные данные не отправляются открытым текстом
+	if _, hasPassword := u.User.Password(); u.Scheme == "http" && (opts.Auth != "" || hasPassword) {
+		return nil, fmt.Errorf("refusing to send credentials to %s over plain http, use https://", Redacted(u.String()))
+	}
+	base := *u
+	base.Path = strings.TrimSuffix(base.Path, "/")
+	base.RawPath = strings.TrimSuffix(base.RawPath, "/")
+	authHeader := opts.AuthHeader
+	if authHeader == "" {
+		authHeader = DefaultAuthHeader
+	}
+	return &httpStore{client: withoutCrossHostAuth(opts.Client, authHeader), base: &base, authHeader: authHeader, auth: opts.Auth, collections: make(map[string]bool)}, nil
+}
+
+// withoutCrossHostAuth возвращает копию client, которая не передаёт заголовок авторизации
+// при редиректе на другой хост или на другую схему (https → http). net/http сам убирает
+// только Authorization, а произвольный заголовок (X-Api-Key) ушёл бы на чужой сервер
+// или открытым текстом вместе с запросом
+func withoutCrossHostAuth(client *http.Client, authHeader string) *http.Client {
+	c := *client
+	checkRedirect := c.CheckRedirect
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if req.URL.Host != via[0].URL.Host || req.URL.Scheme != via[0].URL.Scheme {
+			req.Header.Del(authHeader)
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		// Ограничение http.Client по умолчанию
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &c
+}
+
+func (s *httpStore) List(context.Context) (map[string]string, error) {
+	return nil, nil
+}
+
+// Put выгружает файл. WebDAV отвечает 409 Conflict, если родительской директории нет:
+// тогда директории создаются MKCOL и запрос повторяется
+func (s *httpStore) Put(ctx context.Context, key string, data []byte, contentType, cacheControl string) error {
+	header := http.Header{}
+	header.Set("Content-Type", contentType)
+	header.Set("Cache-Control", cacheControl)
+	resp, err := s.do(ctx, http.MethodPut, key, data, header)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusConflict && strings.Contains(key, "/") {
+		resp.Body.Close()
+		if err := s.mkcol(ctx, path.Dir(key)); err != nil {
+			return err
+		}
+		if resp, err = s.do(ctx, http.MethodPut, key, data, header); err != nil {
+			return err
+		}
+	}
+	_, err = checkResponse(resp)
+	return err
+}
+
+func (s *httpStore) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	_, err = checkResponse(resp)
+	return err
+}
+
+// mkcol создаёт директорию dir и её родителей; 405 Method Not Allowed — директория уже есть
+func (s *httpStore) mkcol(ctx context.Context, dir string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	segments := strings.Split(dir, "/")
+	for i := range segments {
+		collection := strings.Join(segments[:i+1], "/")
+		if s.collections[collection] {
+			continue
+		}
+		resp, err := s.do(ctx, "MKCOL", collection+"/", nil, nil)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusMethodNotAllowed {
+			if _, err := checkResponse(resp); err != nil {
+				return err
+			}
+		} else {
+			resp.Body.Close()
+		}
+		s.collections[collection] = true
+	}
+	return nil
+}
+
+// do выполняет запрос к файлу key с заголовком авторизации
+func (s *httpStore) do(ctx context.Context, method, key string, body []byte, header http.Header) (*http.Response, error) {
+	u := objectURL(s.base, key)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		req.Body, req.ContentLength = http.NoBody, 0
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if s.auth != "" {
+		req.Header.Set(s.authHeader, s.auth)
+	}
+	return s.client.Do(req)
+}
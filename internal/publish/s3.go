@@ -0,0 +1,273 @@
+package publish
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// emptySHA256 — хеш пустого тела запроса для x-amz-content-sha256
+const emptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// s3Store — хранилище с S3 API: Amazon S3, S3-совместимые (MinIO, R2) и XML API Google Cloud Storage
+type s3Store struct {
+	client *http.Client
+	base   *url.URL // адрес бакета: https://bucket.s3.region.amazonaws.com или https://host/bucket
+	prefix string   // префикс ключей с / на конце или пусто
+	sign   func(req *http.Request, payloadHash string)
+}
+
+// newS3Store создаёт хранилище s3://. Учётные данные — AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY
+// и AWS_SESSION_TOKEN, регион — AWS_REGION или AWS_DEFAULT_REGION. AWS_ENDPOINT_URL_S3 или
+// AWS_ENDPOINT_URL задают S3-совместимый сервер; к нему обращаемся в path-style
+func newS3Store(bucket, prefix string, opts Options) (*s3Store, error) {
+	accessKey, secretKey := opts.Env("AWS_ACCESS_KEY_ID"), opts.Env("AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		return nil, errors.New("publishing to s3:// needs AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	region := cmp.Or(opts.Env("AWS_REGION"), opts.Env("AWS_DEFAULT_REGION"), "us-east-1")
+
+	var base string
+	switch endpoint := cmp.Or(opts.Env("AWS_ENDPOINT_URL_S3"), opts.Env("AWS_ENDPOINT_URL")); {
+	case endpoint != "":
+		base = strings.TrimSuffix(endpoint, "/") + "/" + bucket
+	case strings.Contains(bucket, "."):
+		// Сертификат *.s3.amazonaws.com не покрывает бакеты с точкой в имени
+		base = "https://s3." + region + ".amazonaws.com/" + bucket
+	default:
+		base = "https://" + bucket + ".s3." + region + ".amazonaws.com"
+	}
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint: %w", err)
+	}
+
+	creds := sigV4Credentials{accessKey: accessKey, secretKey: secretKey, sessionToken: opts.Env("AWS_SESSION_TOKEN"), region: region}
+	return &s3Store{client: opts.Client, base: u, prefix: keyPrefix(prefix), sign: creds.sign}, nil
+}
+
+// newGCSStore создаёт хранилище gs:// поверх XML API Cloud Storage, совместимого с S3.
+// Токен OAuth — GOOGLE_OAUTH_ACCESS_TOKEN (например, из gcloud auth print-access-token);
+// STORAGE_EMULATOR_HOST направляет запросы в эмулятор
+func newGCSStore(bucket, prefix string, opts Options) (*s3Store, error) {
+	token := opts.Env("GOOGLE_OAUTH_ACCESS_TOKEN")
+	if token == "" {
+		return nil, errors.New("publishing to gs:// needs GOOGLE_OAUTH_ACCESS_TOKEN (e.g. from gcloud auth print-access-token)")
+	}
+	endpoint := "https://storage.googleapis.com"
+	if emulator := opts.Env("STORAGE_EMULATOR_HOST"); emulator != "" {
+		endpoint = emulator
+		if !strings.Contains(endpoint, "://") {
+			endpoint = "http://" + endpoint
+		}
+	}
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/") + "/" + bucket)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Cloud Storage endpoint: %w", err)
+	}
+	sign := func(req *http.Request, _ string) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return &s3Store{client: opts.Client, base: u, prefix: keyPrefix(prefix), sign: sign}, nil
+}
+
+// listBucketResult — используемые поля ответа ListObjectsV2
+type listBucketResult struct {
+	Contents []struct {
+		Key  string `xml:"Key"`
+		ETag string `xml:"ETag"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *s3Store) List(ctx context.Context) (map[string]string, error) {
+	objects := make(map[string]string)
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {s.prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		u := *s.base
+		u.RawQuery = canonicalQuery(query)
+		data, err := s.do(ctx, http.MethodGet, &u, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var page listBucketResult
+		if err := xml.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("invalid list response: %w", err)
+		}
+		for _, obj := range page.Contents {
+			key := strings.TrimPrefix(obj.Key, s.prefix)
+			if key == "" || strings.HasSuffix(key, "/") {
+				continue
+			}
+			// ETag объекта, загруженного одним PUT, — MD5 содержимого; у составных он с суффиксом -N
+			etag := strings.Trim(obj.ETag, `"`)
+			if len(etag) != 32 {
+				etag = ""
+			}
+			objects[key] = etag
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, data []byte, contentType, cacheControl string) error {
+	sum := md5.Sum(data)
+	header := http.Header{
+		"Content-Type":  {contentType},
+		"Cache-Control": {cacheControl},
+		"Content-Md5":   {base64.StdEncoding.EncodeToString(sum[:])},
+	}
+	_, err := s.do(ctx, http.MethodPut, objectURL(s.base, s.prefix+key), data, header)
+	return err
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	_, err := s.do(ctx, http.MethodDelete, objectURL(s.base, s.prefix+key), nil, nil)
+	return err
+}
+
+// do подписывает и выполняет запрос к хранилищу
+func (s *s3Store) do(ctx context.Context, method string, u *url.URL, body []byte, header http.Header) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		req.Body, req.ContentLength = http.NoBody, 0
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	payloadHash := emptySHA256
+	if len(body) > 0 {
+		sum := sha256.Sum256(body)
+		payloadHash = hex.EncodeToString(sum[:])
+	}
+	s.sign(req, payloadHash)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	return checkResponse(resp)
+}
+
+// sigV4Credentials подписывают запросы к S3 по AWS Signature Version 4
+type sigV4Credentials struct {
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	region       string
+	now          func() time.Time // nil — time.Now; задаётся в тестах
+}
+
+// sign добавляет заголовки x-amz-* и Authorization. Подписываются host и x-amz-*
+func (c sigV4Credentials) sign(req *http.Request, payloadHash string) {
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+	t := now().UTC()
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if c.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		if name = strings.ToLower(name); strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalURI := req.URL.EscapedPath()
+	if canonicalURI == "" {
+		canonicalURI = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + c.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+c.secretKey), date)
+	for _, part := range []string{c.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.accessKey, scope, signedHeaders, signature))
+}
+
+// canonicalQuery кодирует параметры запроса по правилам SigV4: по алфавиту, RFC 3986
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var parts []string
+	for _, name := range names {
+		values := slices.Clone(query[name])
+		slices.Sort(values)
+		for _, v := range values {
+			parts = append(parts, escape(name)+"="+escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// keyPrefix добавляет / к непустому префиксу ключей
+func keyPrefix(prefix string) string {
+	if prefix == "" {
+		return ""
+	}
+	return prefix + "/"
+}
@@ -0,0 +1,292 @@
+// Package specdiff сравнивает две версии модели API и описывает изменения операций
+package specdiff
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+// Виды изменений
+const (
+	KindAdded      = "added"
+	KindChanged    = "changed"
+	KindDeprecated = "deprecated"
+	KindRemoved    = "removed"
+)
+
+// Change — изменение операции между версиями спецификации
+type Change struct {
+	Kind      string `json:"kind"`
+	Operation string `json:"operation"` // METHOD /path
+	Message   string `json:"message"`
+	Breaking  bool   `json:"breaking"` // существующие клиенты могут перестать работать
+}
+
+// HasBreaking сообщает, что среди изменений есть ломающие
+func HasBreaking(changes []Change) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// adder добавляет изменение операции; breaking — изменение ломает существующих клиентов
+type adder func(kind string, breaking bool, format string, args ...any)
+
+// Compare возвращает изменения от old к new, упорядоченные по операции
+func Compare(old, new *parser.API) []Change {
+	var changes []Change
+	add := func(kind, op string, breaking bool, format string, args ...any) {
+		changes = append(changes, Change{Kind: kind, Operation: op, Message: fmt.Sprintf(format, args...), Breaking: breaking})
+	}
+
+	before := operations(old)
+	after := operations(new)
+
+	for key, prev := range before {
+		if _, ok := after[key]; !ok {
+			add(KindRemoved, key, true, "operation removed%s", summarySuffix(prev))
+		}
+	}
+	for key, ep := range after {
+		prev, ok := before[key]
+		if !ok {
+			add(KindAdded, key, false, "operation added%s", summarySuffix(ep))
+			continue
+		}
+		if ep.Deprecated && !prev.Deprecated {
+			add(KindDeprecated, key, false, "operation deprecated")
+		}
+		opAdd := func(kind string, breaking bool, format string, args ...any) {
+			add(kind, key, breaking, format, args...)
+		}
+		compareParameters(prev, ep, opAdd)
+		compareRequestBody(prev, ep, opAdd)
+		compareResponses(prev, ep, opAdd)
+	}
+
+	slices.SortStableFunc(changes, func(a, b Change) int {
+		return cmp.Or(strings.Compare(a.Operation, b.Operation), strings.Compare(a.Kind, b.Kind), strings.Compare(a.Message, b.Message))
+	})
+	return changes
+}
+
+// operations индексирует операции по "METHOD /path"
+func operations(api *parser.API) map[string]parser.Endpoint {
+	result := make(map[string]parser.Endpoint, len(api.Endpoints))
+	for _, ep := range api.Endpoints {
+		result[ep.Method+" "+ep.Path] = ep
+	}
+	return result
+}
+
+func summarySuffix(ep parser.Endpoint) string {
+	if ep.Summary == "" {
+		return ""
+	}
+	return " (" + ep.Summary + ")"
+}
+
+// compareParameters сравнивает параметры операции по имени и расположению
+func compareParameters(prev, ep parser.Endpoint, add adder) {
+	key := func(p parser.Parameter) string { return p.In + ":" + p.Name }
+	before := make(map[string]parser.Parameter)
+	for _, p := range prev.Parameters {
+		before[key(p)] = p
+	}
+	after := make(map[string]parser.Parameter)
+	for _, p := range ep.Parameters {
+		after[key(p)] = p
+	}
+
+	for k, p := range before {
+		if _, ok := after[k]; !ok {
+			add(KindRemoved, true, "%s parameter %q removed", p.In, p.Name)
+		}
+	}
+	for k, p := range after {
+		old, ok := before[k]
+		switch {
+		case !ok && p.Required:
+			add(KindAdded, true, "required %s parameter %q added", p.In, p.Name)
+		case !ok:
+			add(KindAdded, false, "optional %s parameter %q added", p.In, p.Name)
+		default:
+			if p.Required && !old.Required {
+				add(KindChanged, true, "%s parameter %q became required", p.In, p.Name)
+			}
+			if !p.Required && old.Required {
+				add(KindChanged, false, "%s parameter %q became optional", p.In, p.Name)
+			}
+			if p.Type != old.Type && old.Type != "" && p.Type != "" {
+				add(KindChanged, true, "%s parameter %q type changed from %s to %s", p.In, p.Name, old.Type, p.Type)
+			}
+			compareEnum(fmt.Sprintf("%s parameter %q", p.In, p.Name), old.Enum, p.Enum, add)
+		}
+	}
+}
+
+// compareEnum сообщает об удалённых и добавленных значениях перечисления
+func compareEnum(subject string, before, after []string, add adder) {
+	if len(before) == 0 && len(after) == 0 {
+		return
+	}
+	var removed, added []string
+	for _, v := range before {
+		if !slices.Contains(after, v) {
+			removed = append(removed, v)
+		}
+	}
+	for _, v := range after {
+		if !slices.Contains(before, v) {
+			added = append(added, v)
+		}
+	}
+	switch {
+	case len(before) == 0:
+		add(KindChanged, true, "%s restricted to values %s", subject, quoteList(after))
+	case len(after) == 0:
+		add(KindChanged, false, "%s no longer restricted to an enum", subject)
+	default:
+		if len(removed) > 0 {
+			add(KindChanged, true, "%s no longer accepts values %s", subject, quoteList(removed))
+		}
+		if len(added) > 0 {
+			add(KindChanged, false, "%s accepts new values %s", subject, quoteList(added))
+		}
+	}
+}
+
+// compareRequestBody сравнивает наличие тела запроса и обязательные поля его схемы
+func compareRequestBody(prev, ep parser.Endpoint, add adder) {
+	switch {
+	case prev.RequestBody == nil && ep.RequestBody == nil:
+		return
+	case prev.RequestBody == nil:
+		if ep.RequestBody.Required {
+			add(KindAdded, true, "required request body added")
+		} else {
+			add(KindAdded, false, "optional request body added")
+		}
+		return
+	case ep.RequestBody == nil:
+		add(KindRemoved, true, "request body removed")
+		return
+	}
+	if ep.RequestBody.Required && !prev.RequestBody.Required {
+		add(KindChanged, true, "request body became required")
+	}
+
+	before := bodySchema(prev.RequestBody.Content)
+	after := bodySchema(ep.RequestBody.Content)
+	if before == nil || after == nil {
+		return
+	}
+	for _, field := range after.Required {
+		if !slices.Contains(before.Required, field) {
+			add(KindChanged, true, "request body field %q became required", field)
+		}
+	}
+	for name, prop := range after.Properties {
+		if old, ok := before.Properties[name]; ok {
+			compareEnum(fmt.Sprintf("request body field %q", name), old.Enum, prop.Enum, add)
+		}
+	}
+}
+
+// compareResponses сравнивает коды ответов и поля успешного ответа
+func compareResponses(prev, ep parser.Endpoint, add adder) {
+	for code := range prev.Responses {
+		if _, ok := ep.Responses[code]; !ok {
+			add(KindRemoved, strings.HasPrefix(code, "2"), "response %s removed", code)
+		}
+	}
+	for code, resp := range ep.Responses {
+		old, ok := prev.Responses[code]
+		if !ok {
+			add(KindAdded, false, "response %s added", code)
+			continue
+		}
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		before := bodySchema(old.Content)
+		after := bodySchema(resp.Content)
+		if before == nil || after == nil {
+			continue
+		}
+		for name := range before.Properties {
+			if _, ok := after.Properties[name]; !ok {
+				add(KindRemoved, true, "response %s field %q removed", code, name)
+			}
+		}
+		for name := range after.Properties {
+			if _, ok := before.Properties[name]; !ok {
+				add(KindAdded, false, "response %s field %q added", code, name)
+			}
+		}
+	}
+}
+
+// bodySchema возвращает схему JSON-содержимого, а без него — первого типа по алфавиту
+func bodySchema(content map[string]parser.MediaType) *parser.Schema {
+	if media, ok := content["application/json"]; ok {
+		return media.Schema
+	}
+	for _, contentType := range slices.Sorted(maps.Keys(content)) {
+		return content[contentType].Schema
+	}
+	return nil
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "`" + v + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// changelogKinds — порядок секций журнала изменений (как в Keep a Changelog)
+var changelogKinds = []struct{ kind, title string }{
+	{KindAdded, "Added"},
+	{KindChanged, "Changed"},
+	{KindDeprecated, "Deprecated"},
+	{KindRemoved, "Removed"},
+}
+
+// Changelog оформляет изменения секцией Markdown для llms.txt
+func Changelog(changes []Change, since string) string {
+	var sb strings.Builder
+	sb.WriteString("## Changelog\n\n")
+	if len(changes) == 0 {
+		sb.WriteString("No API changes since " + since + ".\n")
+		return sb.String()
+	}
+	sb.WriteString("Changes since " + since + ":\n")
+	for _, section := range changelogKinds {
+		var lines []string
+		for _, c := range changes {
+			if c.Kind == section.kind {
+				line := "- `" + c.Operation + "`: " + c.Message
+				if c.Breaking {
+					line += " **(breaking)**"
+				}
+				lines = append(lines, line+"\n")
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		sb.WriteString("\n### " + section.title + "\n\n")
+		sb.WriteString(strings.Join(lines, ""))
+	}
+	return sb.String()
+}
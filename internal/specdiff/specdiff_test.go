@@ -0,0 +1,73 @@
+package specdiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mdwit/spec2llms/internal/parser"
+)
+
+func TestCompare(t *testing.T) {
+	userSchema := func(props ...string) *parser.Schema {
+		s := &parser.Schema{Type: "object", Properties: map[string]*parser.Schema{}}
+		for _, p := range props {
+			s.Properties[p] = &parser.Schema{Type: "string"}
+		}
+		return s
+	}
+	old := &parser.API{Endpoints: []parser.Endpoint{
+		{Method: "GET", Path: "/users", Summary: "List users",
+			Parameters: []parser.Parameter{{Name: "status", In: "query", Enum: []string{"active", "banned"}}},
+			Responses: map[string]parser.Response{"200": {Content: map[string]parser.MediaType{
+				"application/json": {Schema: userSchema("id", "email")},
+			}}}},
+		{Method: "DELETE", Path: "/users/{id}", Summary: "Delete user"},
+	}}
+	new := &parser.API{Endpoints: []parser.Endpoint{
+		{Method: "GET", Path: "/users", Summary: "List users", Deprecated: true,
+			Parameters: []parser.Parameter{
+				{Name: "status", In: "query", Enum: []string{"active"}},
+				{Name: "org", In: "query", Required: true},
+			},
+			Responses: map[string]parser.Response{
+				"200": {Content: map[string]parser.MediaType{"application/json": {Schema: userSchema("id", "name")}}},
+				"429": {},
+			}},
+		{Method: "POST", Path: "/users", Summary: "Create user",
+			RequestBody: &parser.RequestBody{Required: true}},
+	}}
+
+	var got []string
+	for _, c := range Compare(old, new) {
+		line := c.Kind + " " + c.Operation + ": " + c.Message
+		if c.Breaking {
+			line += " [breaking]"
+		}
+		got = append(got, line)
+	}
+	expected := []string{
+		`removed DELETE /users/{id}: operation removed (Delete user) [breaking]`,
+		`added GET /users: required query parameter "org" added [breaking]`,
+		`added GET /users: response 200 field "name" added`,
+		`added GET /users: response 429 added`,
+		"changed GET /users: query parameter \"status\" no longer accepts values `banned` [breaking]",
+		`deprecated GET /users: operation deprecated`,
+		`removed GET /users: response 200 field "email" removed [breaking]`,
+		`added POST /users: operation added (Create user)`,
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected changes:\n%s", strings.Join(got, "\n"))
+	}
+
+	changelog := Changelog(Compare(old, new), "v1.2.0")
+	if !strings.HasPrefix(changelog, "## Changelog\n\nChanges since v1.2.0:\n\n### Added\n\n") ||
+		!strings.Contains(changelog, "### Removed\n\n- `DELETE /users/{id}`: operation removed (Delete user) **(breaking)**\n") {
+		t.Errorf("unexpected changelog:\n%s", changelog)
+	}
+	if !HasBreaking(Compare(old, new)) || HasBreaking(Compare(old, old)) {
+		t.Error("unexpected breaking classification")
+	}
+	if got := Changelog(nil, "v1.2.0"); got != "## Changelog\n\nNo API changes since v1.2.0.\n" {
+		t.Errorf("unexpected empty changelog: %q", got)
+	}
+}
@@ -0,0 +1,223 @@
+// Package textdiff строит построчный unified diff двух текстов
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// context — число неизменённых строк вокруг изменений в hunk
+const context = 3
+
+// maxEdits ограничивает поиск кратчайшего diff; при большем числе правок
+// тексты считаются заменёнными целиком, чтобы не расходовать память квадратично
+const maxEdits = 4000
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type op struct {
+	kind opKind
+	line string
+}
+
+// Unified возвращает diff в формате diff -u или пустую строку, если тексты совпадают
+func Unified(oldName, newName, a, b string) string {
+	if a == b {
+		return ""
+	}
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	var sb strings.Builder
+	sb.WriteString("--- " + oldName + "\n")
+	sb.WriteString("+++ " + newName + "\n")
+
+	// Группируем изменения в hunk'и с контекстом вокруг
+	for i := 0; i < len(ops); {
+		if ops[i].kind == opEqual {
+			i++
+			continue
+		}
+		start := max(i-context, 0)
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != opEqual {
+				end++
+				continue
+			}
+			// Конец hunk'а — когда до следующего изменения больше двух контекстов
+			run := end
+			for run < len(ops) && ops[run].kind == opEqual {
+				run++
+			}
+			if run == len(ops) || run-end > 2*context {
+				end = min(end+context, len(ops))
+				break
+			}
+			end = run
+		}
+		writeHunk(&sb, ops, start, end)
+		i = end
+	}
+	return sb.String()
+}
+
+// writeHunk записывает hunk ops[start:end] с заголовком @@ -l,s +l,s @@
+func writeHunk(sb *strings.Builder, ops []op, start, end int) {
+	oldLine, newLine := 1, 1
+	for _, o := range ops[:start] {
+		if o.kind != opInsert {
+			oldLine++
+		}
+		if o.kind != opDelete {
+			newLine++
+		}
+	}
+	var oldCount, newCount int
+	var body strings.Builder
+	for _, o := range ops[start:end] {
+		switch o.kind {
+		case opEqual:
+			oldCount++
+			newCount++
+			body.WriteString(" " + o.line + "\n")
+		case opDelete:
+			oldCount++
+			body.WriteString("-" + o.line + "\n")
+		case opInsert:
+			newCount++
+			body.WriteString("+" + o.line + "\n")
+		}
+	}
+	// Как в diff -u: для пустого диапазона указывается строка перед ним
+	if oldCount == 0 {
+		oldLine--
+	}
+	if newCount == 0 {
+		newLine--
+	}
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", oldLine, oldCount, newLine, newCount)
+	sb.WriteString(body.String())
+}
+
+// splitLines разбивает текст на строки без завершающих переводов строки
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines строит кратчайшую последовательность правок алгоритмом Майерса
+func diffLines(a, b []string) []op {
+	// Общие начало и конец не участвуют в поиске
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var ops []op
+	for _, line := range a[:prefix] {
+		ops = append(ops, op{opEqual, line})
+	}
+	ops = append(ops, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, op{opEqual, line})
+	}
+	return ops
+}
+
+func myers(a, b []string) []op {
+	n, m := len(a), len(b)
+	offset := n + m
+	v := make([]int, 2*offset+2)
+	var trace [][]int
+
+	for d := 0; d <= n+m; d++ {
+		if d > maxEdits {
+			return replaceAll(a, b)
+		}
+		// Сохраняем только диапазон диагоналей [-d, d], нужный для обратного прохода
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace)
+			}
+		}
+	}
+	return replaceAll(a, b)
+}
+
+// backtrack восстанавливает правки по сохранённым состояниям диагоналей
+func backtrack(a, b []string, trace [][]int) []op {
+	x, y := len(a), len(b)
+	var ops []op
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		at := func(k int) int { return v[k+d] }
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		var prevX int
+		if d > 0 {
+			prevX = at(prevK)
+		}
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, op{opEqual, a[x]})
+		}
+		if d > 0 {
+			if x == prevX {
+				y--
+				ops = append(ops, op{opInsert, b[y]})
+			} else {
+				x--
+				ops = append(ops, op{opDelete, a[x]})
+			}
+		}
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+func replaceAll(a, b []string) []op {
+	ops := make([]op, 0, len(a)+len(b))
+	for _, line := range a {
+		ops = append(ops, op{opDelete, line})
+	}
+	for _, line := range b {
+		ops = append(ops, op{opInsert, line})
+	}
+	return ops
+}
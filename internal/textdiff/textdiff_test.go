@@ -0,0 +1,70 @@
+package textdiff
+
+import "testing"
+
+func TestUnified(t *testing.T) {
+	a := "# API\n\n## Endpoints\n- GET /users\n- GET /orders\n\n## Auth\nBearer\n"
+	b := "# API\n\n## Endpoints\n- GET /users\n- POST /users\n- GET /orders\n\n## Auth\nBearer\n"
+
+	want := `--- a/llms.txt
++++ b/llms.txt
+@@ -2,6 +2,7 @@
+ 
+ ## Endpoints
+ - GET /users
++- POST /users
+ - GET /orders
+ 
+ ## Auth
+`
+	if got := Unified("a/llms.txt", "b/llms.txt", a, b); got != want {
+		t.Errorf("unexpected diff:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := Unified("a", "b", a, a); got != "" {
+		t.Errorf("expected empty diff for equal texts, got:\n%s", got)
+	}
+}
+
+func TestUnifiedNewFile(t *testing.T) {
+	want := "--- /dev/null\n+++ b/x.txt\n@@ -0,0 +1,2 @@\n+one\n+two\n"
+	if got := Unified("/dev/null", "b/x.txt", "", "one\ntwo\n"); got != want {
+		t.Errorf("unexpected diff:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestDiffLinesMinimal(t *testing.T) {
+	a := []string{"a", "b", "c", "a", "b", "b", "a"}
+	b := []string{"c", "b", "a", "b", "a", "c"}
+	edits := 0
+	var oldLines, newLines []string
+	for _, o := range diffLines(a, b) {
+		switch o.kind {
+		case opEqual:
+			oldLines = append(oldLines, o.line)
+			newLines = append(newLines, o.line)
+		case opDelete:
+			edits++
+			oldLines = append(oldLines, o.line)
+		case opInsert:
+			edits++
+			newLines = append(newLines, o.line)
+		}
+	}
+	if edits != 5 {
+		t.Errorf("expected 5 edits, got %d", edits)
+	}
+	if len(oldLines) != len(a) || len(newLines) != len(b) {
+		t.Fatalf("edit script does not reproduce inputs")
+	}
+	for i := range a {
+		if oldLines[i] != a[i] {
+			t.Errorf("old line %d: %q != %q", i, oldLines[i], a[i])
+		}
+	}
+	for i := range b {
+		if newLines[i] != b[i] {
+			t.Errorf("new line %d: %q != %q", i, newLines[i], b[i])
+		}
+	}
+}
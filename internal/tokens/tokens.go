@@ -0,0 +1,94 @@
+// Package tokens оценивает число токенов в тексте для разных семейств моделей.
+// Точные BPE-словари не встраиваются: оценки строятся эвристически по классам символов,
+// с коэффициентами, подобранными под каждый токенизатор
+package tokens
+
+import (
+	"fmt"
+	"sort"
+	"unicode"
+)
+
+// Estimator оценивает число токенов в тексте
+type Estimator interface {
+	Name() string
+	Count(text string) int
+}
+
+// registry — зарегистрированные токенизаторы по имени
+var registry = map[string]Estimator{}
+
+// Register регистрирует токенизатор; одноимённый заменяется
+func Register(e Estimator) {
+	registry[e.Name()] = e
+}
+
+// Default — имя токенизатора по умолчанию
+const Default = "cl100k"
+
+// Get возвращает токенизатор по имени; пустое имя — токенизатор по умолчанию
+func Get(name string) (Estimator, error) {
+	if name == "" {
+		name = Default
+	}
+	if e, ok := registry[name]; ok {
+		return e, nil
+	}
+	return nil, fmt.Errorf("unknown tokenizer %q (available: %v)", name, Names())
+}
+
+// Names возвращает имена зарегистрированных токенизаторов по алфавиту
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	// Коэффициенты: сколько символов в среднем приходится на токен. Это приближения
+	// к cl100k, o200k и llama, а не их словари: точные числа токенов могут отличаться
+	Register(heuristic{name: "cl100k", latin: 4.0, other: 1.6, punct: 0.8})
+	Register(heuristic{name: "o200k", latin: 4.3, other: 2.6, punct: 0.7})
+	Register(heuristic{name: "llama", latin: 3.6, other: 1.4, punct: 0.9})
+	Register(bytesEstimator{})
+}
+
+// heuristic оценивает токены по классам символов: латиница и цифры кодируются
+// длинными токенами, прочие письменности (кириллица, CJK) — короткими,
+// а знаки препинания JSON и Markdown почти всегда отдельными токенами
+type heuristic struct {
+	name  string
+	latin float64 // символов латиницы/цифр на токен
+	other float64 // символов прочих письменностей на токен
+	punct float64 // токенов на знак препинания
+}
+
+func (h heuristic) Name() string { return h.name }
+
+func (h heuristic) Count(text string) int {
+	var latin, other, punct int
+	for _, r := range text {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			latin++
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			other++
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			punct++
+		}
+	}
+	estimate := float64(latin)/h.latin + float64(other)/h.other + float64(punct)*h.punct
+	return int(estimate + 0.5)
+}
+
+// bytesEstimator — грубая оценка без учёта модели: 4 байта на токен
+type bytesEstimator struct{}
+
+func (bytesEstimator) Name() string { return "bytes" }
+
+func (bytesEstimator) Count(text string) int {
+	return (len(text) + 3) / 4
+}
@@ -0,0 +1,35 @@
+package tokens
+
+import "testing"
+
+func TestGet(t *testing.T) {
+	for _, name := range []string{"", "cl100k", "o200k", "llama", "bytes"} {
+		if _, err := Get(name); err != nil {
+			t.Errorf("Get(%q) failed: %v", name, err)
+		}
+	}
+	if _, err := Get("gpt-2"); err == nil {
+		t.Error("expected error for unknown tokenizer")
+	}
+}
+
+func TestCount(t *testing.T) {
+	english := "Returns a paginated list of users."
+	russian := "Возвращает постраничный список пользователей."
+
+	cl100k, _ := Get("cl100k")
+	o200k, _ := Get("o200k")
+	if cl100k.Count(english) == 0 {
+		t.Error("estimate should be positive for non-empty text")
+	}
+	// o200k кодирует кириллицу заметно эффективнее cl100k
+	if o200k.Count(russian) >= cl100k.Count(russian) {
+		t.Errorf("o200k should estimate fewer tokens for Russian: o200k=%d cl100k=%d",
+			o200k.Count(russian), cl100k.Count(russian))
+	}
+
+	bytes, _ := Get("bytes")
+	if got := bytes.Count("12345678"); got != 2 {
+		t.Errorf("bytes estimate: expected 2, got %d", got)
+	}
+}